@@ -530,3 +530,26 @@ type DeleteNoteRequest struct {
 type DeleteNoteResponse struct {
 	Success bool `json:"success"`
 }
+
+// FullTextSearchRequest represents a keyword search request over document metadata.
+//
+//easyjson:json
+type FullTextSearchRequest struct {
+	Query string `json:"query"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// FullTextMatch represents a single document matched by full-text search.
+//
+//easyjson:json
+type FullTextMatch struct {
+	DocumentID uuid.UUID `json:"document_id"`
+	Rank       float32   `json:"rank"`
+}
+
+// FullTextSearchResponse represents matches ordered by descending rank.
+//
+//easyjson:json
+type FullTextSearchResponse struct {
+	Matches []FullTextMatch `json:"matches"`
+}
@@ -22,10 +22,12 @@ const (
 	ErrCodeForbidden      ErrorCode = "forbidden"
 )
 
-// ErrorResponse represents a standard error response
+// ErrorResponse represents a standard error response.
+// Error carries an ErrorCode, Message an optional human readable description.
 //
 //easyjson:json
 type ErrorResponse struct {
 	Success bool   `json:"success"`
 	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
 }
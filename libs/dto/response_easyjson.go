@@ -138,6 +138,12 @@ func easyjson6ff3ac1dDecodeGithubComArtmexbetRaibecasLibsDto1(in *jlexer.Lexer,
 			} else {
 				out.Error = string(in.String())
 			}
+		case "message":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Message = string(in.String())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -162,6 +168,11 @@ func easyjson6ff3ac1dEncodeGithubComArtmexbetRaibecasLibsDto1(out *jwriter.Write
 		out.RawString(prefix)
 		out.String(string(in.Error))
 	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
 	out.RawByte('}')
 }
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/mailru/easyjson"
 	"github.com/nats-io/nats.go"
@@ -17,6 +18,11 @@ import (
 type Message struct {
 	*nats.Msg
 	Ctx context.Context
+
+	// replyErr - ошибка, отправленная клиенту в ответе (учитывается в статистике сервиса)
+	replyErr error
+	// pattern - subject подписки с wildcard, по которой пришло сообщение (метка метрик)
+	pattern string
 }
 
 // UnmarshalData десериализует данные сообщения в структуру (legacy, использует encoding/json)
@@ -62,6 +68,7 @@ type Client struct {
 	logger      *slog.Logger
 	propagator  propagation.TextMapPropagator
 	tracer      trace.Tracer
+	metrics     *Metrics
 }
 
 // ClientOption - опция для конфигурации клиента
@@ -123,52 +130,46 @@ func WithTracer(tracer trace.Tracer) ClientOption {
 // Subscribe подписывается на subject
 func (c *Client) Subscribe(subject string, handler HandlerFunc) (*nats.Subscription, error) {
 	return c.conn.Subscribe(subject, func(msg *nats.Msg) {
-		// Извлекаем контекст с trace информацией
-		ctx := c.extractContext(msg)
-
-		ctx, span := c.tracer.Start(ctx, fmt.Sprintf("nats.handle %s", msg.Subject))
-		defer span.End()
-
-		// Создаём обёртку с контекстом
-		message := &Message{
-			Msg: msg,
-			Ctx: ctx,
-		}
-
-		// Применяем middleware
-		finalHandler := c.applyMiddlewares(handler)
-
-		if err := finalHandler(message); err != nil {
-			c.logger.Error("handler error",
-				"subject", subject,
-				"error", err,
-			)
-		}
+		_ = c.dispatch(msg, handler, "subject", subject)
 	})
 }
 
 // QueueSubscribe подписывается на subject с queue group
 func (c *Client) QueueSubscribe(subject, queue string, handler HandlerFunc) (*nats.Subscription, error) {
 	return c.conn.QueueSubscribe(subject, queue, func(msg *nats.Msg) {
-		ctx := c.extractContext(msg)
-
-		message := &Message{
-			Msg: msg,
-			Ctx: ctx,
-		}
-
-		finalHandler := c.applyMiddlewares(handler)
-
-		if err := finalHandler(message); err != nil {
-			c.logger.Error("handler error",
-				"subject", subject,
-				"queue", queue,
-				"error", err,
-			)
-		}
+		_ = c.dispatch(msg, handler, "subject", subject, "queue", queue)
 	})
 }
 
+// dispatch обрабатывает входящее сообщение: восстанавливает trace context,
+// открывает span и вызывает обработчик с middleware. logArgs дополняют лог ошибки обработчика.
+// Возвращает ошибку обработчика или ошибку, отправленную клиенту в ответе
+func (c *Client) dispatch(msg *nats.Msg, handler HandlerFunc, logArgs ...any) error {
+	// Извлекаем контекст с trace информацией
+	ctx := c.extractContext(msg)
+
+	ctx, span := c.tracer.Start(ctx, fmt.Sprintf("nats.handle %s", msg.Subject))
+	defer span.End()
+
+	// Создаём обёртку с контекстом
+	message := &Message{
+		Msg: msg,
+		Ctx: ctx,
+	}
+	if msg.Sub != nil {
+		message.pattern = msg.Sub.Subject
+	}
+
+	// Применяем middleware
+	finalHandler := c.applyMiddlewares(handler)
+
+	if err := finalHandler(message); err != nil {
+		c.logger.Error("handler error", append(logArgs, "error", err)...)
+		return err
+	}
+	return message.replyErr
+}
+
 // Publish публикует сообщение с автоматической пропагацией trace context
 func (c *Client) Publish(ctx context.Context, subject string, data []byte) error {
 	msg := nats.NewMsg(subject)
@@ -177,7 +178,9 @@ func (c *Client) Publish(ctx context.Context, subject string, data []byte) error
 	// Инжектируем trace context в headers
 	c.propagator.Inject(ctx, &headerCarrier{header: msg.Header})
 
-	return c.conn.PublishMsg(msg)
+	err := c.conn.PublishMsg(msg)
+	c.metrics.observeSent(subject, metricsKindPublish, len(data), err)
+	return err
 }
 
 // PublishMsg публикует готовое сообщение с пропагацией trace context
@@ -185,7 +188,9 @@ func (c *Client) PublishMsg(ctx context.Context, msg *nats.Msg) error {
 	// Инжектируем trace context в headers
 	c.propagator.Inject(ctx, &headerCarrier{header: msg.Header})
 
-	return c.conn.PublishMsg(msg)
+	err := c.conn.PublishMsg(msg)
+	c.metrics.observeSent(msg.Subject, metricsKindPublish, len(msg.Data), err)
+	return err
 }
 
 // RequestMsg выполняет синхронный request-reply с пропагацией trace
@@ -197,7 +202,9 @@ func (c *Client) RequestMsg(ctx context.Context, msg *nats.Msg) (*Message, error
 	// Инжектируем trace context в headers
 	c.propagator.Inject(ctx, &headerCarrier{header: msg.Header})
 
+	start := time.Now()
 	resp, err := c.conn.RequestMsgWithContext(ctx, msg)
+	c.metrics.observeRequest(msg.Subject, len(msg.Data), time.Since(start), replyData(resp), err)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -0,0 +1,313 @@
+package natsw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Headers describing why a message was moved to a dead-letter subject.
+const (
+	HeaderDLQSubject    = "X-DLQ-Subject"
+	HeaderDLQStream     = "X-DLQ-Stream"
+	HeaderDLQConsumer   = "X-DLQ-Consumer"
+	HeaderDLQSequence   = "X-DLQ-Sequence"
+	HeaderDLQDeliveries = "X-DLQ-Deliveries"
+	HeaderDLQReason     = "X-DLQ-Reason"
+	HeaderDLQError      = "X-DLQ-Error"
+	HeaderDLQFailedAt   = "X-DLQ-Failed-At"
+
+	// dlqHeaderPrefix is stripped from a dead letter when it is replayed.
+	dlqHeaderPrefix = "X-DLQ-"
+	// natsHeaderPrefix marks server-interpreted headers (Nats-Msg-Id, Nats-Expected-*)
+	// that must not follow a message into another stream.
+	natsHeaderPrefix = "Nats-"
+
+	// deadLetterAttempts is how many times a message that will not be redelivered
+	// is published to the dead-letter subject before it is given up.
+	deadLetterAttempts = 3
+	// deadLetterRetryDelay is the pause before the second attempt, doubled after each failure.
+	deadLetterRetryDelay = 100 * time.Millisecond
+)
+
+// Reasons stored in HeaderDLQReason.
+const (
+	DeadLetterReasonPermanent  = "permanent"
+	DeadLetterReasonMaxDeliver = "max_deliver"
+)
+
+// PermanentError marks a handler error that redelivery cannot fix, such as a
+// malformed payload. ConsumeStream moves such messages to the dead-letter
+// subject on the first failure instead of retrying them.
+type PermanentError struct {
+	Err error
+}
+
+// Permanent wraps err as a permanent error. A nil err stays nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanent reports whether err or any error it wraps is a PermanentError.
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+// DeadLetter is a message stored in a dead-letter stream.
+type DeadLetter struct {
+	// Sequence is the position of the dead letter in the dead-letter stream.
+	Sequence uint64
+	// Subject is the subject the message was originally published to.
+	Subject    string
+	Stream     string
+	Consumer   string
+	Deliveries uint64
+	Reason     string
+	Error      string
+	FailedAt   time.Time
+	Header     nats.Header
+	Data       []byte
+}
+
+// handleFailure NAKs a failed message with backoff or, once retrying is
+// pointless, moves it to the dead-letter subject and terminates it.
+func (jsc *JetStreamContext) handleFailure(ctx context.Context, cfg ConsumerConfig, jsMsg jetstream.Msg, err error) {
+	var delivered uint64 = 1
+	if meta, metaErr := jsMsg.Metadata(); metaErr == nil {
+		delivered = meta.NumDelivered
+	}
+
+	permanent := IsPermanent(err)
+	exhausted := cfg.MaxDeliver > 0 && delivered >= uint64(cfg.MaxDeliver)
+	if !permanent && !exhausted {
+		delay := cfg.backoff(delivered)
+		jsc.logger.Warn("jetstream handler error, nacking message",
+			"subject", jsMsg.Subject(),
+			"consumer", cfg.Durable,
+			"delivered", delivered,
+			"delay", delay,
+			"error", err,
+		)
+		if nakErr := jsMsg.NakWithDelay(delay); nakErr != nil {
+			jsc.logger.Error("failed to nak message", "error", nakErr)
+			return
+		}
+		jsc.client.metrics.observeJetStream(cfg, metricsJetStreamNak)
+		return
+	}
+
+	reason := DeadLetterReasonMaxDeliver
+	if permanent {
+		reason = DeadLetterReasonPermanent
+	}
+
+	if cfg.DeadLetterSubject == "" {
+		jsc.logger.Error("jetstream handler error, dropping message",
+			"subject", jsMsg.Subject(),
+			"consumer", cfg.Durable,
+			"delivered", delivered,
+			"reason", reason,
+			"error", err,
+		)
+	} else {
+		attempts := 1
+		if exhausted {
+			// Nothing redelivers the message after this attempt, so publishing is retried here
+			attempts = deadLetterAttempts
+		}
+		if dlqErr := jsc.publishDeadLetterRetry(ctx, cfg, jsMsg, delivered, reason, err, attempts); dlqErr != nil {
+			if !exhausted {
+				// The redelivery retries moving the message to the dead-letter subject
+				jsc.logger.Error("failed to move message to dead-letter subject, nacking",
+					"subject", jsMsg.Subject(),
+					"dead_letter_subject", cfg.DeadLetterSubject,
+					"error", dlqErr,
+				)
+				if nakErr := jsMsg.NakWithDelay(cfg.backoff(delivered)); nakErr != nil {
+					jsc.logger.Error("failed to nak message", "error", nakErr)
+					return
+				}
+				jsc.client.metrics.observeJetStream(cfg, metricsJetStreamNak)
+				return
+			}
+			// JetStream does not redeliver after MaxDeliver, so neither a nak nor a term helps.
+			// The message is left unacknowledged in the stream where it can still be found by its sequence.
+			jsc.logger.Error("failed to move message to dead-letter subject, message lost",
+				"subject", jsMsg.Subject(),
+				"consumer", cfg.Durable,
+				"dead_letter_subject", cfg.DeadLetterSubject,
+				"delivered", delivered,
+				"handler_error", err,
+				"error", dlqErr,
+			)
+			jsc.client.metrics.observeJetStream(cfg, metricsJetStreamLost)
+			return
+		}
+		jsc.logger.Error("jetstream handler error, message moved to dead-letter subject",
+			"subject", jsMsg.Subject(),
+			"consumer", cfg.Durable,
+			"dead_letter_subject", cfg.DeadLetterSubject,
+			"delivered", delivered,
+			"reason", reason,
+			"error", err,
+		)
+	}
+
+	if termErr := jsMsg.TermWithReason(reason); termErr != nil {
+		jsc.logger.Error("failed to terminate message", "error", termErr)
+		return
+	}
+	jsc.client.metrics.observeJetStream(cfg, metricsJetStreamTerm)
+}
+
+// publishDeadLetterRetry calls publishDeadLetter up to attempts times with a growing pause.
+func (jsc *JetStreamContext) publishDeadLetterRetry(ctx context.Context, cfg ConsumerConfig, jsMsg jetstream.Msg, delivered uint64, reason string, handlerErr error, attempts int) error {
+	delay := deadLetterRetryDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = jsc.publishDeadLetter(ctx, cfg, jsMsg, delivered, reason, handlerErr); err == nil || attempt >= attempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// publishDeadLetter stores a copy of the message with the failure in X-DLQ-* headers.
+func (jsc *JetStreamContext) publishDeadLetter(ctx context.Context, cfg ConsumerConfig, jsMsg jetstream.Msg, delivered uint64, reason string, handlerErr error) error {
+	msg := nats.NewMsg(cfg.DeadLetterSubject)
+	msg.Data = jsMsg.Data()
+	for key, values := range jsMsg.Headers() {
+		if !strings.HasPrefix(key, natsHeaderPrefix) {
+			msg.Header[key] = values
+		}
+	}
+
+	msg.Header.Set(HeaderDLQSubject, jsMsg.Subject())
+	msg.Header.Set(HeaderDLQStream, cfg.Stream)
+	msg.Header.Set(HeaderDLQConsumer, cfg.Durable)
+	if meta, err := jsMsg.Metadata(); err == nil {
+		msg.Header.Set(HeaderDLQSequence, strconv.FormatUint(meta.Sequence.Stream, 10))
+	}
+	msg.Header.Set(HeaderDLQDeliveries, strconv.FormatUint(delivered, 10))
+	msg.Header.Set(HeaderDLQReason, reason)
+	msg.Header.Set(HeaderDLQError, handlerErr.Error())
+	msg.Header.Set(HeaderDLQFailedAt, time.Now().UTC().Format(time.RFC3339Nano))
+
+	if _, err := jsc.js.PublishMsg(ctx, msg); err != nil {
+		return fmt.Errorf("jetstream publish to %q: %w", cfg.DeadLetterSubject, err)
+	}
+	return nil
+}
+
+// DeadLetters returns up to limit dead letters from the stream, oldest first.
+// A non-positive limit returns all of them.
+func (jsc *JetStreamContext) DeadLetters(ctx context.Context, stream string, limit int) ([]DeadLetter, error) {
+	s, err := jsc.js.Stream(ctx, stream)
+	if err != nil {
+		return nil, fmt.Errorf("get stream %q: %w", stream, err)
+	}
+	info, err := s.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get stream %q info: %w", stream, err)
+	}
+
+	var letters []DeadLetter
+	if info.State.Msgs == 0 {
+		return letters, nil
+	}
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq; seq++ {
+		if limit > 0 && len(letters) >= limit {
+			break
+		}
+		raw, err := s.GetMsg(ctx, seq)
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			// Deleted after a replay
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get message %d from stream %q: %w", seq, stream, err)
+		}
+		letters = append(letters, newDeadLetter(raw))
+	}
+	return letters, nil
+}
+
+// ReplayDeadLetter republishes the dead letter to its original subject without
+// the X-DLQ-* headers and deletes it from the dead-letter stream.
+func (jsc *JetStreamContext) ReplayDeadLetter(ctx context.Context, stream string, seq uint64) error {
+	s, err := jsc.js.Stream(ctx, stream)
+	if err != nil {
+		return fmt.Errorf("get stream %q: %w", stream, err)
+	}
+	raw, err := s.GetMsg(ctx, seq)
+	if err != nil {
+		return fmt.Errorf("get message %d from stream %q: %w", seq, stream, err)
+	}
+
+	letter := newDeadLetter(raw)
+	if letter.Subject == "" {
+		return fmt.Errorf("message %d in stream %q has no %s header", seq, stream, HeaderDLQSubject)
+	}
+
+	msg := nats.NewMsg(letter.Subject)
+	msg.Data = letter.Data
+	for key, values := range letter.Header {
+		if !strings.HasPrefix(key, dlqHeaderPrefix) && !strings.HasPrefix(key, natsHeaderPrefix) {
+			msg.Header[key] = values
+		}
+	}
+
+	if _, err := jsc.js.PublishMsg(ctx, msg); err != nil {
+		return fmt.Errorf("jetstream publish to %q: %w", letter.Subject, err)
+	}
+	if err := s.DeleteMsg(ctx, seq); err != nil {
+		return fmt.Errorf("delete message %d from stream %q: %w", seq, stream, err)
+	}
+	return nil
+}
+
+// newDeadLetter reads the failure description from the X-DLQ-* headers.
+func newDeadLetter(raw *jetstream.RawStreamMsg) DeadLetter {
+	header := raw.Header
+	if header == nil {
+		header = nats.Header{}
+	}
+	deliveries, _ := strconv.ParseUint(header.Get(HeaderDLQDeliveries), 10, 64)
+	failedAt, _ := time.Parse(time.RFC3339Nano, header.Get(HeaderDLQFailedAt))
+
+	return DeadLetter{
+		Sequence:   raw.Sequence,
+		Subject:    header.Get(HeaderDLQSubject),
+		Stream:     header.Get(HeaderDLQStream),
+		Consumer:   header.Get(HeaderDLQConsumer),
+		Deliveries: deliveries,
+		Reason:     header.Get(HeaderDLQReason),
+		Error:      header.Get(HeaderDLQError),
+		FailedAt:   failedAt,
+		Header:     header,
+		Data:       raw.Data,
+	}
+}
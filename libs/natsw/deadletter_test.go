@@ -0,0 +1,235 @@
+package natsw_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/artmexbet/raibecas/libs/natsw"
+)
+
+func connectJetStream(t *testing.T) *natsw.JetStreamContext {
+	t.Helper()
+	jsc, err := connectRPC(t).JetStream()
+	if err != nil {
+		t.Fatalf("JetStream: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := jsc.Raw().AccountInfo(ctx); err != nil {
+		t.Skipf("JetStream not available: %v", err)
+	}
+	return jsc
+}
+
+func ensureTestStream(t *testing.T, jsc *natsw.JetStreamContext, cfg natsw.StreamConfig) {
+	t.Helper()
+	ctx := context.Background()
+	_ = jsc.Raw().DeleteStream(ctx, cfg.Name)
+	if _, err := jsc.EnsureStream(ctx, cfg); err != nil {
+		t.Fatalf("EnsureStream %s: %v", cfg.Name, err)
+	}
+	t.Cleanup(func() { _ = jsc.Raw().DeleteStream(context.Background(), cfg.Name) })
+}
+
+func TestConsumeStream_DeadLettersAndReplay(t *testing.T) {
+	jsc := connectJetStream(t)
+	ctx := context.Background()
+
+	ensureTestStream(t, jsc, natsw.StreamConfig{
+		Name:      "TEST_DLQ_SOURCE",
+		Subjects:  []string{"test.dlq.source.*"},
+		Storage:   jetstream.MemoryStorage,
+		Retention: jetstream.WorkQueuePolicy,
+	})
+	ensureTestStream(t, jsc, natsw.StreamConfig{
+		Name:     "TEST_DLQ",
+		Subjects: []string{"test.dlq.dead"},
+		Storage:  jetstream.MemoryStorage,
+	})
+
+	var transientAttempts, permanentAttempts, replayed atomic.Int32
+	var healed atomic.Bool
+	consumeCtx, err := jsc.ConsumeStream(ctx, natsw.ConsumerConfig{
+		Stream:            "TEST_DLQ_SOURCE",
+		Durable:           "test-dlq-consumer",
+		AckWait:           5 * time.Second,
+		MaxDeliver:        3,
+		Backoff:           []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		DeadLetterSubject: "test.dlq.dead",
+	}, func(msg *natsw.Message) error {
+		switch msg.Subject {
+		case "test.dlq.source.transient":
+			if healed.Load() {
+				replayed.Add(1)
+				return nil
+			}
+			transientAttempts.Add(1)
+			return errors.New("database is down")
+		default:
+			permanentAttempts.Add(1)
+			return natsw.Permanent(errors.New("malformed payload"))
+		}
+	})
+	if err != nil {
+		t.Fatalf("ConsumeStream: %v", err)
+	}
+	defer consumeCtx.Stop()
+
+	for _, subject := range []string{"test.dlq.source.transient", "test.dlq.source.permanent"} {
+		if _, err := jsc.Publish(ctx, subject, []byte(`{"id":1}`)); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	letters := waitDeadLetters(t, jsc, "TEST_DLQ", 2)
+	if got := transientAttempts.Load(); got != 3 {
+		t.Errorf("Expected 3 attempts before dead-lettering, got %d", got)
+	}
+	if got := permanentAttempts.Load(); got != 1 {
+		t.Errorf("Expected permanent error to skip retries, got %d attempts", got)
+	}
+
+	reasons := make(map[string]natsw.DeadLetter)
+	for _, letter := range letters {
+		reasons[letter.Subject] = letter
+	}
+	transient := reasons["test.dlq.source.transient"]
+	if transient.Reason != natsw.DeadLetterReasonMaxDeliver || transient.Deliveries != 3 || transient.Error != "database is down" {
+		t.Errorf("Unexpected transient dead letter %+v", transient)
+	}
+	if transient.Stream != "TEST_DLQ_SOURCE" || transient.Consumer != "test-dlq-consumer" || string(transient.Data) != `{"id":1}` {
+		t.Errorf("Dead letter lost its origin %+v", transient)
+	}
+	if reasons["test.dlq.source.permanent"].Reason != natsw.DeadLetterReasonPermanent {
+		t.Errorf("Unexpected permanent dead letter %+v", reasons["test.dlq.source.permanent"])
+	}
+
+	healed.Store(true)
+	if err := jsc.ReplayDeadLetter(ctx, "TEST_DLQ", transient.Sequence); err != nil {
+		t.Fatalf("ReplayDeadLetter: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for replayed.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if replayed.Load() != 1 {
+		t.Error("Replayed message was not delivered to the consumer")
+	}
+
+	left, err := jsc.DeadLetters(ctx, "TEST_DLQ", 0)
+	if err != nil {
+		t.Fatalf("DeadLetters: %v", err)
+	}
+	if len(left) != 1 || left[0].Subject != "test.dlq.source.permanent" {
+		t.Errorf("Expected only the permanent dead letter to remain, got %+v", left)
+	}
+}
+
+func TestConsumeStream_DeadLetterPublishFailureKeepsMessage(t *testing.T) {
+	nc, err := nats.Connect(nats.DefaultURL)
+	if err != nil {
+		t.Skipf("NATS server not available: %v", err)
+	}
+	defer nc.Close()
+	reg := prometheus.NewRegistry()
+	jsc, err := natsw.NewClient(nc, natsw.WithMetrics(natsw.NewMetrics(reg))).JetStream()
+	if err != nil {
+		t.Fatalf("JetStream: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := jsc.Raw().AccountInfo(ctx); err != nil {
+		t.Skipf("JetStream not available: %v", err)
+	}
+
+	ensureTestStream(t, jsc, natsw.StreamConfig{
+		Name:      "TEST_DLQ_LOST_SOURCE",
+		Subjects:  []string{"test.dlq.lost.source"},
+		Storage:   jetstream.MemoryStorage,
+		Retention: jetstream.WorkQueuePolicy,
+	})
+
+	var attempts atomic.Int32
+	cfg := natsw.ConsumerConfig{
+		Stream:     "TEST_DLQ_LOST_SOURCE",
+		Durable:    "test-dlq-lost-consumer",
+		AckWait:    5 * time.Second,
+		MaxDeliver: 2,
+		Backoff:    []time.Duration{10 * time.Millisecond},
+		// No stream captures this subject, so every dead-letter publish fails
+		DeadLetterSubject: "test.dlq.lost.nowhere",
+	}
+	consumeCtx, err := jsc.ConsumeStream(ctx, cfg, func(*natsw.Message) error {
+		attempts.Add(1)
+		return errors.New("database is down")
+	})
+	if err != nil {
+		t.Fatalf("ConsumeStream: %v", err)
+	}
+	defer consumeCtx.Stop()
+
+	if _, err := jsc.Publish(ctx, "test.dlq.lost.source", []byte(`{"id":1}`)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	labels := map[string]string{"stream": cfg.Stream, "consumer": cfg.Durable, "result": "lost"}
+	deadline := time.Now().Add(5 * time.Second)
+	for metricValue(t, reg, "natsw_jetstream_acks_total", labels) == 0 && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if got := metricValue(t, reg, "natsw_jetstream_acks_total", labels); got != 1 {
+		t.Fatalf("Expected the message to be counted as lost once, got %v", got)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+
+	// Only the first failure is nacked; the exhausted message is neither nacked nor terminated
+	for _, result := range []string{"nak", "term"} {
+		labels["result"] = result
+		want := 0.0
+		if result == "nak" {
+			want = 1
+		}
+		if got := metricValue(t, reg, "natsw_jetstream_acks_total", labels); got != want {
+			t.Errorf("natsw_jetstream_acks_total{result=%q} = %v, want %v", result, got, want)
+		}
+	}
+
+	stream, err := jsc.Raw().Stream(ctx, cfg.Stream)
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	info, err := stream.Info(ctx)
+	if err != nil {
+		t.Fatalf("Stream info: %v", err)
+	}
+	if info.State.Msgs != 1 {
+		t.Errorf("Expected the message to stay in the stream, got %d messages", info.State.Msgs)
+	}
+}
+
+// waitDeadLetters ждёт, пока в DLQ stream окажется n сообщений
+func waitDeadLetters(t *testing.T, jsc *natsw.JetStreamContext, stream string, n int) []natsw.DeadLetter {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		letters, err := jsc.DeadLetters(context.Background(), stream, 0)
+		if err != nil {
+			t.Fatalf("DeadLetters: %v", err)
+		}
+		if len(letters) >= n || time.Now().After(deadline) {
+			if len(letters) != n {
+				t.Fatalf("Expected %d dead letters, got %d", n, len(letters))
+			}
+			return letters
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -27,6 +27,22 @@ type ConsumerConfig struct {
 	FilterSubject string
 	AckWait       time.Duration
 	MaxDeliver    int
+	// Backoff holds the NAK delays after consecutive failed deliveries; the last
+	// delay repeats. An empty schedule redelivers immediately.
+	Backoff []time.Duration
+	// DeadLetterSubject receives messages that failed permanently or exhausted
+	// MaxDeliver, with the failure described in X-DLQ-* headers. When empty such
+	// messages are terminated and only logged.
+	DeadLetterSubject string
+}
+
+// backoff returns the NAK delay after the given delivery attempt failed.
+func (cfg ConsumerConfig) backoff(delivered uint64) time.Duration {
+	if len(cfg.Backoff) == 0 {
+		return 0
+	}
+	idx := min(int(delivered), len(cfg.Backoff)) - 1
+	return cfg.Backoff[max(idx, 0)]
 }
 
 // JetStreamContext wraps the new JetStream API for stream/consumer management.
@@ -75,7 +91,10 @@ func (jsc *JetStreamContext) EnsureStream(ctx context.Context, cfg StreamConfig)
 
 // ConsumeStream creates a durable consumer and starts consuming messages.
 // The handler receives messages wrapped in natsw.Message with trace context.
-// On handler success the message is acked; on error it is nacked for redelivery.
+// On handler success the message is acked. On error it is nacked with the
+// cfg.Backoff delay, unless the error is permanent (see Permanent) or this was
+// the last of cfg.MaxDeliver attempts: then the message is moved to
+// cfg.DeadLetterSubject and terminated.
 func (jsc *JetStreamContext) ConsumeStream(
 	ctx context.Context,
 	cfg ConsumerConfig,
@@ -99,6 +118,12 @@ func (jsc *JetStreamContext) ConsumeStream(
 		"filter", cfg.FilterSubject,
 	)
 
+	// Метрики обработчика помечаются фильтром консьюмера, а не subject каждого сообщения
+	pattern := cfg.FilterSubject
+	if pattern == "" {
+		pattern = ">"
+	}
+
 	consumeCtx, err := consumer.Consume(func(jsMsg jetstream.Msg) {
 		// Extract headers for trace propagation
 		headers := jsMsg.Headers()
@@ -114,28 +139,24 @@ func (jsc *JetStreamContext) ConsumeStream(
 		defer span.End()
 
 		message := &Message{
-			Msg: rawMsg,
-			Ctx: msgCtx,
+			Msg:     rawMsg,
+			Ctx:     msgCtx,
+			pattern: pattern,
 		}
 
 		// Apply middleware chain
 		finalHandler := jsc.client.applyMiddlewares(handler)
 
 		if err := finalHandler(message); err != nil {
-			jsc.logger.Error("jetstream handler error, nacking message",
-				"subject", jsMsg.Subject(),
-				"consumer", cfg.Durable,
-				"error", err,
-			)
-			if nakErr := jsMsg.Nak(); nakErr != nil {
-				jsc.logger.Error("failed to nak message", "error", nakErr)
-			}
+			jsc.handleFailure(msgCtx, cfg, jsMsg, err)
 			return
 		}
 
 		if ackErr := jsMsg.Ack(); ackErr != nil {
 			jsc.logger.Error("failed to ack message", "error", ackErr)
+			return
 		}
+		jsc.client.metrics.observeJetStream(cfg, metricsJetStreamAck)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("start consuming %q: %w", cfg.Durable, err)
@@ -162,6 +183,7 @@ func (jsc *JetStreamContext) Publish(ctx context.Context, subject string, data [
 	jsc.client.propagator.Inject(ctx, &headerCarrier{header: msg.Header})
 
 	ack, err := jsc.js.PublishMsg(ctx, msg, opts...)
+	jsc.client.metrics.observeSent(subject, metricsKindPublish, len(data), err)
 	if err != nil {
 		return nil, fmt.Errorf("jetstream publish to %q: %w", subject, err)
 	}
@@ -0,0 +1,257 @@
+package natsw
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/artmexbet/raibecas/libs/dto"
+)
+
+const (
+	// Статусы обработки и отправки сообщений
+	metricsStatusOK    = "ok"
+	metricsStatusError = "error"
+
+	// Направления сообщений в natsw_message_size_bytes
+	metricsDirectionReceived = "received"
+	metricsDirectionSent     = "sent"
+
+	// Виды исходящих сообщений
+	metricsKindPublish = "publish"
+	metricsKindRequest = "request"
+
+	// Результаты обработки сообщений JetStream
+	metricsJetStreamAck  = "ack"
+	metricsJetStreamNak  = "nak"
+	metricsJetStreamTerm = "term"
+	// Сообщение после MaxDeliver не удалось переложить в dead-letter subject
+	metricsJetStreamLost = "lost"
+)
+
+// Metrics - Prometheus-метрики NATS трафика: обработчики, исходящие сообщения и JetStream.
+// Обработчики помечаются subject подписки (с wildcard), исходящие сообщения - subject,
+// который возвращает subjectLabel. Методы безопасно вызывать у nil, поэтому клиент без метрик ничего не записывает
+type Metrics struct {
+	subjectLabel func(subject string) string
+
+	handled         *prometheus.CounterVec
+	handlerErrors   *prometheus.CounterVec
+	handlerDuration *prometheus.HistogramVec
+	messageSize     *prometheus.HistogramVec
+	sent            *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	jetStreamAcks   *prometheus.CounterVec
+}
+
+// MetricsOption - опция Metrics
+type MetricsOption func(*Metrics)
+
+// WithSubjectLabel задаёт метку subject исходящих сообщений и запросов.
+// Нужна клиентам, которые публикуют в subject с идентификаторами (chat.response.<user_id>):
+// без неё каждый идентификатор становится отдельным временным рядом. См. SubjectPatterns
+func WithSubjectLabel(label func(subject string) string) MetricsOption {
+	return func(m *Metrics) {
+		m.subjectLabel = label
+	}
+}
+
+// SubjectPatterns возвращает функцию для WithSubjectLabel, которая заменяет subject
+// первым подходящим шаблоном с wildcard (* и >). Остальные subject не меняются
+func SubjectPatterns(patterns ...string) func(subject string) string {
+	return func(subject string) string {
+		for _, pattern := range patterns {
+			if subjectMatches(pattern, subject) {
+				return pattern
+			}
+		}
+		return subject
+	}
+}
+
+// subjectMatches проверяет subject по шаблону NATS: * - один токен, > - один и более последних
+func subjectMatches(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+	for i, token := range patternTokens {
+		if token == ">" {
+			return len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) || token != "*" && token != subjectTokens[i] {
+			return false
+		}
+	}
+	return len(patternTokens) == len(subjectTokens)
+}
+
+// NewMetrics регистрирует метрики natsw в reg (по умолчанию prometheus.DefaultRegisterer)
+func NewMetrics(reg prometheus.Registerer, opts ...MetricsOption) *Metrics {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	factory := promauto.With(reg)
+
+	m := &Metrics{
+		subjectLabel: func(subject string) string { return subject },
+
+		handled: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "natsw_handled_messages_total",
+			Help: "The total number of NATS messages processed by handlers",
+		}, []string{"subject", "status"}),
+
+		handlerErrors: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "natsw_handler_errors_total",
+			Help: "The total number of NATS handler errors by error code",
+		}, []string{"subject", "code"}),
+
+		handlerDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "natsw_handler_duration_seconds",
+			Help:    "A histogram of NATS handler duration",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"subject"}),
+
+		messageSize: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "natsw_message_size_bytes",
+			Help:    "A histogram of NATS message payload sizes",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 8),
+		}, []string{"subject", "direction"}),
+
+		sent: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "natsw_sent_messages_total",
+			Help: "The total number of published NATS messages and sent requests",
+		}, []string{"subject", "kind", "status"}),
+
+		requestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "natsw_request_duration_seconds",
+			Help:    "A histogram of NATS request-reply round trip duration",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"subject"}),
+
+		jetStreamAcks: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "natsw_jetstream_acks_total",
+			Help: "The total number of JetStream messages acked, nacked, terminated or lost by consumers",
+		}, []string{"stream", "consumer", "result"}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// MetricsMiddleware записывает число обработанных сообщений, ошибки по коду dto.ErrorCode,
+// длительность обработчика и размер входящих сообщений.
+// Ошибкой считается и ошибка обработчика, и конверт ошибки RPC, отправленный клиенту
+func MetricsMiddleware(m *Metrics) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(msg *Message) error {
+			start := time.Now()
+			err := next(msg)
+			m.observeHandled(msg, time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// WithMetrics добавляет MetricsMiddleware и записывает метрики исходящих сообщений клиента
+func WithMetrics(m *Metrics) ClientOption {
+	return func(c *Client) {
+		c.metrics = m
+		c.middlewares = append(c.middlewares, MetricsMiddleware(m))
+	}
+}
+
+// ServeMetrics запускает HTTP сервер с /metrics для сервисов без собственного HTTP API.
+// Сервер останавливается через Shutdown
+func ServeMetrics(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		slog.Info("starting metrics server", "addr", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("metrics server failed", "error", err)
+		}
+	}()
+
+	return srv
+}
+
+// observeHandled учитывает сообщение, обработанное подпиской
+func (m *Metrics) observeHandled(msg *Message, elapsed time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	subject := msg.pattern
+	if subject == "" {
+		subject = msg.Subject
+	}
+
+	m.handlerDuration.WithLabelValues(subject).Observe(elapsed.Seconds())
+	m.messageSize.WithLabelValues(subject, metricsDirectionReceived).Observe(float64(len(msg.Data)))
+
+	if err == nil {
+		err = msg.replyErr
+	}
+	if err == nil {
+		m.handled.WithLabelValues(subject, metricsStatusOK).Inc()
+		return
+	}
+
+	m.handled.WithLabelValues(subject, metricsStatusError).Inc()
+	code := ErrorCode(err)
+	if code == "" {
+		code = dto.ErrCodeInternal
+	}
+	m.handlerErrors.WithLabelValues(subject, string(code)).Inc()
+}
+
+// observeSent учитывает опубликованное сообщение или отправленный запрос
+func (m *Metrics) observeSent(subject, kind string, size int, err error) {
+	if m == nil {
+		return
+	}
+
+	subject = m.subjectLabel(subject)
+	status := metricsStatusOK
+	if err != nil {
+		status = metricsStatusError
+	}
+	m.sent.WithLabelValues(subject, kind, status).Inc()
+	m.messageSize.WithLabelValues(subject, metricsDirectionSent).Observe(float64(size))
+}
+
+// observeRequest учитывает запрос вместе с длительностью и размером ответа
+func (m *Metrics) observeRequest(subject string, size int, elapsed time.Duration, reply []byte, err error) {
+	if m == nil {
+		return
+	}
+
+	m.observeSent(subject, metricsKindRequest, size, err)
+	subject = m.subjectLabel(subject)
+	m.requestDuration.WithLabelValues(subject).Observe(elapsed.Seconds())
+	if err == nil {
+		m.messageSize.WithLabelValues(subject, metricsDirectionReceived).Observe(float64(len(reply)))
+	}
+}
+
+// observeJetStream учитывает подтверждение сообщения JetStream консьюмером
+func (m *Metrics) observeJetStream(cfg ConsumerConfig, result string) {
+	if m == nil {
+		return
+	}
+	m.jetStreamAcks.WithLabelValues(cfg.Stream, cfg.Durable, result).Inc()
+}
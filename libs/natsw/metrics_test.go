@@ -0,0 +1,165 @@
+package natsw_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/artmexbet/raibecas/libs/dto"
+	"github.com/artmexbet/raibecas/libs/natsw"
+)
+
+func TestMetrics_RecordsHandlersAndRequests(t *testing.T) {
+	nc, err := nats.Connect(nats.DefaultURL)
+	if err != nil {
+		t.Skipf("NATS server not available: %v", err)
+	}
+	defer nc.Close()
+
+	reg := prometheus.NewRegistry()
+	client := natsw.NewClient(nc, natsw.WithMetrics(natsw.NewMetrics(reg)))
+
+	endpoint := natsw.NewEndpoint[greetRequest, greetResponse]("test.metrics.greet")
+	sub, err := natsw.HandleRPC(client, endpoint, func(_ *natsw.Message, req *greetRequest) (*greetResponse, error) {
+		if req.Name == "" {
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
+		}
+		return &greetResponse{Greeting: req.Name}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	ctx := context.Background()
+	if _, err := natsw.Call(ctx, client, endpoint, &greetRequest{Name: "Фихте"}); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	_, _ = natsw.Call(ctx, client, endpoint, &greetRequest{})
+	if err := client.Publish(ctx, "test.metrics.event", []byte("{}")); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	// Метрики обработчика пишутся после ответа
+	time.Sleep(20 * time.Millisecond)
+
+	subject := endpoint.Subject
+	checks := []struct {
+		name   string
+		labels map[string]string
+		want   float64
+	}{
+		{"natsw_handled_messages_total", map[string]string{"subject": subject, "status": "ok"}, 1},
+		{"natsw_handled_messages_total", map[string]string{"subject": subject, "status": "error"}, 1},
+		{"natsw_handler_errors_total", map[string]string{"subject": subject, "code": string(dto.ErrCodeInvalidRequest)}, 1},
+		{"natsw_sent_messages_total", map[string]string{"subject": subject, "kind": "request", "status": "ok"}, 2},
+		{"natsw_sent_messages_total", map[string]string{"subject": "test.metrics.event", "kind": "publish", "status": "ok"}, 1},
+		{"natsw_handler_duration_seconds", map[string]string{"subject": subject}, 2},
+		{"natsw_request_duration_seconds", map[string]string{"subject": subject}, 2},
+		{"natsw_message_size_bytes", map[string]string{"subject": subject, "direction": "received"}, 4},
+	}
+	for _, check := range checks {
+		if got := metricValue(t, reg, check.name, check.labels); got != check.want {
+			t.Errorf("%s%v = %v, want %v", check.name, check.labels, got, check.want)
+		}
+	}
+}
+
+func TestMetrics_LabelsBySubjectPattern(t *testing.T) {
+	nc, err := nats.Connect(nats.DefaultURL)
+	if err != nil {
+		t.Skipf("NATS server not available: %v", err)
+	}
+	defer nc.Close()
+
+	reg := prometheus.NewRegistry()
+	pattern := "test.metrics.cancel.*"
+	client := natsw.NewClient(nc, natsw.WithMetrics(natsw.NewMetrics(reg, natsw.WithSubjectLabel(natsw.SubjectPatterns(pattern)))))
+
+	received := make(chan struct{}, 2)
+	sub, err := client.Subscribe(pattern, func(*natsw.Message) error {
+		received <- struct{}{}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	ctx := context.Background()
+	for _, userID := range []string{"u1", "u2"} {
+		if err := client.Publish(ctx, "test.metrics.cancel."+userID, nil); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	for range 2 {
+		select {
+		case <-received:
+		case <-time.After(time.Second):
+			t.Fatal("Message was not delivered")
+		}
+	}
+	// Метрики обработчика пишутся после его возврата
+	time.Sleep(20 * time.Millisecond)
+
+	checks := []struct {
+		name   string
+		labels map[string]string
+		want   float64
+	}{
+		{"natsw_handled_messages_total", map[string]string{"subject": pattern, "status": "ok"}, 2},
+		{"natsw_handled_messages_total", map[string]string{"subject": "test.metrics.cancel.u1"}, 0},
+		{"natsw_sent_messages_total", map[string]string{"subject": pattern, "kind": "publish"}, 2},
+		{"natsw_sent_messages_total", map[string]string{"subject": "test.metrics.cancel.u1"}, 0},
+	}
+	for _, check := range checks {
+		if got := metricValue(t, reg, check.name, check.labels); got != check.want {
+			t.Errorf("%s%v = %v, want %v", check.name, check.labels, got, check.want)
+		}
+	}
+}
+
+func TestSubjectPatterns(t *testing.T) {
+	label := natsw.SubjectPatterns("chat.response.*", "indexing.>")
+	tests := map[string]string{
+		"chat.response.u1":          "chat.response.*",
+		"chat.response.u1.extra":    "chat.response.u1.extra",
+		"indexing.document.indexed": "indexing.>",
+		"indexing":                  "indexing",
+		"users.list":                "users.list",
+	}
+	for subject, want := range tests {
+		if got := label(subject); got != want {
+			t.Errorf("SubjectPatterns(%q) = %q, want %q", subject, got, want)
+		}
+	}
+}
+
+// metricValue возвращает значение счётчика или число наблюдений гистограммы с метками labels
+func metricValue(t *testing.T, reg *prometheus.Registry, name string, labels map[string]string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, pair := range metric.GetLabel() {
+				if want, ok := labels[pair.GetName()]; ok && want != pair.GetValue() {
+					continue metrics
+				}
+			}
+			if h := metric.GetHistogram(); h != nil {
+				return float64(h.GetSampleCount())
+			}
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
@@ -0,0 +1,277 @@
+package natsw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/artmexbet/raibecas/libs/dto"
+)
+
+const (
+	// HeaderDeadline - дедлайн запроса в формате RFC3339Nano, выставляется Call
+	HeaderDeadline = "X-Deadline"
+	// HeaderErrorCode - код ошибки в ответе RPC, тело ответа при этом - dto.ErrorResponse
+	HeaderErrorCode = "X-Error-Code"
+
+	// defaultCallTimeout ограничивает Call, если у контекста нет дедлайна
+	defaultCallTimeout = 5 * time.Second
+)
+
+// Endpoint - объявленный request-reply subject с типами запроса и ответа.
+// Сервис и клиент объявляют один и тот же endpoint, вместо того чтобы вручную
+// сериализовать запросы и разбирать ответы.
+type Endpoint[Req, Resp any] struct {
+	Subject string
+}
+
+// NewEndpoint объявляет endpoint на subject
+func NewEndpoint[Req, Resp any](subject string) Endpoint[Req, Resp] {
+	return Endpoint[Req, Resp]{Subject: subject}
+}
+
+// RPCError - ошибка RPC, передаётся клиенту в стандартном конверте с кодом dto.ErrorCode
+type RPCError struct {
+	Code    dto.ErrorCode
+	Message string
+}
+
+// NewRPCError создаёт ошибку RPC с кодом и необязательным описанием
+func NewRPCError(code dto.ErrorCode, message string) *RPCError {
+	return &RPCError{Code: code, Message: message}
+}
+
+func (e *RPCError) Error() string {
+	if e.Message == "" {
+		return string(e.Code)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// ErrorCode возвращает код ошибки RPC из цепочки err или пустую строку, если это не ошибка RPC
+func ErrorCode(err error) dto.ErrorCode {
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return rpcErr.Code
+	}
+	return ""
+}
+
+// RPCHandler - типизированный обработчик запроса.
+// Ошибка *RPCError уходит клиенту как есть, любая другая - как dto.ErrCodeInternal без подробностей.
+type RPCHandler[Req, Resp any] func(msg *Message, req *Req) (*Resp, error)
+
+// CallOption - опция запроса Call
+type CallOption func(msg *nats.Msg)
+
+// WithHeader добавляет заголовок к запросу, пустое значение пропускается
+func WithHeader(key, value string) CallOption {
+	return func(msg *nats.Msg) {
+		if value != "" {
+			msg.Header.Set(key, value)
+		}
+	}
+}
+
+// HandleRPC подписывает типизированный обработчик на subject endpoint
+func HandleRPC[Req, Resp any](c *Client, endpoint Endpoint[Req, Resp], handler RPCHandler[Req, Resp]) (*nats.Subscription, error) {
+	return c.Subscribe(endpoint.Subject, RPC(handler))
+}
+
+// RPC превращает типизированный обработчик в HandlerFunc: разбирает запрос,
+// ограничивает контекст дедлайном клиента, сериализует ответ или конверт ошибки
+// и отмечает ошибки в текущем span
+func RPC[Req, Resp any](handler RPCHandler[Req, Resp]) HandlerFunc {
+	return func(msg *Message) error {
+		span := trace.SpanFromContext(msg.Ctx)
+		span.SetAttributes(
+			attribute.String("messaging.system", "nats"),
+			attribute.String("messaging.destination.name", msg.Subject),
+		)
+
+		if deadline, ok := requestDeadline(msg.Msg); ok {
+			if !time.Now().Before(deadline) {
+				// Клиент уже не ждёт ответа
+				return fmt.Errorf("rpc %s: deadline exceeded before handling", msg.Subject)
+			}
+			ctx, cancel := context.WithDeadline(msg.Ctx, deadline)
+			defer cancel()
+			msg.Ctx = ctx
+		}
+
+		req := new(Req)
+		if len(msg.Data) > 0 {
+			if err := unmarshal(msg.Data, req); err != nil {
+				return respondRPCError(msg, span, NewRPCError(dto.ErrCodeInvalidRequest, "malformed request"))
+			}
+		}
+
+		resp, err := handler(msg, req)
+		if err != nil {
+			return respondRPCError(msg, span, err)
+		}
+		if resp == nil {
+			resp = new(Resp)
+		}
+
+		data, err := marshal(resp)
+		if err != nil {
+			return respondRPCError(msg, span, fmt.Errorf("failed to marshal response: %w", err))
+		}
+		return msg.Respond(data)
+	}
+}
+
+// Call выполняет типизированный запрос к endpoint.
+// Дедлайн контекста (или defaultCallTimeout) передаётся обработчику в заголовке HeaderDeadline,
+// конверт ошибки возвращается как *RPCError.
+func Call[Req, Resp any](ctx context.Context, c *Client, endpoint Endpoint[Req, Resp], req *Req, opts ...CallOption) (*Resp, error) {
+	if req == nil {
+		req = new(Req)
+	}
+	data, err := marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s request: %w", endpoint.Subject, err)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultCallTimeout)
+		defer cancel()
+	}
+	deadline, _ := ctx.Deadline()
+
+	msg := nats.NewMsg(endpoint.Subject)
+	msg.Data = data
+	for _, opt := range opts {
+		opt(msg)
+	}
+	msg.Header.Set(HeaderDeadline, deadline.UTC().Format(time.RFC3339Nano))
+
+	ctx, span := c.tracer.Start(ctx, fmt.Sprintf("nats.request %s", endpoint.Subject),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "nats"),
+			attribute.String("messaging.destination.name", endpoint.Subject),
+		),
+	)
+	defer span.End()
+
+	// Инжектируем trace context в headers
+	c.propagator.Inject(ctx, &headerCarrier{header: msg.Header})
+
+	start := time.Now()
+	reply, err := c.conn.RequestMsgWithContext(ctx, msg)
+	c.metrics.observeRequest(endpoint.Subject, len(data), time.Since(start), replyData(reply), err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("request %s failed: %w", endpoint.Subject, err)
+	}
+
+	if code := reply.Header.Get(HeaderErrorCode); code != "" {
+		var envelope dto.ErrorResponse
+		// Код в заголовке достаточен, описание в теле необязательно
+		_ = envelope.UnmarshalJSON(reply.Data)
+		rpcErr := NewRPCError(dto.ErrorCode(code), envelope.Message)
+		span.SetAttributes(attribute.String("rpc.error_code", code))
+		if rpcErr.Code == dto.ErrCodeInternal {
+			span.SetStatus(codes.Error, rpcErr.Error())
+		}
+		return nil, rpcErr
+	}
+
+	resp := new(Resp)
+	if err := unmarshal(reply.Data, resp); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to unmarshal %s response: %w", endpoint.Subject, err)
+	}
+	return resp, nil
+}
+
+// replyData возвращает тело ответа или nil, если ответа нет
+func replyData(reply *nats.Msg) []byte {
+	if reply == nil {
+		return nil
+	}
+	return reply.Data
+}
+
+// respondRPCError отправляет конверт ошибки. Ошибки RPC, кроме внутренних, считаются
+// штатным ответом; остальные ошибки записываются в span и возвращаются для логирования
+func respondRPCError(msg *Message, span trace.Span, err error) error {
+	var rpcErr *RPCError
+	expected := errors.As(err, &rpcErr)
+	if !expected {
+		rpcErr = NewRPCError(dto.ErrCodeInternal, "")
+	}
+
+	span.SetAttributes(attribute.String("rpc.error_code", string(rpcErr.Code)))
+	if rpcErr.Code == dto.ErrCodeInternal {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	envelope := dto.ErrorResponse{
+		Error:   string(rpcErr.Code),
+		Message: rpcErr.Message,
+	}
+	data, marshalErr := envelope.MarshalJSON()
+	if marshalErr != nil {
+		return fmt.Errorf("failed to marshal error response: %w", marshalErr)
+	}
+
+	reply := nats.NewMsg(msg.Reply)
+	reply.Header.Set(HeaderErrorCode, string(rpcErr.Code))
+	reply.Data = data
+	if respondErr := msg.RespondMsg(reply); respondErr != nil {
+		return fmt.Errorf("failed to respond: %w", respondErr)
+	}
+	msg.replyErr = rpcErr
+
+	if expected {
+		return nil
+	}
+	return err
+}
+
+// requestDeadline читает дедлайн клиента из заголовка HeaderDeadline
+func requestDeadline(msg *nats.Msg) (time.Time, bool) {
+	if msg.Header == nil {
+		return time.Time{}, false
+	}
+	value := msg.Header.Get(HeaderDeadline)
+	if value == "" {
+		return time.Time{}, false
+	}
+	deadline, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return deadline, true
+}
+
+// marshal сериализует через easyjson, если тип его поддерживает, иначе через encoding/json
+func marshal(v any) ([]byte, error) {
+	if m, ok := v.(easyjson.Marshaler); ok {
+		return easyjson.Marshal(m)
+	}
+	return json.Marshal(v)
+}
+
+// unmarshal десериализует через easyjson, если тип его поддерживает, иначе через encoding/json
+func unmarshal(data []byte, v any) error {
+	if u, ok := v.(easyjson.Unmarshaler); ok {
+		return easyjson.Unmarshal(data, u)
+	}
+	return json.Unmarshal(data, v)
+}
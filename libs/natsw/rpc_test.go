@@ -0,0 +1,147 @@
+package natsw_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/artmexbet/raibecas/libs/dto"
+	"github.com/artmexbet/raibecas/libs/natsw"
+)
+
+type greetRequest struct {
+	Name string `json:"name"`
+}
+
+type greetResponse struct {
+	Greeting string `json:"greeting"`
+}
+
+var greet = natsw.NewEndpoint[greetRequest, greetResponse]("test.rpc.greet")
+
+func connectRPC(t *testing.T) *natsw.Client {
+	t.Helper()
+	nc, err := nats.Connect(nats.DefaultURL)
+	if err != nil {
+		t.Skipf("NATS server not available: %v", err)
+	}
+	t.Cleanup(nc.Close)
+	return natsw.NewClient(nc, natsw.WithRecover())
+}
+
+func TestRPC_Call(t *testing.T) {
+	client := connectRPC(t)
+
+	sub, err := natsw.HandleRPC(client, greet, func(msg *natsw.Message, req *greetRequest) (*greetResponse, error) {
+		if msg.Header.Get("X-User-Role") != "Admin" {
+			return nil, natsw.NewRPCError(dto.ErrCodeForbidden, "admins only")
+		}
+		if req.Name == "" {
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
+		}
+		if _, ok := msg.Ctx.Deadline(); !ok {
+			return nil, errors.New("deadline was not propagated")
+		}
+		return &greetResponse{Greeting: "Привет, " + req.Name}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	ctx := context.Background()
+
+	resp, err := natsw.Call(ctx, client, greet, &greetRequest{Name: "Гегель"}, natsw.WithHeader("X-User-Role", "Admin"))
+	if err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if resp.Greeting != "Привет, Гегель" {
+		t.Errorf("Unexpected greeting %q", resp.Greeting)
+	}
+
+	_, err = natsw.Call(ctx, client, greet, &greetRequest{Name: "Гегель"})
+	var rpcErr *natsw.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != dto.ErrCodeForbidden || rpcErr.Message != "admins only" {
+		t.Errorf("Expected forbidden error with message, got %v", err)
+	}
+
+	_, err = natsw.Call(ctx, client, greet, &greetRequest{}, natsw.WithHeader("X-User-Role", "Admin"))
+	if code := natsw.ErrorCode(err); code != dto.ErrCodeInvalidRequest {
+		t.Errorf("Expected invalid_request, got %v", err)
+	}
+}
+
+func TestRPC_InternalErrorsAreHidden(t *testing.T) {
+	client := connectRPC(t)
+
+	endpoint := natsw.NewEndpoint[greetRequest, greetResponse]("test.rpc.internal")
+	sub, err := natsw.HandleRPC(client, endpoint, func(*natsw.Message, *greetRequest) (*greetResponse, error) {
+		return nil, errors.New("connection refused by 10.0.0.5")
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	_, err = natsw.Call(context.Background(), client, endpoint, &greetRequest{Name: "x"})
+	var rpcErr *natsw.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != dto.ErrCodeInternal || rpcErr.Message != "" {
+		t.Errorf("Expected internal error without details, got %v", err)
+	}
+}
+
+func TestRPC_MalformedRequest(t *testing.T) {
+	client := connectRPC(t)
+
+	endpoint := natsw.NewEndpoint[greetRequest, greetResponse]("test.rpc.malformed")
+	sub, err := natsw.HandleRPC(client, endpoint, func(*natsw.Message, *greetRequest) (*greetResponse, error) {
+		t.Error("handler must not be called")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	reply, err := client.Conn().Request(endpoint.Subject, []byte("{"), 2*time.Second)
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if code := reply.Header.Get(natsw.HeaderErrorCode); code != string(dto.ErrCodeInvalidRequest) {
+		t.Errorf("Expected %s header, got %q", dto.ErrCodeInvalidRequest, code)
+	}
+	var envelope dto.ErrorResponse
+	if err := envelope.UnmarshalJSON(reply.Data); err != nil || envelope.Error != string(dto.ErrCodeInvalidRequest) {
+		t.Errorf("Expected legacy compatible error body, got %s", reply.Data)
+	}
+}
+
+func TestRPC_HandlerSeesCallerDeadline(t *testing.T) {
+	client := connectRPC(t)
+
+	endpoint := natsw.NewEndpoint[greetRequest, greetResponse]("test.rpc.deadline")
+	deadlines := make(chan time.Time, 1)
+	sub, err := natsw.HandleRPC(client, endpoint, func(msg *natsw.Message, _ *greetRequest) (*greetResponse, error) {
+		deadline, _ := msg.Ctx.Deadline()
+		deadlines <- deadline
+		return &greetResponse{}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 700*time.Millisecond)
+	defer cancel()
+	want, _ := ctx.Deadline()
+
+	if _, err := natsw.Call(ctx, client, endpoint, nil); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if got := <-deadlines; !got.Equal(want) {
+		t.Errorf("Handler deadline %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,400 @@
+package natsw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/nats-io/nuid"
+)
+
+const (
+	// defaultMaxConcurrent - число одновременных обработчиков endpoint по умолчанию
+	defaultMaxConcurrent = 32
+	// defaultDrainTimeout - сколько Drain по умолчанию ждёт начатые обработчики
+	defaultDrainTimeout = 30 * time.Second
+	// drainPollInterval - период проверки завершения drain подписок
+	drainPollInterval = 10 * time.Millisecond
+)
+
+// ErrServiceDraining возвращается при регистрации endpoint в останавливаемом сервисе
+var ErrServiceDraining = errors.New("service is draining")
+
+// ServiceConfig - настройки сервиса
+type ServiceConfig struct {
+	// Name - имя сервиса, оно же queue group по умолчанию.
+	// Под этим именем сервис отвечает на $SRV.PING/INFO/STATS
+	Name string
+	// Version - версия сервиса в формате SemVer
+	Version string
+	// Description - описание сервиса для $SRV.INFO
+	Description string
+	// Metadata - произвольные метки экземпляра сервиса
+	Metadata map[string]string
+	// QueueGroup - queue group endpoint'ов; по умолчанию Name
+	QueueGroup string
+	// MaxConcurrent - сколько обработчиков одного endpoint выполняются одновременно
+	MaxConcurrent int
+	// DrainTimeout - сколько Drain ждёт начатые обработчики
+	DrainTimeout time.Duration
+}
+
+// Service регистрирует endpoint'ы сервиса: по умолчанию в queue group, чтобы
+// каждый запрос обрабатывала одна реплика, с ограничением одновременных обработчиков
+// и плавной остановкой через Drain.
+// Каждый экземпляр отвечает на служебные запросы $SRV.PING/INFO/STATS по протоколу
+// NATS micro, поэтому его видят `nats micro ls` и readiness-проверки
+type Service struct {
+	client  *Client
+	cfg     ServiceConfig
+	id      string
+	started time.Time
+
+	mu        sync.Mutex
+	endpoints []*serviceEndpoint
+	verbSubs  []*nats.Subscription
+	draining  bool
+}
+
+// serviceEndpoint - подписка endpoint с семафором обработчиков и статистикой
+type serviceEndpoint struct {
+	subject  string
+	queue    string
+	slots    chan struct{}
+	inflight sync.WaitGroup
+	sub      *nats.Subscription
+
+	statsMu        sync.Mutex
+	numRequests    int
+	numErrors      int
+	lastError      string
+	processingTime time.Duration
+}
+
+// EndpointOption - опция регистрации endpoint
+type EndpointOption func(*serviceEndpoint)
+
+// WithMaxConcurrent переопределяет число одновременных обработчиков endpoint
+func WithMaxConcurrent(n int) EndpointOption {
+	return func(e *serviceEndpoint) {
+		if n > 0 {
+			e.slots = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithQueueGroup переопределяет queue group endpoint.
+// Пустая строка отключает queue group: сообщение получит каждая реплика
+func WithQueueGroup(queue string) EndpointOption {
+	return func(e *serviceEndpoint) {
+		e.queue = queue
+	}
+}
+
+// NewService создаёт сервис поверх клиента
+func NewService(client *Client, cfg ServiceConfig) *Service {
+	if cfg.QueueGroup == "" {
+		cfg.QueueGroup = cfg.Name
+	}
+	if cfg.MaxConcurrent <= 0 {
+		cfg.MaxConcurrent = defaultMaxConcurrent
+	}
+	if cfg.DrainTimeout <= 0 {
+		cfg.DrainTimeout = defaultDrainTimeout
+	}
+
+	return &Service{
+		client:  client,
+		cfg:     cfg,
+		id:      nuid.Next(),
+		started: time.Now().UTC(),
+	}
+}
+
+// Handle регистрирует обработчик subject.
+// Когда все слоты endpoint заняты, новые сообщения ждут в буфере подписки
+func (s *Service) Handle(subject string, handler HandlerFunc, opts ...EndpointOption) error {
+	ep := &serviceEndpoint{
+		subject: subject,
+		queue:   s.cfg.QueueGroup,
+		slots:   make(chan struct{}, s.cfg.MaxConcurrent),
+	}
+	for _, opt := range opts {
+		opt(ep)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.draining {
+		return fmt.Errorf("subscribe to %s: %w", subject, ErrServiceDraining)
+	}
+	// Сервис объявляет себя вместе с первым endpoint
+	if s.verbSubs == nil {
+		if err := s.subscribeVerbs(); err != nil {
+			return err
+		}
+	}
+
+	callback := func(msg *nats.Msg) {
+		ep.inflight.Add(1)
+		// Блокирует доставку следующих сообщений подписки, пока нет свободного слота
+		ep.slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-ep.slots
+				ep.inflight.Done()
+			}()
+			start := time.Now()
+			err := s.client.dispatch(msg, handler, "service", s.cfg.Name, "subject", subject)
+			ep.record(time.Since(start), err)
+		}()
+	}
+
+	var err error
+	if ep.queue == "" {
+		ep.sub, err = s.client.conn.Subscribe(subject, callback)
+	} else {
+		ep.sub, err = s.client.conn.QueueSubscribe(subject, ep.queue, callback)
+	}
+	if err != nil {
+		return fmt.Errorf("subscribe to %s: %w", subject, err)
+	}
+
+	s.endpoints = append(s.endpoints, ep)
+	return nil
+}
+
+// AddRPC регистрирует типизированный обработчик endpoint в сервисе
+func AddRPC[Req, Resp any](s *Service, endpoint Endpoint[Req, Resp], handler RPCHandler[Req, Resp], opts ...EndpointOption) error {
+	return s.Handle(endpoint.Subject, RPC(handler), opts...)
+}
+
+// Name возвращает имя сервиса
+func (s *Service) Name() string {
+	return s.cfg.Name
+}
+
+// Subjects возвращает subject'ы зарегистрированных endpoint'ов
+func (s *Service) Subjects() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subjects := make([]string, len(s.endpoints))
+	for i, ep := range s.endpoints {
+		subjects[i] = ep.subject
+	}
+	return subjects
+}
+
+// ID возвращает уникальный идентификатор экземпляра сервиса
+func (s *Service) ID() string {
+	return s.id
+}
+
+// Info возвращает описание экземпляра и его endpoint'ов, как в ответе $SRV.INFO
+func (s *Service) Info() micro.Info {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoints := make([]micro.EndpointInfo, len(s.endpoints))
+	for i, ep := range s.endpoints {
+		endpoints[i] = micro.EndpointInfo{
+			Name:       ep.subject,
+			Subject:    ep.subject,
+			QueueGroup: ep.queue,
+		}
+	}
+	return micro.Info{
+		ServiceIdentity: s.identity(),
+		Type:            micro.InfoResponseType,
+		Description:     s.cfg.Description,
+		Endpoints:       endpoints,
+	}
+}
+
+// Stats возвращает счётчики запросов, ошибок и время обработки по endpoint'ам,
+// как в ответе $SRV.STATS. Ошибкой считается и ошибка обработчика, и конверт ошибки RPC
+func (s *Service) Stats() micro.Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoints := make([]*micro.EndpointStats, len(s.endpoints))
+	for i, ep := range s.endpoints {
+		endpoints[i] = ep.stats()
+	}
+	return micro.Stats{
+		ServiceIdentity: s.identity(),
+		Type:            micro.StatsResponseType,
+		Started:         s.started,
+		Endpoints:       endpoints,
+	}
+}
+
+// PingService запрашивает $SRV.PING.<name> и возвращает ответ первого отозвавшегося
+// экземпляра сервиса. Если ни один экземпляр не запущен, возвращается nats.ErrNoResponders
+func PingService(ctx context.Context, c *Client, name string) (*micro.Ping, error) {
+	subject, err := micro.ControlSubject(micro.PingVerb, name, "")
+	if err != nil {
+		return nil, fmt.Errorf("control subject for %s: %w", name, err)
+	}
+
+	reply, err := c.conn.RequestWithContext(ctx, subject, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ping %s: %w", name, err)
+	}
+
+	var ping micro.Ping
+	if err := json.Unmarshal(reply.Data, &ping); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s ping: %w", name, err)
+	}
+	return &ping, nil
+}
+
+// Drain прекращает приём новых сообщений, дообрабатывает полученные и ждёт
+// завершения начатых обработчиков. Ожидание ограничено ctx и DrainTimeout;
+// по истечении времени оставшиеся подписки снимаются, а обработчики не прерываются.
+// Служебные подписки $SRV снимаются сразу, чтобы readiness-проверки перестали видеть экземпляр
+func (s *Service) Drain(ctx context.Context) error {
+	s.mu.Lock()
+	s.draining = true
+	endpoints := s.endpoints
+	verbSubs := s.verbSubs
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.DrainTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, sub := range verbSubs {
+		if err := sub.Unsubscribe(); err != nil && !errors.Is(err, nats.ErrBadSubscription) {
+			errs = append(errs, fmt.Errorf("unsubscribe %s: %w", sub.Subject, err))
+		}
+	}
+	for _, ep := range endpoints {
+		if err := ep.sub.Drain(); err != nil && !errors.Is(err, nats.ErrBadSubscription) {
+			errs = append(errs, fmt.Errorf("drain %s: %w", ep.subject, err))
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, ep := range endpoints {
+			waitUnsubscribed(ep.sub)
+			ep.inflight.Wait()
+		}
+	}()
+
+	select {
+	case <-done:
+		return errors.Join(errs...)
+	case <-ctx.Done():
+		for _, ep := range endpoints {
+			_ = ep.sub.Unsubscribe()
+		}
+		errs = append(errs, fmt.Errorf("drain service %s: %w", s.cfg.Name, ctx.Err()))
+		return errors.Join(errs...)
+	}
+}
+
+// subscribeVerbs подписывает сервис на служебные subject'ы $SRV: общий для всех
+// сервисов, по имени сервиса и по идентификатору экземпляра. Вызывается под s.mu
+func (s *Service) subscribeVerbs() error {
+	handlers := map[micro.Verb]func() any{
+		micro.PingVerb: func() any {
+			return micro.Ping{ServiceIdentity: s.identity(), Type: micro.PingResponseType}
+		},
+		micro.InfoVerb:  func() any { return s.Info() },
+		micro.StatsVerb: func() any { return s.Stats() },
+	}
+
+	for verb, handler := range handlers {
+		for _, target := range [][2]string{{"", ""}, {s.cfg.Name, ""}, {s.cfg.Name, s.id}} {
+			subject, err := micro.ControlSubject(verb, target[0], target[1])
+			if err != nil {
+				s.unsubscribeVerbs()
+				return fmt.Errorf("control subject for %s: %w", s.cfg.Name, err)
+			}
+			sub, err := s.client.conn.Subscribe(subject, func(msg *nats.Msg) {
+				data, err := json.Marshal(handler())
+				if err != nil {
+					s.client.logger.Error("failed to marshal control response", "subject", msg.Subject, "error", err)
+					return
+				}
+				_ = msg.Respond(data)
+			})
+			if err != nil {
+				s.unsubscribeVerbs()
+				return fmt.Errorf("subscribe to %s: %w", subject, err)
+			}
+			s.verbSubs = append(s.verbSubs, sub)
+		}
+	}
+	return nil
+}
+
+// unsubscribeVerbs снимает служебные подписки, созданные до ошибки. Вызывается под s.mu
+func (s *Service) unsubscribeVerbs() {
+	for _, sub := range s.verbSubs {
+		_ = sub.Unsubscribe()
+	}
+	s.verbSubs = nil
+}
+
+// identity возвращает идентификацию экземпляра для ответов $SRV
+func (s *Service) identity() micro.ServiceIdentity {
+	return micro.ServiceIdentity{
+		Name:     s.cfg.Name,
+		ID:       s.id,
+		Version:  s.cfg.Version,
+		Metadata: s.cfg.Metadata,
+	}
+}
+
+// record учитывает обработанное сообщение в статистике endpoint
+func (e *serviceEndpoint) record(elapsed time.Duration, err error) {
+	e.statsMu.Lock()
+	defer e.statsMu.Unlock()
+
+	e.numRequests++
+	e.processingTime += elapsed
+	if err != nil {
+		e.numErrors++
+		// Ошибка после recover содержит стек, в статистику попадает только первая строка
+		e.lastError, _, _ = strings.Cut(err.Error(), "\n")
+	}
+}
+
+// stats возвращает снимок статистики endpoint
+func (e *serviceEndpoint) stats() *micro.EndpointStats {
+	e.statsMu.Lock()
+	defer e.statsMu.Unlock()
+
+	stats := &micro.EndpointStats{
+		Name:           e.subject,
+		Subject:        e.subject,
+		QueueGroup:     e.queue,
+		NumRequests:    e.numRequests,
+		NumErrors:      e.numErrors,
+		LastError:      e.lastError,
+		ProcessingTime: e.processingTime,
+	}
+	if e.numRequests > 0 {
+		stats.AverageProcessingTime = e.processingTime / time.Duration(e.numRequests)
+	}
+	return stats
+}
+
+// waitUnsubscribed ждёт, пока drain подписки доставит буфер и закроет её
+func waitUnsubscribed(sub *nats.Subscription) {
+	for sub.IsValid() {
+		time.Sleep(drainPollInterval)
+	}
+}
@@ -0,0 +1,216 @@
+package natsw_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+
+	"github.com/artmexbet/raibecas/libs/dto"
+	"github.com/artmexbet/raibecas/libs/natsw"
+)
+
+func TestService_QueueGroupHandlesRequestOnce(t *testing.T) {
+	var handled atomic.Int32
+	for range 2 {
+		svc := natsw.NewService(connectRPC(t), natsw.ServiceConfig{Name: "test-once"})
+		if err := svc.Handle("test.service.once", func(msg *natsw.Message) error {
+			handled.Add(1)
+			return msg.Respond([]byte("ok"))
+		}); err != nil {
+			t.Fatalf("Handle: %v", err)
+		}
+		t.Cleanup(func() { _ = svc.Drain(context.Background()) })
+	}
+
+	client := connectRPC(t)
+	for range 10 {
+		if _, err := client.Conn().Request("test.service.once", nil, 2*time.Second); err != nil {
+			t.Fatalf("Request: %v", err)
+		}
+	}
+	// Даём второй реплике время получить лишнюю копию, если queue group не сработала
+	time.Sleep(50 * time.Millisecond)
+
+	if got := handled.Load(); got != 10 {
+		t.Errorf("Expected 10 handled requests, got %d", got)
+	}
+}
+
+func TestService_LimitsConcurrentHandlers(t *testing.T) {
+	client := connectRPC(t)
+	svc := natsw.NewService(client, natsw.ServiceConfig{Name: "test-limit", MaxConcurrent: 2})
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	wg.Add(6)
+	if err := svc.Handle("test.service.limit", func(*natsw.Message) error {
+		defer wg.Done()
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	defer svc.Drain(context.Background())
+
+	for range 6 {
+		if err := client.Conn().Publish("test.service.limit", nil); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Errorf("Expected at most 2 concurrent handlers, peak was %d", got)
+	}
+}
+
+func TestService_DrainWaitsForHandlers(t *testing.T) {
+	client := connectRPC(t)
+	svc := natsw.NewService(client, natsw.ServiceConfig{Name: "test-drain"})
+
+	started := make(chan struct{})
+	var finished atomic.Bool
+	if err := svc.Handle("test.service.drain", func(*natsw.Message) error {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		finished.Store(true)
+		return nil
+	}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	if err := client.Conn().Publish("test.service.drain", nil); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	<-started
+
+	if err := svc.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if !finished.Load() {
+		t.Error("Drain returned before the handler finished")
+	}
+	if err := svc.Handle("test.service.drain", func(*natsw.Message) error { return nil }); !errors.Is(err, natsw.ErrServiceDraining) {
+		t.Errorf("Expected ErrServiceDraining, got %v", err)
+	}
+	if _, err := client.Conn().Request("test.service.drain", nil, 100*time.Millisecond); !errors.Is(err, nats.ErrNoResponders) {
+		t.Errorf("Expected no responders after drain, got %v", err)
+	}
+}
+
+func TestService_DrainTimeout(t *testing.T) {
+	client := connectRPC(t)
+	svc := natsw.NewService(client, natsw.ServiceConfig{Name: "test-drain-timeout"})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	if err := svc.Handle("test.service.stuck", func(*natsw.Message) error {
+		close(started)
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	if err := client.Conn().Publish("test.service.stuck", nil); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := svc.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestService_AnswersControlRequests(t *testing.T) {
+	client := connectRPC(t)
+	svc := natsw.NewService(client, natsw.ServiceConfig{Name: "test-micro", Version: "1.2.3", Description: "greeter"})
+
+	endpoint := natsw.NewEndpoint[greetRequest, greetResponse]("test.service.micro")
+	if err := natsw.AddRPC(svc, endpoint, func(_ *natsw.Message, req *greetRequest) (*greetResponse, error) {
+		if req.Name == "" {
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
+		}
+		return &greetResponse{Greeting: req.Name}, nil
+	}); err != nil {
+		t.Fatalf("AddRPC: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := natsw.Call(ctx, client, endpoint, &greetRequest{Name: "Кант"}); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if _, err := natsw.Call(ctx, client, endpoint, &greetRequest{}); err == nil {
+		t.Fatal("Expected invalid_request error")
+	}
+
+	ping, err := natsw.PingService(ctx, client, "test-micro")
+	if err != nil {
+		t.Fatalf("PingService: %v", err)
+	}
+	if ping.ID != svc.ID() || ping.Version != "1.2.3" || ping.Type != micro.PingResponseType {
+		t.Errorf("Unexpected ping %+v", ping)
+	}
+
+	var info micro.Info
+	requestControl(t, client, micro.InfoVerb, svc.ID(), &info)
+	if info.Description != "greeter" || len(info.Endpoints) != 1 || info.Endpoints[0].QueueGroup != "test-micro" {
+		t.Errorf("Unexpected info %+v", info)
+	}
+
+	// Статистика пишется после ответа, даём обработчику её записать
+	time.Sleep(20 * time.Millisecond)
+	var stats micro.Stats
+	requestControl(t, client, micro.StatsVerb, svc.ID(), &stats)
+	if len(stats.Endpoints) != 1 {
+		t.Fatalf("Expected 1 endpoint in stats, got %d", len(stats.Endpoints))
+	}
+	got := stats.Endpoints[0]
+	if got.Subject != endpoint.Subject || got.NumRequests != 2 || got.NumErrors != 1 || got.LastError != string(dto.ErrCodeInvalidRequest) {
+		t.Errorf("Unexpected endpoint stats %+v", got)
+	}
+	if got.ProcessingTime <= 0 || got.AverageProcessingTime != got.ProcessingTime/2 {
+		t.Errorf("Unexpected processing time %v, average %v", got.ProcessingTime, got.AverageProcessingTime)
+	}
+
+	if err := svc.Drain(ctx); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if _, err := natsw.PingService(ctx, client, "test-micro"); !errors.Is(err, nats.ErrNoResponders) {
+		t.Errorf("Expected no responders after drain, got %v", err)
+	}
+}
+
+// requestControl запрашивает служебный subject экземпляра сервиса test-micro
+func requestControl(t *testing.T, client *natsw.Client, verb micro.Verb, id string, v any) {
+	t.Helper()
+	subject, err := micro.ControlSubject(verb, "test-micro", id)
+	if err != nil {
+		t.Fatalf("ControlSubject: %v", err)
+	}
+	reply, err := client.Conn().Request(subject, nil, 2*time.Second)
+	if err != nil {
+		t.Fatalf("Request %s: %v", subject, err)
+	}
+	if err := json.Unmarshal(reply.Data, v); err != nil {
+		t.Fatalf("Unmarshal %s: %v", subject, err)
+	}
+}
@@ -59,15 +59,14 @@ func NewUserConsumer(userRepo UserRepository, logger *slog.Logger) *UserConsumer
 	}
 }
 
-// Subscribe subscribes to user registration events
-func (c *UserConsumer) Subscribe(client *natsw.Client) error {
-	_, err := client.Subscribe("users.user.registered", c.handleUserRegistered)
-	if err != nil {
+// Subscribe registers the user event handlers on the service,
+// so that replicas share the events through the queue group and shutdown drains them.
+func (c *UserConsumer) Subscribe(service *natsw.Service) error {
+	if err := service.Handle("users.user.registered", c.handleUserRegistered); err != nil {
 		return fmt.Errorf("failed to subscribe to users.user.registered: %w", err)
 	}
 
-	_, err = client.Subscribe("users.user.updated", c.handleUserUpdated)
-	if err != nil {
+	if err := service.Handle("users.user.updated", c.handleUserUpdated); err != nil {
 		return fmt.Errorf("failed to subscribe to users.user.updated: %w", err)
 	}
 
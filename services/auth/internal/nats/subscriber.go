@@ -2,12 +2,12 @@ package nats
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/nats-io/nats.go"
 
 	"github.com/artmexbet/raibecas/libs/natsw"
 
@@ -21,22 +21,13 @@ type IRegistrationService interface {
 
 // Subscriber handles subscribing to NATS events
 type Subscriber struct {
-	client      *natsw.Client
-	regService  IRegistrationService
-	publisher   EventPublisher
-	subscribers []*nats.Subscription
+	regService IRegistrationService
+	publisher  EventPublisher
 }
 
 // NewSubscriber creates a new NATS subscriber
-func NewSubscriber(conn *nats.Conn, regService IRegistrationService, publisher EventPublisher) *Subscriber {
-	// Создаём клиент с middleware
-	client := natsw.NewClient(conn,
-		natsw.WithLogger(slog.Default()),
-		natsw.WithRecover(),
-	)
-
+func NewSubscriber(regService IRegistrationService, publisher EventPublisher) *Subscriber {
 	return &Subscriber{
-		client:     client,
 		regService: regService,
 		publisher:  publisher,
 	}
@@ -56,37 +47,21 @@ type RegistrationRejectedEvent struct {
 	Reason     string    `json:"reason,omitempty"`
 }
 
-// Start starts all NATS subscriptions
-func (s *Subscriber) Start(_ context.Context) error {
-	// Subscribe to registration approved events
-	sub1, err := s.client.Subscribe(SubjectAdminRegistrationApproved, s.handleRegistrationApproved)
-	if err != nil {
-		return fmt.Errorf("failed to subscribe to %s: %w", SubjectAdminRegistrationApproved, err)
-	}
-	s.subscribers = append(s.subscribers, sub1)
-
-	// Subscribe to registration rejected events
-	sub2, err := s.client.Subscribe(SubjectAdminRegistrationRejected, s.handleRegistrationRejected)
+// Start registers the event handlers on the service.
+// Like request/reply endpoints they share the queue group between replicas and are drained on shutdown.
+func (s *Subscriber) Start(service *natsw.Service) error {
+	err := errors.Join(
+		service.Handle(SubjectAdminRegistrationApproved, s.handleRegistrationApproved),
+		service.Handle(SubjectAdminRegistrationRejected, s.handleRegistrationRejected),
+	)
 	if err != nil {
-		return fmt.Errorf("failed to subscribe to %s: %w", SubjectAdminRegistrationRejected, err)
+		return err
 	}
-	s.subscribers = append(s.subscribers, sub2)
 
 	slog.Info("NATS subscribers started successfully")
 	return nil
 }
 
-// Stop stops all NATS subscriptions
-func (s *Subscriber) Stop() error {
-	for _, sub := range s.subscribers {
-		if err := sub.Unsubscribe(); err != nil {
-			return fmt.Errorf("failed to unsubscribe: %w", err)
-		}
-	}
-	slog.Info("NATS subscribers stopped successfully")
-	return nil
-}
-
 // handleRegistrationApproved handles registration approval events
 func (s *Subscriber) handleRegistrationApproved(msg *natsw.Message) error {
 	var event RegistrationApprovedEvent
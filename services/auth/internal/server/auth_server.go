@@ -2,8 +2,10 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	natsgo "github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -34,10 +37,11 @@ type App struct {
 	pool           *pgxpool.Pool
 	redis          *redis.Client
 	natsClient     *natsw.Client
+	natsService    *natsw.Service
 	subscriber     *natspkg.Subscriber
 	userConsumer   *consumer.UserConsumer
-	subscriptions  []*natsgo.Subscription
 	tracerProvider *sdktrace.TracerProvider
+	metricsSrv     *http.Server
 }
 
 // New creates a new App-based server instance
@@ -126,6 +130,7 @@ func New(cfg *config.Config) (*App, error) {
 		natsw.WithRecover(),
 		natsw.WithTracer(natsTracer),
 		natsw.WithMiddleware(natsw.TraceHandlerMiddleware(natsTracer)),
+		natsw.WithMetrics(natsw.NewMetrics(prometheus.DefaultRegisterer)),
 	)
 
 	pgs := postgres.New(pool)
@@ -149,7 +154,7 @@ func New(cfg *config.Config) (*App, error) {
 
 	// Initialize nats publisher and subscriber
 	publisher := natspkg.NewPublisher(natsConn)
-	subscriber := natspkg.NewSubscriber(natsConn, regService, publisher)
+	subscriber := natspkg.NewSubscriber(regService, publisher)
 
 	// Initialize user consumer for outbox events
 	userConsumer := consumer.NewUserConsumer(pgs, logger)
@@ -158,15 +163,25 @@ func New(cfg *config.Config) (*App, error) {
 	authHandler := handler.NewAuthHandler(authService, publisher, serviceTracer)
 	regHandler := handler.NewRegistrationHandler(regService, publisher, serviceTracer)
 
+	// Request/reply endpoints and event handlers are shared between replicas through the queue group
+	natsService := natsw.NewService(natsClient, natsw.ServiceConfig{
+		Name:          "auth",
+		Version:       cfg.Telemetry.ServiceVersion,
+		Description:   "Authentication and token validation",
+		QueueGroup:    cfg.NATS.QueueGroup,
+		MaxConcurrent: cfg.NATS.MaxConcurrent,
+		DrainTimeout:  cfg.NATS.DrainTimeout,
+	})
+
 	// Setup App subscriptions
 	server := &App{
 		cfg:            cfg,
 		pool:           pool,
 		redis:          redisClient,
 		natsClient:     natsClient,
+		natsService:    natsService,
 		subscriber:     subscriber,
 		userConsumer:   userConsumer,
-		subscriptions:  make([]*natsgo.Subscription, 0),
 		tracerProvider: tracerProvider,
 	}
 
@@ -176,12 +191,12 @@ func New(cfg *config.Config) (*App, error) {
 	}
 
 	// Subscribe to user registration events from users service
-	if err := userConsumer.Subscribe(natsClient); err != nil {
+	if err := userConsumer.Subscribe(natsService); err != nil {
 		return nil, fmt.Errorf("failed to subscribe user consumer: %w", err)
 	}
 
 	// Start event subscriber (for admin approval/rejection events)
-	if err := subscriber.Start(ctx); err != nil {
+	if err := subscriber.Start(natsService); err != nil {
 		return nil, fmt.Errorf("failed to start event subscriber: %w", err)
 	}
 
@@ -190,65 +205,31 @@ func New(cfg *config.Config) (*App, error) {
 
 // setupSubscriptions sets up App request/reply subscriptions
 func (s *App) setupSubscriptions(authHandler *handler.AuthHandler, regHandler *handler.RegistrationHandler) error {
-	// Auth service subscriptions using natsw.Client
-	sub, err := s.natsClient.Subscribe(natspkg.SubjectAuthRegister, regHandler.HandleRegister)
-	if err != nil {
-		return fmt.Errorf("failed to subscribe to %s: %w", natspkg.SubjectAuthRegister, err)
-	}
-	s.subscriptions = append(s.subscriptions, sub)
-
-	sub, err = s.natsClient.Subscribe(natspkg.SubjectAuthLogin, authHandler.HandleLogin)
-	if err != nil {
-		return fmt.Errorf("failed to subscribe to %s: %w", natspkg.SubjectAuthLogin, err)
-	}
-	s.subscriptions = append(s.subscriptions, sub)
-
-	sub, err = s.natsClient.Subscribe(natspkg.SubjectAuthRefresh, authHandler.HandleRefresh)
-	if err != nil {
-		return fmt.Errorf("failed to subscribe to %s: %w", natspkg.SubjectAuthRefresh, err)
-	}
-	s.subscriptions = append(s.subscriptions, sub)
-
-	sub, err = s.natsClient.Subscribe(natspkg.SubjectAuthValidate, authHandler.HandleValidate)
-	if err != nil {
-		return fmt.Errorf("failed to subscribe to %s: %w", natspkg.SubjectAuthValidate, err)
-	}
-	s.subscriptions = append(s.subscriptions, sub)
-
-	sub, err = s.natsClient.Subscribe(natspkg.SubjectAuthLogout, authHandler.HandleLogout)
-	if err != nil {
-		return fmt.Errorf("failed to subscribe to %s: %w", natspkg.SubjectAuthLogout, err)
-	}
-	s.subscriptions = append(s.subscriptions, sub)
-
-	sub, err = s.natsClient.Subscribe(natspkg.SubjectAuthLogoutAll, authHandler.HandleLogoutAll)
-	if err != nil {
-		return fmt.Errorf("failed to subscribe to %s: %w", natspkg.SubjectAuthLogoutAll, err)
-	}
-	s.subscriptions = append(s.subscriptions, sub)
-
-	sub, err = s.natsClient.Subscribe(natspkg.SubjectAuthChangePassword, authHandler.HandleChangePassword)
+	err := errors.Join(
+		s.natsService.Handle(natspkg.SubjectAuthRegister, regHandler.HandleRegister),
+		s.natsService.Handle(natspkg.SubjectAuthLogin, authHandler.HandleLogin),
+		s.natsService.Handle(natspkg.SubjectAuthRefresh, authHandler.HandleRefresh),
+		s.natsService.Handle(natspkg.SubjectAuthValidate, authHandler.HandleValidate),
+		s.natsService.Handle(natspkg.SubjectAuthLogout, authHandler.HandleLogout),
+		s.natsService.Handle(natspkg.SubjectAuthLogoutAll, authHandler.HandleLogoutAll),
+		s.natsService.Handle(natspkg.SubjectAuthChangePassword, authHandler.HandleChangePassword),
+	)
 	if err != nil {
-		return fmt.Errorf("failed to subscribe to %s: %w", natspkg.SubjectAuthChangePassword, err)
+		return err
 	}
-	s.subscriptions = append(s.subscriptions, sub)
 
 	slog.Info("App subscriptions setup complete",
-		"topics", []string{
-			natspkg.SubjectAuthRegister,
-			natspkg.SubjectAuthLogin,
-			natspkg.SubjectAuthRefresh,
-			natspkg.SubjectAuthValidate,
-			natspkg.SubjectAuthLogout,
-			natspkg.SubjectAuthLogoutAll,
-			natspkg.SubjectAuthChangePassword,
-		})
+		"queue_group", s.cfg.NATS.QueueGroup,
+		"topics", s.natsService.Subjects())
 
 	return nil
 }
 
 // Start starts the App server
 func (s *App) Start() error {
+	// Auth has no HTTP API, so metrics get a dedicated server
+	s.metricsSrv = natsw.ServeMetrics(fmt.Sprintf(":%d", s.cfg.Metrics.Port))
+
 	slog.Info("Auth service is ready and listening on App topics")
 
 	// Wait for interrupt signal
@@ -266,21 +247,20 @@ func (s *App) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Unsubscribe from all topics
-	for _, sub := range s.subscriptions {
-		if err := sub.Unsubscribe(); err != nil {
-			slog.Error("Error unsubscribing", "error", err)
-		}
-	}
-
-	// Stop App event subscribers
-	if err := s.subscriber.Stop(); err != nil {
-		slog.Error("Error stopping App subscribers", "error", err)
+	// Finish in-flight requests before closing connections
+	if err := s.natsService.Drain(ctx); err != nil {
+		slog.Error("Error draining App subscriptions", "error", err)
 	}
 
 	// Close App connection
 	s.natsClient.Close()
 
+	if s.metricsSrv != nil {
+		if err := s.metricsSrv.Shutdown(ctx); err != nil {
+			slog.Error("Error shutting down metrics server", "error", err)
+		}
+	}
+
 	// Shutdown tracer provider (flush all pending spans)
 	if err := telemetry.Shutdown(ctx, s.tracerProvider); err != nil {
 		slog.Error("Error shutting down tracer provider", "error", err)
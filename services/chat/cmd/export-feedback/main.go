@@ -0,0 +1,121 @@
+// Command export-feedback dumps rated chat answers as JSONL: one object per answer with the question,
+// the retrieved chunk and document IDs, the answer, the rating and the comment.
+// The database is configured with the CHAT_DB_* variables of the chat service.
+//
+// Usage:
+//
+//	export-feedback [-since 2026-01-01] [-rating up|down|all] [-out feedback.jsonl]
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+	"github.com/artmexbet/raibecas/services/chat/internal/postgres"
+)
+
+func main() {
+	since := flag.String("since", "", "export feedback given at or after this date (2006-01-02 or RFC 3339)")
+	rating := flag.String("rating", "all", "export only positive (up) or negative (down) feedback")
+	out := flag.String("out", "-", "output file, - for stdout")
+	flag.Parse()
+
+	if err := run(*since, *rating, *out); err != nil {
+		slog.Error("export failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(since, rating, out string) error {
+	filter, err := parseFilter(since, rating)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	store, err := postgres.New(ctx, &cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	defer store.Close()
+
+	records, err := store.ListFeedback(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "-" {
+		f, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("create output: %w", err)
+		}
+		defer f.Close() //nolint:errcheck // closed after flushing below
+		w = f
+	}
+
+	buf := bufio.NewWriter(w)
+	if err := writeRecords(buf, records); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+
+	slog.Info("feedback exported", "records", len(records))
+	return nil
+}
+
+func parseFilter(since, rating string) (domain.FeedbackFilter, error) {
+	var filter domain.FeedbackFilter
+
+	if since != "" {
+		t, err := time.Parse(time.DateOnly, since)
+		if err != nil {
+			if t, err = time.Parse(time.RFC3339, since); err != nil {
+				return filter, fmt.Errorf("invalid -since %q: expected 2006-01-02 or RFC 3339", since)
+			}
+		}
+		filter.Since = t
+	}
+
+	switch rating {
+	case "all", "":
+		filter.Rating = domain.FeedbackNone
+	case "up":
+		filter.Rating = domain.FeedbackPositive
+	case "down":
+		filter.Rating = domain.FeedbackNegative
+	default:
+		return filter, fmt.Errorf("invalid -rating %q: expected up, down or all", rating)
+	}
+	return filter, nil
+}
+
+// writeRecords writes one JSON object per line.
+func writeRecords(w io.Writer, records []domain.FeedbackRecord) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("encode record %s: %w", record.MessageID, err)
+		}
+	}
+	return nil
+}
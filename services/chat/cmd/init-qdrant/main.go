@@ -43,7 +43,7 @@ func main() {
 		"По выражению одного из основателей языков программирования Никлауса Вирта «Программы = алгоритмы + структуры данных»[1][2].\n\nПрограммирование основывается на использовании языков программирования и средств программирования. В основном языки программирования основаны на текстовом представлении программ, но иногда программировать можно, используя, например, визуальное программирование или «zero-code» программирование.",
 	}
 
-	ne, err := neuro.NewConnector(&config.Ollama{
+	ne, err := neuro.NewOllamaProvider(&config.Ollama{
 		Protocol:        "http",
 		Host:            "localhost",
 		Port:            "11434",
@@ -56,7 +56,7 @@ func main() {
 	}
 
 	for i, doc := range documents {
-		vec, err := ne.GenerateEmbeddings(ctx, doc)
+		vec, err := ne.Embed(ctx, doc)
 		if err != nil {
 			slog.ErrorContext(ctx, "Failed to generate embedding", slog.String("document", doc), "error", err)
 			continue
@@ -66,7 +66,7 @@ func main() {
 			Points: []*qdrant.PointStruct{
 				{
 					Id:      qdrant.NewIDNum(uint64(i)),
-					Payload: qdrant.NewValueMap(map[string]any{"content": doc}),
+					Payload: qdrant.NewValueMap(map[string]any{"content": doc, "is_public": true}),
 					Vectors: qdrant.NewVectors(float64ToVectors(vec)...),
 				},
 			},
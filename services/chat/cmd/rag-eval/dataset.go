@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// evalCase is a question with the documents that should be retrieved for it
+// and the keywords a good answer contains.
+type evalCase struct {
+	ID          string   `json:"id" yaml:"id"`
+	Question    string   `json:"question" yaml:"question"`
+	DocumentIDs []string `json:"document_ids" yaml:"document_ids"` // Expected sources.
+	Keywords    []string `json:"keywords" yaml:"keywords"`
+	CorpusID    string   `json:"corpus_id" yaml:"corpus_id"`
+	// MessageID names cases without an ID, so that positive answers exported by export-feedback can be used as is.
+	MessageID string `json:"message_id" yaml:"-"`
+}
+
+// loadDataset reads cases from a YAML list or a JSONL file, chosen by the file extension.
+func loadDataset(path string) ([]evalCase, error) {
+	var (
+		cases []evalCase
+		err   error
+	)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		cases, err = loadYAML(path)
+	case ".jsonl", ".ndjson":
+		cases, err = loadJSONL(path)
+	default:
+		return nil, fmt.Errorf("unsupported dataset %q: expected .yaml, .yml or .jsonl", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(cases) == 0 {
+		return nil, errors.New("dataset has no cases")
+	}
+
+	seen := make(map[string]struct{}, len(cases))
+	for i := range cases {
+		c := &cases[i]
+		if strings.TrimSpace(c.Question) == "" {
+			return nil, fmt.Errorf("case %d has no question", i+1)
+		}
+		if c.ID == "" {
+			c.ID = c.MessageID
+		}
+		if c.ID == "" {
+			c.ID = fmt.Sprintf("case-%d", i+1)
+		}
+		if _, ok := seen[c.ID]; ok {
+			return nil, fmt.Errorf("duplicate case id %q", c.ID)
+		}
+		seen[c.ID] = struct{}{}
+	}
+	return cases, nil
+}
+
+func loadYAML(path string) ([]evalCase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read dataset: %w", err)
+	}
+	var cases []evalCase
+	if err := yaml.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("decode dataset: %w", err)
+	}
+	return cases, nil
+}
+
+func loadJSONL(path string) ([]evalCase, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read dataset: %w", err)
+	}
+	defer f.Close() //nolint:errcheck // read only
+
+	var cases []evalCase
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var c evalCase
+		if err := json.Unmarshal([]byte(text), &c); err != nil {
+			return nil, fmt.Errorf("decode dataset line %d: %w", line, err)
+		}
+		cases = append(cases, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read dataset: %w", err)
+	}
+	return cases, nil
+}
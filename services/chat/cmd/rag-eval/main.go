@@ -0,0 +1,312 @@
+// Command rag-eval runs a dataset of questions through the chat pipeline and reports how well retrieval
+// and answers match the expectations: recall@K and MRR of the expected source documents and the share of
+// expected keywords found in the answers. The report has no timestamps, so committing it and diffing the
+// next run shows regressions before deploy.
+//
+// The dataset is a YAML list or a JSONL file of cases with the question, the expected source documents,
+// the keywords of a good answer and an optional corpus:
+//
+//	{"id":"imperative","question":"Что такое категорический императив?","document_ids":["6f1c..."],"keywords":["максим"],"corpus_id":"kant"}
+//
+// Answers exported by export-feedback -rating up can be used as a JSONL dataset as is.
+// By default the service backends are configured with the variables of the chat service; -stub-chunks
+// replaces Qdrant and the model with a keyword search over a JSONL file of {id, document_id, text} chunks.
+//
+// Usage:
+//
+//	rag-eval -dataset eval.yaml [-k 5] [-format text|json] [-out report.txt] [-stub-chunks chunks.jsonl]
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qdrant/go-client/qdrant"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+	"github.com/artmexbet/raibecas/services/chat/internal/neuro"
+	"github.com/artmexbet/raibecas/services/chat/internal/postgres"
+	qdrantWrapper "github.com/artmexbet/raibecas/services/chat/internal/qdrant-wrapper"
+	"github.com/artmexbet/raibecas/services/chat/internal/retriever"
+	"github.com/artmexbet/raibecas/services/chat/internal/service"
+)
+
+const evalUserID = "rag-eval"
+
+type vectorRetriever interface {
+	RetrieveVectors(ctx context.Context, query string, vector []float64, scope domain.SearchScope) ([]domain.Document, error)
+}
+
+type corpusRegistry interface {
+	ListCorpora(ctx context.Context) ([]domain.Corpus, error)
+	GetCorpus(ctx context.Context, id string) (domain.Corpus, error)
+	CreateCorpus(ctx context.Context, corpus domain.Corpus) (domain.Corpus, error)
+	UpdateCorpus(ctx context.Context, corpus domain.Corpus) (domain.Corpus, error)
+	DeleteCorpus(ctx context.Context, id string) error
+}
+
+type options struct {
+	dataset        string
+	k              int
+	format         string
+	out            string
+	stubChunks     string
+	timeout        time.Duration
+	includePrivate bool
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.dataset, "dataset", "", "YAML or JSONL file with the evaluation cases")
+	flag.IntVar(&opts.k, "k", 0, "count only the first k retrieved documents towards recall, all when 0")
+	flag.StringVar(&opts.format, "format", "text", "report format: text or json")
+	flag.StringVar(&opts.out, "out", "-", "output file, - for stdout")
+	flag.StringVar(&opts.stubChunks, "stub-chunks", "", "JSONL chunks to search instead of Qdrant and the model")
+	flag.DurationVar(&opts.timeout, "timeout", 2*time.Minute, "time limit of a single case")
+	flag.BoolVar(&opts.includePrivate, "private", false, "retrieve documents that are not public")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		slog.Error("evaluation failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	if opts.dataset == "" {
+		return errors.New("-dataset is required")
+	}
+	if opts.format != "text" && opts.format != "json" {
+		return fmt.Errorf("invalid -format %q: expected text or json", opts.format)
+	}
+
+	cases, err := loadDataset(opts.dataset)
+	if err != nil {
+		return err
+	}
+
+	var (
+		svc      *service.Chat
+		settings map[string]string
+	)
+	if opts.stubChunks != "" {
+		svc, settings, err = newStubService(opts.stubChunks)
+	} else {
+		var closeBackends func()
+		svc, settings, closeBackends, err = newService(needsCorpora(cases))
+		if closeBackends != nil {
+			defer closeBackends()
+		}
+	}
+	if err != nil {
+		return err
+	}
+	settings["k"] = strconv.Itoa(opts.k)
+	settings["private"] = strconv.FormatBool(opts.includePrivate)
+
+	results := make([]caseResult, 0, len(cases))
+	for _, c := range cases {
+		result := runCase(svc, c, opts)
+		if result.Error != "" {
+			slog.Warn("case failed", "case", c.ID, "error", result.Error)
+		}
+		results = append(results, result)
+	}
+
+	r := report{Dataset: opts.dataset, Settings: settings, Cases: results, Summary: summarize(results)}
+	if err := writeReport(r, opts); err != nil {
+		return err
+	}
+
+	slog.Info("evaluation finished",
+		"cases", r.Summary.Cases,
+		"errors", r.Summary.Errors,
+		"recall", r.Summary.Recall,
+		"mrr", r.Summary.MRR,
+		"keywords", r.Summary.Keywords,
+	)
+	return nil
+}
+
+// runCase asks the question in a new session and evaluates the final answer.
+// A failed case keeps zero recall, so errors lower the averages instead of being skipped.
+func runCase(svc *service.Chat, c evalCase, opts options) caseResult {
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	var (
+		answer    strings.Builder
+		citations []domain.Citation
+	)
+	err := svc.ProcessInput(ctx, c.Question, evalUserID, "", domain.ChatOptions{
+		CorpusID:       c.CorpusID,
+		IncludePrivate: opts.includePrivate,
+	}, func(response domain.ChatResponse) error {
+		if response.Message != nil {
+			answer.WriteString(response.Message.Content)
+		}
+		if response.Done {
+			citations = response.Citations
+		}
+		return nil
+	})
+
+	result := evaluate(c, citations, answer.String(), opts.k)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func writeReport(r report, opts options) error {
+	var w io.Writer = os.Stdout
+	if opts.out != "-" {
+		f, err := os.Create(opts.out)
+		if err != nil {
+			return fmt.Errorf("create output: %w", err)
+		}
+		defer f.Close() //nolint:errcheck // closed after flushing below
+		w = f
+	}
+
+	buf := bufio.NewWriter(w)
+	write := writeText
+	if opts.format == "json" {
+		write = writeJSON
+	}
+	if err := write(buf, r); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	return nil
+}
+
+func needsCorpora(cases []evalCase) bool {
+	for _, c := range cases {
+		if c.CorpusID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// evalContext disables the background work of the service that needs a real session.
+func evalContext(cfg config.ContextGeneration) *config.ContextGeneration {
+	cfg.GenerateTitles = false
+	cfg.SummarizeHistory = false
+	return &cfg
+}
+
+func newStubService(chunksPath string) (*service.Chat, map[string]string, error) {
+	chunks, err := loadChunks(chunksPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var contextCfg config.ContextGeneration
+	contextCfg.MaxTokens = 8192
+	contextCfg.ResponseTokens = 1024
+	contextCfg.CharsPerToken = 3
+
+	const limit = 5
+	svc := service.New(&stubRetriever{chunks: chunks, limit: limit}, stubNeuro{}, &memoryHistory{}, noCorpora{}, nil, nil, nil,
+		evalContext(contextCfg), noop.NewTracerProvider().Tracer("rag-eval"))
+	return svc, map[string]string{
+		"backend":          "stub",
+		"chunks":           chunksPath,
+		"count_of_results": strconv.Itoa(limit),
+	}, nil
+}
+
+// newService connects to the backends of the chat service. Postgres is only needed for corpus lookups.
+func newService(withCorpora bool) (*service.Chat, map[string]string, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	qdrantClient, err := qdrant.NewClient(&qdrant.Config{Host: cfg.Qdrant.Host, Port: cfg.Qdrant.Port})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create qdrant client: %w", err)
+	}
+	closers := []func(){func() { _ = qdrantClient.Close() }}
+	closeAll := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+
+	qdrantWrap := qdrantWrapper.New(&cfg.Qdrant, qdrantClient)
+	if err := qdrantWrap.CheckConnection(ctx); err != nil {
+		return nil, nil, closeAll, fmt.Errorf("failed to check qdrant connection: %w", err)
+	}
+
+	llmProvider, err := neuro.NewProvider(cfg)
+	if err != nil {
+		return nil, nil, closeAll, fmt.Errorf("failed to create LLM provider: %w", err)
+	}
+	llm := neuro.NewConnector(llmProvider, &cfg.Ollama)
+
+	var vectors vectorRetriever = qdrantWrap
+	if cfg.Qdrant.Hybrid.Enabled {
+		// The keyword half of hybrid search lives in the documents service, which is not started for evaluation
+		slog.Warn("hybrid retrieval is not supported by rag-eval, evaluating vector search only")
+	}
+	if cfg.Qdrant.Rerank.Enabled {
+		switch cfg.Qdrant.Rerank.Provider {
+		case config.RerankProviderLLM, "":
+			vectors = retriever.NewReranker(vectors, neuro.NewScorer(llm, &cfg.Qdrant.Rerank), &cfg.Qdrant)
+		case config.RerankProviderHTTP:
+			vectors = retriever.NewReranker(vectors, retriever.NewHTTPScorer(&cfg.Qdrant.Rerank), &cfg.Qdrant)
+		default:
+			return nil, nil, closeAll, fmt.Errorf("unknown re-ranking provider %q", cfg.Qdrant.Rerank.Provider)
+		}
+	}
+
+	var corpora corpusRegistry = noCorpora{}
+	if withCorpora {
+		store, err := postgres.New(ctx, &cfg.Database)
+		if err != nil {
+			return nil, nil, closeAll, fmt.Errorf("failed to connect to postgres: %w", err)
+		}
+		closers = append(closers, store.Close)
+		corpora = store
+	}
+
+	svc := service.New(vectors, llm, &memoryHistory{}, corpora, nil, nil, nil,
+		evalContext(cfg.Ollama.Context), noop.NewTracerProvider().Tracer("rag-eval"))
+
+	settings := map[string]string{
+		"backend":          "live",
+		"llm_provider":     cfg.LLM.Provider,
+		"embedding_model":  cfg.EmbeddingModel(),
+		"generation_model": cfg.Ollama.GenerationModel,
+		"collection":       cfg.Qdrant.CollectionName,
+		"count_of_results": strconv.FormatUint(cfg.Qdrant.CountOfResults, 10),
+		"rerank":           "off",
+		"query_variants":   strconv.Itoa(cfg.Ollama.Context.QueryVariants),
+	}
+	if cfg.LLM.Provider == config.ProviderOpenAI {
+		settings["generation_model"] = cfg.LLM.OpenAI.GenerationModel
+	}
+	if cfg.Qdrant.Rerank.Enabled {
+		settings["rerank"] = cfg.Qdrant.Rerank.Provider
+	}
+	return svc, settings, closeAll, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDataset(t *testing.T) {
+	yamlPath := writeFile(t, "eval.yaml", `
+- id: imperative
+  question: Что такое категорический императив?
+  document_ids: [kant-1]
+  keywords: [максима]
+- question: Что такое дазайн?
+`)
+	cases, err := loadDataset(yamlPath)
+	if err != nil {
+		t.Fatalf("loadDataset(yaml) error = %v", err)
+	}
+	if len(cases) != 2 || cases[0].ID != "imperative" || cases[0].DocumentIDs[0] != "kant-1" || cases[1].ID != "case-2" {
+		t.Errorf("loadDataset(yaml) = %+v", cases)
+	}
+
+	// Lines of export-feedback are named after the rated message
+	jsonlPath := writeFile(t, "eval.jsonl", `{"message_id":"m1","question":"Что такое дазайн?","document_ids":["heidegger-1"],"rating":1}`+"\n")
+	cases, err = loadDataset(jsonlPath)
+	if err != nil {
+		t.Fatalf("loadDataset(jsonl) error = %v", err)
+	}
+	if len(cases) != 1 || cases[0].ID != "m1" {
+		t.Errorf("loadDataset(jsonl) = %+v", cases)
+	}
+
+	for name, content := range map[string]string{
+		"duplicate.yaml": "- {id: a, question: q}\n- {id: a, question: q}\n",
+		"empty.yaml":     "[]\n",
+		"noquestion.yml": "- {id: a}\n",
+		"eval.csv":       "id,question\n",
+	} {
+		if _, err := loadDataset(writeFile(t, name, content)); err == nil {
+			t.Errorf("loadDataset(%s) error = nil, want error", name)
+		}
+	}
+}
+
+func TestRunWithStubs(t *testing.T) {
+	chunks := writeFile(t, "chunks.jsonl", strings.Join([]string{
+		`{"id":"c1","document_id":"kant","text":"Категорический императив: поступай согласно максиме, которая может стать всеобщим законом."}`,
+		`{"id":"c2","document_id":"heidegger","text":"Дазайн есть сущее, которому в его бытии речь идёт о самом этом бытии."}`,
+	}, "\n"))
+	dataset := writeFile(t, "eval.yaml", `
+- id: imperative
+  question: Что такое категорический императив?
+  document_ids: [kant]
+  keywords: [максиме, свобода]
+- id: unknown-corpus
+  question: Что такое дазайн?
+  document_ids: [heidegger]
+  corpus_id: heidegger
+`)
+	out := filepath.Join(t.TempDir(), "report.txt")
+
+	err := run(options{dataset: dataset, format: "text", out: out, stubChunks: chunks, timeout: time.Second})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	report, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"backend: stub\n",
+		"imperative                        1.000  1.000      1/2  kant\n",
+		"unknown-corpus                    0.000  0.000        -  error: corpus not found\n",
+		"errors: 1\n",
+		"recall: 0.500\n",
+		"keywords: 0.500\n",
+	} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("report does not contain %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, report{Dataset: "eval.yaml", Cases: []caseResult{{ID: "a"}}}); err != nil {
+		t.Fatal(err)
+	}
+	// Cases without expected documents have no recall rather than a zero one
+	if !strings.Contains(buf.String(), `"id": "a"`) || strings.Contains(buf.String(), `"reciprocal_rank"`) {
+		t.Errorf("writeJSON() = %s", buf.String())
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// caseResult holds the metrics of a single case.
+type caseResult struct {
+	ID             string   `json:"id"`
+	Retrieved      []string `json:"retrieved"` // Distinct documents of the context in retrieval order.
+	Recall         *float64 `json:"recall,omitempty"`
+	ReciprocalRank *float64 `json:"reciprocal_rank,omitempty"`
+	KeywordsFound  int      `json:"keywords_found"`
+	KeywordsTotal  int      `json:"keywords_total"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// summary averages the metrics over the cases that define them.
+type summary struct {
+	Cases    int     `json:"cases"`
+	Errors   int     `json:"errors"`
+	Recall   float64 `json:"recall"`
+	MRR      float64 `json:"mrr"`
+	Keywords float64 `json:"keywords"` // Share of expected keywords found in the answers.
+}
+
+// evaluate scores the answer to c. Only the first k retrieved documents count towards recall;
+// k <= 0 counts all of them. Cases without expected documents or keywords leave the respective metrics unset.
+func evaluate(c evalCase, citations []domain.Citation, answer string, k int) caseResult {
+	_, retrieved := domain.ContextIDs(citations)
+	result := caseResult{ID: c.ID, Retrieved: retrieved}
+
+	if len(c.DocumentIDs) > 0 {
+		top := retrieved
+		if k > 0 && len(top) > k {
+			top = top[:k]
+		}
+		recall := float64(countFound(c.DocumentIDs, top)) / float64(len(c.DocumentIDs))
+		rr := reciprocalRank(c.DocumentIDs, retrieved)
+		result.Recall, result.ReciprocalRank = &recall, &rr
+	}
+
+	answer = strings.ToLower(answer)
+	for _, keyword := range c.Keywords {
+		if strings.Contains(answer, strings.ToLower(keyword)) {
+			result.KeywordsFound++
+		}
+	}
+	result.KeywordsTotal = len(c.Keywords)
+	return result
+}
+
+func countFound(expected, retrieved []string) int {
+	found := 0
+	for _, id := range expected {
+		for _, r := range retrieved {
+			if r == id {
+				found++
+				break
+			}
+		}
+	}
+	return found
+}
+
+// reciprocalRank returns 1/rank of the first expected document among retrieved, zero when none was retrieved.
+func reciprocalRank(expected, retrieved []string) float64 {
+	for rank, r := range retrieved {
+		for _, id := range expected {
+			if r == id {
+				return 1 / float64(rank+1)
+			}
+		}
+	}
+	return 0
+}
+
+func summarize(results []caseResult) summary {
+	s := summary{Cases: len(results)}
+	var ranked, found, total int
+	for _, r := range results {
+		if r.Error != "" {
+			s.Errors++
+		}
+		if r.Recall != nil {
+			ranked++
+			s.Recall += *r.Recall
+			s.MRR += *r.ReciprocalRank
+		}
+		found += r.KeywordsFound
+		total += r.KeywordsTotal
+	}
+	if ranked > 0 {
+		s.Recall /= float64(ranked)
+		s.MRR /= float64(ranked)
+	}
+	if total > 0 {
+		s.Keywords = float64(found) / float64(total)
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+func TestEvaluate(t *testing.T) {
+	citations := []domain.Citation{
+		{ChunkID: "c1", DocumentID: "d1"},
+		{ChunkID: "c2", DocumentID: "d1"},
+		{ChunkID: "c3", DocumentID: "d2"},
+		{ChunkID: "c4", DocumentID: "d3"},
+	}
+
+	tests := []struct {
+		name         string
+		c            evalCase
+		k            int
+		wantRecall   float64
+		wantRR       float64
+		wantKeywords int
+	}{
+		{name: "all found", c: evalCase{DocumentIDs: []string{"d1", "d3"}}, wantRecall: 1, wantRR: 1},
+		{name: "cut by k", c: evalCase{DocumentIDs: []string{"d1", "d3"}}, k: 2, wantRecall: 0.5, wantRR: 1},
+		{name: "second rank", c: evalCase{DocumentIDs: []string{"d2"}}, wantRecall: 1, wantRR: 0.5},
+		{name: "missed", c: evalCase{DocumentIDs: []string{"d9"}}, wantRecall: 0, wantRR: 0},
+		{name: "keywords", c: evalCase{DocumentIDs: []string{"d3"}, Keywords: []string{"Максим", "закон", "воля"}}, wantRecall: 1, wantRR: 1.0 / 3, wantKeywords: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluate(tt.c, citations, "Поступай по такой максиме, которая может стать всеобщим законом", tt.k)
+
+			if got.Recall == nil || math.Abs(*got.Recall-tt.wantRecall) > 1e-9 {
+				t.Errorf("recall = %v, want %v", got.Recall, tt.wantRecall)
+			}
+			if got.ReciprocalRank == nil || math.Abs(*got.ReciprocalRank-tt.wantRR) > 1e-9 {
+				t.Errorf("reciprocal rank = %v, want %v", got.ReciprocalRank, tt.wantRR)
+			}
+			if got.KeywordsFound != tt.wantKeywords || got.KeywordsTotal != len(tt.c.Keywords) {
+				t.Errorf("keywords = %d/%d, want %d/%d", got.KeywordsFound, got.KeywordsTotal, tt.wantKeywords, len(tt.c.Keywords))
+			}
+		})
+	}
+}
+
+func TestSummarizeSkipsUndefinedMetrics(t *testing.T) {
+	one, half := 1.0, 0.5
+	results := []caseResult{
+		{ID: "a", Recall: &one, ReciprocalRank: &half, KeywordsFound: 1, KeywordsTotal: 2},
+		{ID: "b", KeywordsFound: 2, KeywordsTotal: 2}, // keywords only
+		{ID: "c", Recall: &half, ReciprocalRank: &one, Error: "timeout"},
+	}
+
+	got := summarize(results)
+
+	want := summary{Cases: 3, Errors: 1, Recall: 0.75, MRR: 0.75, Keywords: 0.75}
+	if got != want {
+		t.Errorf("summarize() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// report is the outcome of a run. It contains no timestamps or durations,
+// so reports of the same configuration are identical and regressions show up in a plain diff.
+type report struct {
+	Dataset  string            `json:"dataset"`
+	Settings map[string]string `json:"settings"` // Configuration under test, e.g. models and retrieval limits.
+	Cases    []caseResult      `json:"cases"`
+	Summary  summary           `json:"summary"`
+}
+
+func writeJSON(w io.Writer, r report) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+	return nil
+}
+
+// writeText writes a fixed-width table with one line per case in dataset order followed by the averages.
+func writeText(w io.Writer, r report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "dataset: %s\n", r.Dataset)
+	keys := make([]string, 0, len(r.Settings))
+	for key := range r.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s: %s\n", key, r.Settings[key])
+	}
+
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "%-32s %6s %6s %8s  %s\n", "case", "recall", "rr", "keywords", "retrieved")
+	for _, c := range r.Cases {
+		keywords := "-"
+		if c.KeywordsTotal > 0 {
+			keywords = fmt.Sprintf("%d/%d", c.KeywordsFound, c.KeywordsTotal)
+		}
+		retrieved := strings.Join(c.Retrieved, ",")
+		if c.Error != "" {
+			retrieved = "error: " + c.Error
+		}
+		fmt.Fprintf(&b, "%-32s %6s %6s %8s  %s\n", c.ID, formatMetric(c.Recall), formatMetric(c.ReciprocalRank), keywords, retrieved)
+	}
+
+	s := r.Summary
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "cases: %d\n", s.Cases)
+	fmt.Fprintf(&b, "errors: %d\n", s.Errors)
+	fmt.Fprintf(&b, "recall: %.3f\n", s.Recall)
+	fmt.Fprintf(&b, "mrr: %.3f\n", s.MRR)
+	fmt.Fprintf(&b, "keywords: %.3f\n", s.Keywords)
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
+
+func formatMetric(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.3f", *v)
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+var errNotSupported = errors.New("not supported by rag-eval")
+
+// memoryHistory gives every case an empty session: nothing is read back and saved messages are discarded,
+// so the cases do not influence each other.
+type memoryHistory struct {
+	saved int
+}
+
+func (h *memoryHistory) RetrieveChatHistory(context.Context, string, string) ([]domain.Message, error) {
+	return nil, nil
+}
+
+func (h *memoryHistory) RetrieveBranch(context.Context, string, string, string) ([]domain.Message, error) {
+	return nil, errNotSupported
+}
+
+func (h *memoryHistory) ResolveSession(context.Context, string, string) (string, error) {
+	return "eval", nil
+}
+
+func (h *memoryHistory) SaveMessage(context.Context, string, string, domain.Message) (string, error) {
+	h.saved++
+	return "eval-" + strconv.Itoa(h.saved), nil
+}
+
+func (h *memoryHistory) GetSessionSummary(context.Context, string, string) (domain.SessionSummary, error) {
+	return domain.SessionSummary{}, nil
+}
+
+func (h *memoryHistory) GetMessage(context.Context, string, string, string) (domain.Message, error) {
+	return domain.Message{}, errNotSupported
+}
+
+func (h *memoryHistory) GetLatestLeaf(context.Context, string, string, string) (string, error) {
+	return "", errNotSupported
+}
+
+func (h *memoryHistory) SetActiveMessage(context.Context, string, string, string) error {
+	return errNotSupported
+}
+
+func (h *memoryHistory) ClearChatHistory(context.Context, string) error {
+	return errNotSupported
+}
+
+func (h *memoryHistory) GetChatSize(context.Context, string) (int, error) {
+	return 0, errNotSupported
+}
+
+func (h *memoryHistory) GetUserSessions(context.Context, string, bool, domain.Page) (domain.SessionPage, error) {
+	return domain.SessionPage{}, errNotSupported
+}
+
+func (h *memoryHistory) GetSessionMessages(context.Context, string, string, domain.Page) (domain.MessagePage, error) {
+	return domain.MessagePage{}, errNotSupported
+}
+
+func (h *memoryHistory) UpdateSession(context.Context, string, string, domain.SessionUpdate) (domain.ChatSession, error) {
+	return domain.ChatSession{}, errNotSupported
+}
+
+func (h *memoryHistory) SetGeneratedTitle(context.Context, string, string, string) (domain.ChatSession, bool, error) {
+	return domain.ChatSession{}, false, errNotSupported
+}
+
+func (h *memoryHistory) DeleteSession(context.Context, string, string) error {
+	return errNotSupported
+}
+
+func (h *memoryHistory) SearchMessages(context.Context, string, string, domain.Page) ([]domain.MessageSearchHit, error) {
+	return nil, errNotSupported
+}
+
+func (h *memoryHistory) CreateSession(context.Context, string, string) (string, error) {
+	return "", errNotSupported
+}
+
+func (h *memoryHistory) SaveSessionSummary(context.Context, string, string, domain.SessionSummary) error {
+	return errNotSupported
+}
+
+func (h *memoryHistory) SetMessageFeedback(context.Context, string, string, string, domain.Feedback) error {
+	return errNotSupported
+}
+
+func (h *memoryHistory) CreateSessionShare(context.Context, string, string, string) (domain.SessionShare, error) {
+	return domain.SessionShare{}, errNotSupported
+}
+
+func (h *memoryHistory) GetSessionShare(context.Context, string) (domain.SessionShare, error) {
+	return domain.SessionShare{}, errNotSupported
+}
+
+func (h *memoryHistory) ListSessionShares(context.Context, string, string) ([]domain.SessionShare, error) {
+	return nil, errNotSupported
+}
+
+func (h *memoryHistory) DeleteSessionShare(context.Context, string, string) error {
+	return errNotSupported
+}
+
+// noCorpora is used when no database is configured: cases can only run against the default collection.
+type noCorpora struct{}
+
+func (noCorpora) ListCorpora(context.Context) ([]domain.Corpus, error) {
+	return nil, nil
+}
+
+func (noCorpora) GetCorpus(context.Context, string) (domain.Corpus, error) {
+	return domain.Corpus{}, domain.ErrCorpusNotFound
+}
+
+func (noCorpora) CreateCorpus(context.Context, domain.Corpus) (domain.Corpus, error) {
+	return domain.Corpus{}, errNotSupported
+}
+
+func (noCorpora) UpdateCorpus(context.Context, domain.Corpus) (domain.Corpus, error) {
+	return domain.Corpus{}, errNotSupported
+}
+
+func (noCorpora) DeleteCorpus(context.Context, string) error {
+	return errNotSupported
+}
+
+// stubChunk is a line of the -chunks file.
+type stubChunk struct {
+	ID         string `json:"id"`
+	DocumentID string `json:"document_id"`
+	Text       string `json:"text"`
+}
+
+// stubRetriever ranks chunks by the number of query words they contain.
+// It exercises the pipeline and the dataset without Qdrant or a model.
+type stubRetriever struct {
+	chunks []stubChunk
+	limit  int
+}
+
+func loadChunks(path string) ([]stubChunk, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read chunks: %w", err)
+	}
+	defer f.Close() //nolint:errcheck // read only
+
+	var chunks []stubChunk
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var chunk stubChunk
+		if err := json.Unmarshal([]byte(text), &chunk); err != nil {
+			return nil, fmt.Errorf("decode chunks line %d: %w", line, err)
+		}
+		if chunk.ID == "" {
+			chunk.ID = strconv.Itoa(line)
+		}
+		chunks = append(chunks, chunk)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read chunks: %w", err)
+	}
+	return chunks, nil
+}
+
+func (r *stubRetriever) RetrieveVectors(_ context.Context, query string, _ []float64, scope domain.SearchScope) ([]domain.Document, error) {
+	words := tokenize(query)
+	type scored struct {
+		chunk stubChunk
+		score int
+	}
+	var candidates []scored
+	for _, chunk := range r.chunks {
+		if len(scope.Filters.DocumentIDs) > 0 && !contains(scope.Filters.DocumentIDs, chunk.DocumentID) {
+			continue
+		}
+		text := tokenize(chunk.Text)
+		score := 0
+		for word := range words {
+			if _, ok := text[word]; ok {
+				score++
+			}
+		}
+		if score > 0 {
+			candidates = append(candidates, scored{chunk: chunk, score: score})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+
+	limit := r.limit
+	if scope.Limit > 0 {
+		limit = int(scope.Limit)
+	}
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	docs := make([]domain.Document, len(candidates))
+	for i, c := range candidates {
+		docs[i] = domain.Document{
+			ID:    c.chunk.ID,
+			Score: float32(c.score) / float32(len(words)),
+			Metadata: map[string]interface{}{
+				domain.PayloadDocumentID: c.chunk.DocumentID,
+				domain.PayloadChunkText:  c.chunk.Text,
+			},
+		}
+	}
+	return docs, nil
+}
+
+func tokenize(s string) map[string]struct{} {
+	words := make(map[string]struct{})
+	for _, word := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[word] = struct{}{}
+	}
+	return words
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// stubNeuro answers with the text of the context documents, so keyword hits measure what retrieval delivered.
+type stubNeuro struct{}
+
+func (stubNeuro) GenerateEmbeddings(context.Context, string) ([]float64, error) {
+	return nil, nil
+}
+
+func (stubNeuro) Chat(_ context.Context, workingContext domain.WorkingContext, _ string, fn func(domain.ChatResponse) error) error {
+	texts := make([]string, 0, len(workingContext.Docs))
+	for _, doc := range workingContext.Docs {
+		if text, ok := doc.Metadata[domain.PayloadChunkText].(string); ok {
+			texts = append(texts, text)
+		}
+	}
+	return fn(domain.ChatResponse{
+		Done:      true,
+		Message:   &domain.Message{Role: "assistant", Content: strings.Join(texts, "\n")},
+		CreatedAt: time.Now(),
+	})
+}
+
+func (stubNeuro) Summarize(context.Context, string, []domain.Message) (string, error) {
+	return "", errNotSupported
+}
+
+func (stubNeuro) GenerateTitle(context.Context, string, string) (string, error) {
+	return "", errNotSupported
+}
+
+func (stubNeuro) RewriteQuery(_ context.Context, _ []domain.Message, question string, _ int) ([]string, error) {
+	return []string{question}, nil
+}
@@ -19,15 +19,41 @@ import (
 	"github.com/artmexbet/raibecas/libs/telemetry"
 
 	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/documents"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+	"github.com/artmexbet/raibecas/services/chat/internal/embedcache"
+	"github.com/artmexbet/raibecas/services/chat/internal/events"
 	httphandler "github.com/artmexbet/raibecas/services/chat/internal/handler/http"
 	natshandler "github.com/artmexbet/raibecas/services/chat/internal/handler/nats"
+	"github.com/artmexbet/raibecas/services/chat/internal/inflight"
 	"github.com/artmexbet/raibecas/services/chat/internal/neuro"
 	"github.com/artmexbet/raibecas/services/chat/internal/postgres"
 	qdrantWrapper "github.com/artmexbet/raibecas/services/chat/internal/qdrant-wrapper"
+	"github.com/artmexbet/raibecas/services/chat/internal/quota"
+	"github.com/artmexbet/raibecas/services/chat/internal/retriever"
+	"github.com/artmexbet/raibecas/services/chat/internal/scheduler"
 	"github.com/artmexbet/raibecas/services/chat/internal/service"
 	"github.com/artmexbet/raibecas/services/chat/migrations"
 )
 
+type vectorRetriever interface {
+	RetrieveVectors(ctx context.Context, query string, vector []float64, scope domain.SearchScope) ([]domain.Document, error)
+}
+
+type documentScorer interface {
+	ScoreDocuments(ctx context.Context, query string, docs []domain.Document) ([]float64, error)
+}
+
+type quotaLimiter interface {
+	Acquire(ctx context.Context, userID, role string) (domain.QuotaUsage, error)
+	AddTokens(ctx context.Context, userID, role string, tokens int) (domain.QuotaUsage, error)
+	Refund(ctx context.Context, userID, role string) error
+}
+
+type generationQueue interface {
+	Acquire(ctx context.Context, userID string, queued func(position int)) (func(), error)
+}
+
 // App represents the main entry point for the chat service application.
 type App struct {
 	cfg            *config.Config
@@ -88,11 +114,13 @@ func New() (*App, error) {
 	}
 	slog.Info("connected to NATS", "url", cfg.NATS.URL)
 
-	// Create NATS wrapper client with tracing
+	// Create NATS wrapper client with tracing and metrics
 	var natsClientOpts []natsw.ClientOption
 	natsClientOpts = append(natsClientOpts,
 		natsw.WithRecover(),
 		natsw.WithLogger(slog.Default()),
+		// Per-user subjects are collapsed to their patterns to keep the label cardinality bounded
+		natsw.WithMetrics(natsw.NewMetrics(nil, natsw.WithSubjectLabel(natsw.SubjectPatterns("chat.response.*", "chat.cancel.*")))),
 	)
 	if tp != nil {
 		natsTracer := tp.Tracer("nats-client")
@@ -131,14 +159,6 @@ func New() (*App, error) {
 		return nil, fmt.Errorf("failed to check qdrant connection: %w", err)
 	}
 
-	// Initialize Ollama connector
-	ollama, err := neuro.NewConnector(&cfg.Ollama)
-	if err != nil {
-		natsConn.Close()
-		qdrantClient.Close() //nolint:errcheck // safe to ignore error on close during setup failure
-		return nil, fmt.Errorf("failed to create ollama connector: %w", err)
-	}
-
 	// Initialize Redis client
 	redisClient := redis.NewClient(&redis.Options{Addr: cfg.Redis.GetAddress(), DB: cfg.Redis.DB})
 	if err := redisClient.Ping(ctx).Err(); err != nil {
@@ -148,6 +168,44 @@ func New() (*App, error) {
 	}
 	slog.Info("connected to redis")
 
+	// Initialize LLM connector
+	llmProvider, err := neuro.NewProvider(cfg)
+	if err != nil {
+		natsConn.Close()
+		qdrantClient.Close() //nolint:errcheck // safe to ignore error on close during setup failure
+		redisClient.Close()  //nolint:errcheck // safe to ignore error on close during setup failure
+		return nil, fmt.Errorf("failed to create LLM provider: %w", err)
+	}
+	if cfg.Embedding.Enabled {
+		// Identical questions are embedded once; the model is part of the key so changing it invalidates the cache
+		llmProvider = embedcache.New(llmProvider, redisClient, &cfg.Embedding, cfg.EmbeddingModel(), embedcache.NewMetrics(nil))
+		slog.Info("embedding cache enabled", "size", cfg.Embedding.Size, "ttl", cfg.Embedding.TTL)
+	}
+	llm := neuro.NewConnector(llmProvider, &cfg.Ollama)
+	slog.Info("LLM provider initialized", "provider", cfg.LLM.Provider)
+
+	scorer, err := newScorer(cfg, llm)
+	if err != nil {
+		natsConn.Close()
+		qdrantClient.Close() //nolint:errcheck // safe to ignore error on close during setup failure
+		redisClient.Close()  //nolint:errcheck // safe to ignore error on close during setup failure
+		return nil, fmt.Errorf("failed to create re-ranking scorer: %w", err)
+	}
+
+	// Generation quotas are counted in Redis so that they hold across replicas
+	var quotas quotaLimiter
+	if cfg.Quota.Enabled {
+		limiter, err := quota.New(redisClient, &cfg.Quota)
+		if err != nil {
+			natsConn.Close()
+			qdrantClient.Close() //nolint:errcheck // safe to ignore error on close during setup failure
+			redisClient.Close()  //nolint:errcheck // safe to ignore error on close during setup failure
+			return nil, fmt.Errorf("failed to configure quotas: %w", err)
+		}
+		quotas = limiter
+		slog.Info("chat quotas enabled", "requests_per_minute", cfg.Quota.RequestsPerMinute, "tokens_per_day", cfg.Quota.TokensPerDay)
+	}
+
 	// Initialize PostgreSQL store for chat history
 	pgStore, err := postgres.New(ctx, &cfg.Database)
 	if err != nil {
@@ -158,12 +216,41 @@ func New() (*App, error) {
 	}
 	slog.Info("connected to postgres (chat history)")
 
+	// Session events (e.g. generated titles) are pushed to WebSocket clients
+	sessionEvents := events.NewBroker()
+
 	// Create service
 	serviceTracer := otel.GetTracerProvider().Tracer("chat-service")
-	svc := service.New(qdrantWrap, ollama, pgStore, serviceTracer)
+	var vectors vectorRetriever = qdrantWrap
+	if cfg.Qdrant.Hybrid.Enabled {
+		vectors = retriever.NewHybrid(qdrantWrap, documents.NewConnector(natsClient, cfg.NATS.RequestTimeout), &cfg.Qdrant)
+		slog.Info("hybrid retrieval enabled")
+	}
+	if cfg.Qdrant.Rerank.Enabled {
+		vectors = retriever.NewReranker(vectors, scorer, &cfg.Qdrant)
+		slog.Info("re-ranking enabled", "provider", cfg.Qdrant.Rerank.Provider)
+	}
+	// Generations over the limit wait in a queue served round-robin across users
+	var queue generationQueue
+	if cfg.Queue.MaxConcurrent > 0 {
+		queue = scheduler.New(&cfg.Queue, scheduler.NewMetrics(nil))
+		slog.Info("generation queue enabled", "max_concurrent", cfg.Queue.MaxConcurrent, "max_queued", cfg.Queue.MaxQueued)
+	}
+	svc := service.New(vectors, llm, pgStore, pgStore, sessionEvents, quotas, queue, &cfg.Ollama.Context, serviceTracer)
+
+	// In-flight generations are shared by transports so a request can be cancelled from any of them
+	requests := inflight.NewRegistry()
 
 	// Create NATS handler
-	natsHandler := natshandler.NewHandler(natsClient, svc)
+	natsService := natsw.NewService(natsClient, natsw.ServiceConfig{
+		Name:          "chat",
+		Version:       cfg.Telemetry.ServiceVersion,
+		Description:   "RAG chat over the document corpora",
+		QueueGroup:    cfg.NATS.QueueGroup,
+		MaxConcurrent: cfg.NATS.MaxConcurrent,
+		DrainTimeout:  cfg.NATS.DrainTimeout,
+	})
+	natsHandler := natshandler.NewHandler(natsClient, natsService, svc, requests)
 	if err := natsHandler.Subscribe(); err != nil {
 		natsConn.Close()
 		qdrantClient.Close() //nolint:errcheck // safe to ignore error on close during setup failure
@@ -173,7 +260,7 @@ func New() (*App, error) {
 	slog.Info("NATS handler subscribed")
 
 	// Create HTTP handler (always, port driven by config)
-	httpHandler := httphandler.New(&cfg.HTTP, svc)
+	httpHandler := httphandler.New(&cfg.HTTP, svc, requests, sessionEvents)
 	httpHandler.RegisterRoutes()
 
 	return &App{
@@ -190,6 +277,18 @@ func New() (*App, error) {
 	}, nil
 }
 
+// newScorer returns the scorer of the configured re-ranking provider.
+func newScorer(cfg *config.Config, llm *neuro.Connector) (documentScorer, error) {
+	switch cfg.Qdrant.Rerank.Provider {
+	case config.RerankProviderLLM, "":
+		return neuro.NewScorer(llm, &cfg.Qdrant.Rerank), nil
+	case config.RerankProviderHTTP:
+		return retriever.NewHTTPScorer(&cfg.Qdrant.Rerank), nil
+	default:
+		return nil, fmt.Errorf("unknown re-ranking provider %q", cfg.Qdrant.Rerank.Provider)
+	}
+}
+
 // Run starts the application and blocks until shutdown signal is received.
 func (a *App) Run() error {
 	// Start HTTP server in a goroutine
@@ -213,6 +312,11 @@ func (a *App) Run() error {
 
 	slog.InfoContext(shutdownCtx, "shutting down the service...")
 
+	// Finish in-flight NATS requests before closing connections
+	if err := a.natsHandler.Stop(shutdownCtx); err != nil {
+		slog.ErrorContext(shutdownCtx, "NATS handler drain error", "error", err)
+	}
+
 	// Shutdown HTTP server gracefully
 	if err := a.httpHandler.Shutdown(shutdownCtx); err != nil {
 		slog.ErrorContext(shutdownCtx, "HTTP server shutdown error", "error", err)
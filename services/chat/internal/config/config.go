@@ -15,6 +15,42 @@ type Qdrant struct {
 	RetrievePayload bool   `yaml:"retrieve_payload" env:"RETRIEVE_PAYLOAD" env-default:"true"`
 	CountOfResults  uint64 `yaml:"count_of_results" env:"COUNT_OF_RESULTS" env-default:"5"`
 	VectorDimension uint64 `yaml:"vector_dimension" env:"VECTOR_DIMENSION" env-default:"768"`
+
+	Hybrid HybridSearch `yaml:"hybrid" env-prefix:"HYBRID_"`
+	Rerank Rerank       `yaml:"rerank" env-prefix:"RERANK_"`
+}
+
+// HybridSearch configures fusion of vector hits with full-text hits from the documents service.
+type HybridSearch struct {
+	Enabled           bool    `yaml:"enabled" env:"ENABLED" env-default:"false"`
+	VectorWeight      float64 `yaml:"vector_weight" env:"VECTOR_WEIGHT" env-default:"1.0"`
+	KeywordWeight     float64 `yaml:"keyword_weight" env:"KEYWORD_WEIGHT" env-default:"1.0"`
+	RRFConstant       int     `yaml:"rrf_constant" env:"RRF_CONSTANT" env-default:"60"`
+	KeywordCandidates int     `yaml:"keyword_candidates" env:"KEYWORD_CANDIDATES" env-default:"10"`
+}
+
+// Supported values of Rerank.Provider.
+const (
+	RerankProviderLLM  = "llm"  // the generation model rates the candidates in a single prompt
+	RerankProviderHTTP = "http" // a reranker server implementing the /v1/rerank API
+)
+
+// Rerank configures re-scoring of over-fetched candidates before they are put into the context.
+type Rerank struct {
+	Enabled    bool   `yaml:"enabled" env:"ENABLED" env-default:"false"`
+	Provider   string `yaml:"provider" env:"PROVIDER" env-default:"llm"`
+	Candidates uint64 `yaml:"candidates" env:"CANDIDATES" env-default:"20"` // fetched from the vector store
+	TopK       int    `yaml:"top_k" env:"TOP_K" env-default:"0"`            // kept after re-scoring, CountOfResults when zero
+	// Candidates scoring below the threshold are dropped, even if no context remains.
+	// LLM scores are in [0, 1]; the scale of HTTP rerankers depends on the model.
+	Threshold float64       `yaml:"threshold" env:"THRESHOLD" env-default:"0"`
+	Timeout   time.Duration `yaml:"timeout" env:"TIMEOUT" env-default:"20s"`
+
+	Prompt string `yaml:"prompt" env:"PROMPT" env-default:"Оцени по шкале от 0 до 10, насколько каждый фрагмент помогает ответить на вопрос. Ответь только строками вида «номер: оценка» для каждого фрагмента, без пояснений."`
+
+	URL    string `yaml:"url" env:"URL" env-default:"http://localhost:8000/v1/rerank"`
+	Model  string `yaml:"model" env:"MODEL"`
+	APIKey string `yaml:"api_key" env:"API_KEY"`
 }
 
 func (q *Qdrant) GetAddress() string {
@@ -26,6 +62,31 @@ type ContextGeneration struct {
 	BasePrompt      string `yaml:"base_prompt" env:"BASE_PROMPT"` //todo: maybe use file?
 	ContextPrompt   string `yaml:"context_prompt" env:"CONTEXT_PROMPT"`
 	QueryPrompt     string `yaml:"query_prompt" env:"QUERY_PROMPT"`
+
+	// Token budget of a single generation request. Zero disables trimming.
+	MaxTokens      int     `yaml:"max_tokens" env:"MAX_TOKENS" env-default:"8192"`
+	ResponseTokens int     `yaml:"response_tokens" env:"RESPONSE_TOKENS" env-default:"1024"` // reserved for the answer
+	RecentMessages int     `yaml:"recent_messages" env:"RECENT_MESSAGES" env-default:"4"`    // always kept verbatim
+	CharsPerToken  float64 `yaml:"chars_per_token" env:"CHARS_PER_TOKEN" env-default:"3.0"`  // used to estimate token counts
+
+	SummarizeHistory bool   `yaml:"summarize_history" env:"SUMMARIZE_HISTORY" env-default:"true"`
+	SummaryPrompt    string `yaml:"summary_prompt" env:"SUMMARY_PROMPT" env-default:"Кратко перескажи диалог, сохранив факты, вопросы пользователя и выводы. Если дано предыдущее резюме, дополни его."`
+
+	// Session titles are generated from the first exchange in background.
+	GenerateTitles bool          `yaml:"generate_titles" env:"GENERATE_TITLES" env-default:"true"`
+	TitlePrompt    string        `yaml:"title_prompt" env:"TITLE_PROMPT" env-default:"Придумай короткое название (не больше пяти слов) для диалога по первому вопросу и ответу. Ответь только названием, без кавычек и пояснений."`
+	TitleMaxLength int           `yaml:"title_max_length" env:"TITLE_MAX_LENGTH" env-default:"60"` // in runes
+	TitleTimeout   time.Duration `yaml:"title_timeout" env:"TITLE_TIMEOUT" env-default:"30s"`
+
+	// Follow-up questions are rewritten into standalone search queries before retrieval.
+	// Off by default: the rewrite is an extra blocking model call on every follow-up turn.
+	RewriteQueries bool   `yaml:"rewrite_queries" env:"REWRITE_QUERIES" env-default:"false"`
+	RewritePrompt  string `yaml:"rewrite_prompt" env:"REWRITE_PROMPT" env-default:"Перепиши последний вопрос пользователя в самостоятельный поисковый запрос, понятный без истории диалога: замени местоимения упомянутыми именами, названиями и понятиями. Ответь только запросом, без пояснений."`
+	// Number of recent messages shown to the model when rewriting.
+	RewriteHistoryMessages int           `yaml:"rewrite_history_messages" env:"REWRITE_HISTORY_MESSAGES" env-default:"6"`
+	RewriteTimeout         time.Duration `yaml:"rewrite_timeout" env:"REWRITE_TIMEOUT" env-default:"15s"`
+	// Number of paraphrases retrieved for in addition to the query. Zero disables multi-query retrieval.
+	QueryVariants int `yaml:"query_variants" env:"QUERY_VARIANTS" env-default:"0"`
 }
 
 type Ollama struct {
@@ -45,6 +106,30 @@ func (o *Ollama) GetAddress() string {
 	return fmt.Sprintf("%s://%s:%s", o.Protocol, o.Host, o.Port)
 }
 
+// Supported values of LLM.Provider.
+const (
+	ProviderOllama = "ollama"
+	ProviderOpenAI = "openai"
+)
+
+// LLM selects the language model backend.
+// Prompts and generation settings are shared by all backends and stay in Ollama.Context for compatibility.
+type LLM struct {
+	Provider string `yaml:"provider" env:"PROVIDER" env-default:"ollama"`
+	OpenAI   OpenAI `yaml:"openai" env-prefix:"OPENAI_"`
+}
+
+// OpenAI configures any server implementing the OpenAI /v1/chat/completions and /v1/embeddings API
+// (vLLM, llama.cpp server, LocalAI, ...).
+type OpenAI struct {
+	BaseURL string        `yaml:"base_url" env:"BASE_URL" env-default:"http://localhost:8000/v1"`
+	APIKey  string        `yaml:"api_key" env:"API_KEY"`
+	Timeout time.Duration `yaml:"timeout" env:"TIMEOUT" env-default:"120s"` // whole request, including the streamed answer
+
+	EmbeddingModel  string `yaml:"embedding-model" env:"EMBEDDING_MODEL"`
+	GenerationModel string `yaml:"generation-model" env:"GENERATION_MODEL"`
+}
+
 type Redis struct {
 	Host       string        `yaml:"host" env:"HOST" env-default:"localhost"`
 	Port       string        `yaml:"port" env:"PORT" env-default:"6379"`
@@ -71,6 +156,9 @@ type NATS struct {
 	RequestTimeout time.Duration `yaml:"request_timeout" env:"REQUEST_TIMEOUT" env-default:"30s"`
 	MaxReconnects  int           `yaml:"max_reconnects" env:"MAX_RECONNECTS" env-default:"10"`
 	ReconnectWait  time.Duration `yaml:"reconnect_wait" env:"RECONNECT_WAIT" env-default:"2s"`
+	QueueGroup     string        `yaml:"queue_group" env:"QUEUE_GROUP" env-default:"chat"`
+	MaxConcurrent  int           `yaml:"max_concurrent" env:"MAX_CONCURRENT" env-default:"32"`
+	DrainTimeout   time.Duration `yaml:"drain_timeout" env:"DRAIN_TIMEOUT" env-default:"20s"`
 }
 
 type Database struct {
@@ -101,10 +189,37 @@ type TelemetryConfig struct {
 	MaxExportBatch int           `env:"MAX_EXPORT_BATCH" env-default:"512"`
 }
 
+// EmbeddingCache configures caching of query embeddings in memory and in Redis.
+type EmbeddingCache struct {
+	Enabled bool          `yaml:"enabled" env:"ENABLED" env-default:"true"`
+	Size    int           `yaml:"size" env:"SIZE" env-default:"1024"` // entries kept in memory
+	TTL     time.Duration `yaml:"ttl" env:"TTL" env-default:"168h"`   // of Redis entries
+}
+
+// Quota limits the chat generations of every user. A limit of 0 disables it.
+type Quota struct {
+	Enabled           bool `yaml:"enabled" env:"ENABLED" env-default:"false"`
+	RequestsPerMinute int  `yaml:"requests_per_minute" env:"REQUESTS_PER_MINUTE" env-default:"10"`
+	TokensPerDay      int  `yaml:"tokens_per_day" env:"TOKENS_PER_DAY" env-default:"200000"` // estimated prompt and answer tokens
+	// Roles overrides the limits of roles as role:requests_per_minute/tokens_per_day pairs, e.g. "Admin:0/0,Reader:5/50000"
+	Roles map[string]string `yaml:"roles" env:"ROLES" env-default:"Admin:0/0,SuperAdmin:0/0"`
+}
+
+// Generation limits the answers generated at the same time, so that one user cannot saturate the model.
+// Requests over the limit wait in a queue that serves users in turn.
+type Generation struct {
+	MaxConcurrent int `yaml:"max_concurrent" env:"MAX_CONCURRENT" env-default:"4"` // zero disables the queue
+	MaxQueued     int `yaml:"max_queued" env:"MAX_QUEUED" env-default:"100"`       // waiting requests, unbounded when zero
+}
+
 type Config struct {
 	Qdrant    Qdrant          `yaml:"qdrant" env-prefix:"QDRANT_"`
 	Ollama    Ollama          `yaml:"ollama" env-prefix:"OLLAMA_"`
+	LLM       LLM             `yaml:"llm" env-prefix:"LLM_"`
 	Redis     Redis           `yaml:"redis" env-prefix:"REDIS_"`
+	Embedding EmbeddingCache  `yaml:"embedding_cache" env-prefix:"EMBEDDING_CACHE_"`
+	Quota     Quota           `yaml:"quota" env-prefix:"QUOTA_"`
+	Queue     Generation      `yaml:"generation_queue" env-prefix:"GENERATION_QUEUE_"`
 	NATS      NATS            `yaml:"nats" env-prefix:"NATS_"`
 	Database  Database        `yaml:"database" env-prefix:"CHAT_DB_"`
 	Telemetry TelemetryConfig `yaml:"telemetry" env-prefix:"TELEMETRY_"`
@@ -113,6 +228,14 @@ type Config struct {
 	UseHTTP bool `yaml:"use_http" env:"USE_HTTP" env-default:"false"`
 }
 
+// EmbeddingModel returns the embedding model of the selected LLM provider.
+func (c *Config) EmbeddingModel() string {
+	if c.LLM.Provider == ProviderOpenAI {
+		return c.LLM.OpenAI.EmbeddingModel
+	}
+	return c.Ollama.EmbeddingModel
+}
+
 // Load loads configuration from environment variables using cleanenv
 func Load() (*Config, error) {
 	var cfg Config
@@ -0,0 +1,54 @@
+package documents
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dtodocs "github.com/artmexbet/raibecas/libs/dto/documents"
+	"github.com/artmexbet/raibecas/libs/natsw"
+)
+
+// SubjectSearchFullText is served by the documents service and ranks documents by Postgres full-text search
+// over their titles and descriptions.
+const SubjectSearchFullText = "documents.search.fulltext"
+
+const defaultTimeout = 5 * time.Second
+
+// searchFullTextEndpoint is the typed request-reply endpoint behind SubjectSearchFullText.
+var searchFullTextEndpoint = natsw.NewEndpoint[dtodocs.FullTextSearchRequest, dtodocs.FullTextSearchResponse](SubjectSearchFullText)
+
+// Connector talks to the documents service over NATS.
+type Connector struct {
+	client  *natsw.Client
+	timeout time.Duration
+}
+
+// NewConnector creates a documents service connector. A zero timeout falls back to 5 seconds.
+func NewConnector(client *natsw.Client, timeout time.Duration) *Connector {
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Connector{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// SearchFullText returns IDs of documents matching the query, best match first.
+func (c *Connector) SearchFullText(ctx context.Context, query string, limit int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := natsw.Call(ctx, c.client, searchFullTextEndpoint, &dtodocs.FullTextSearchRequest{Query: query, Limit: limit})
+	if err != nil {
+		return nil, fmt.Errorf("full-text search failed: %w", err)
+	}
+
+	ids := make([]string, len(resp.Matches))
+	for i, match := range resp.Matches {
+		ids[i] = match.DocumentID.String()
+	}
+	return ids, nil
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+)
+
+const (
+	defaultCharsPerToken = 3.0
+
+	messageOverheadTokens  = 4  // role markers and separators added per message
+	documentOverheadTokens = 32 // metadata header rendered for every context document
+	minDocumentTokens      = 64 // smaller truncated documents are not worth sending
+)
+
+// SessionSummary is a rolled-up summary of the oldest messages of a chat session.
+type SessionSummary struct {
+	Content      string
+	MessageCount int // Number of leading session messages covered by Content.
+}
+
+// EstimateTokens approximates the number of tokens in s from its length in runes.
+func EstimateTokens(s string, charsPerToken float64) int {
+	if s == "" {
+		return 0
+	}
+	if charsPerToken <= 0 {
+		charsPerToken = defaultCharsPerToken
+	}
+	return int(math.Ceil(float64(utf8.RuneCountInString(s)) / charsPerToken))
+}
+
+// FitToBudget trims the working context to the token budget from cfg.
+// The system prompt, the new prompt, the summary and the cfg.RecentMessages most recent messages are always kept.
+// The remaining budget is spent on documents in descending score order, truncating the first one that does not fit,
+// and then on older messages from newest to oldest.
+// Returns the trimmed context and the messages that did not fit, in chronological order.
+func (wc WorkingContext) FitToBudget(prompt string, cfg config.ContextGeneration) (WorkingContext, []Message) {
+	if cfg.MaxTokens <= 0 {
+		return wc, nil
+	}
+
+	estimate := func(s string) int { return EstimateTokens(s, cfg.CharsPerToken) }
+	messageTokens := func(m Message) int { return estimate(m.Content) + messageOverheadTokens }
+
+	budget := cfg.MaxTokens - cfg.ResponseTokens -
+		estimate(cfg.BasePrompt) - estimate(wc.PrepareContext(prompt, cfg)) - estimate(wc.Summary) -
+		3*messageOverheadTokens
+
+	keepFrom := max(len(wc.Messages)-cfg.RecentMessages, 0)
+	for _, m := range wc.Messages[keepFrom:] {
+		budget -= messageTokens(m)
+	}
+
+	order := make([]int, len(wc.Docs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return wc.Docs[order[i]].Score > wc.Docs[order[j]].Score
+	})
+
+	kept := make(map[int]Document, len(wc.Docs))
+	for _, i := range order {
+		doc := wc.Docs[i]
+		content, _ := doc.Metadata[PayloadChunkText].(string)
+		tokens := estimate(content) + documentOverheadTokens
+		if tokens <= budget {
+			kept[i] = doc
+			budget -= tokens
+			continue
+		}
+		if available := budget - documentOverheadTokens; available >= minDocumentTokens {
+			ratio := cfg.CharsPerToken
+			if ratio <= 0 {
+				ratio = defaultCharsPerToken
+			}
+			kept[i] = doc.truncated(int(float64(available) * ratio))
+			budget = 0
+		}
+	}
+
+	docs := make([]Document, 0, len(kept))
+	for i := range wc.Docs {
+		if doc, ok := kept[i]; ok {
+			docs = append(docs, doc)
+		}
+	}
+
+	for keepFrom > 0 && messageTokens(wc.Messages[keepFrom-1]) <= budget {
+		keepFrom--
+		budget -= messageTokens(wc.Messages[keepFrom])
+	}
+
+	return WorkingContext{
+		Messages: wc.Messages[keepFrom:],
+		Docs:     docs,
+		Summary:  wc.Summary,
+		Persona:  wc.Persona,
+	}, wc.Messages[:keepFrom]
+}
+
+// EstimateTokens approximates the number of tokens the working context and prompt are sent as,
+// using the same estimates as FitToBudget.
+func (wc WorkingContext) EstimateTokens(prompt string, cfg config.ContextGeneration) int {
+	estimate := func(s string) int { return EstimateTokens(s, cfg.CharsPerToken) }
+
+	tokens := estimate(cfg.BasePrompt) + estimate(wc.PrepareContext(prompt, cfg)) + estimate(wc.Summary) +
+		3*messageOverheadTokens
+	for _, m := range wc.Messages {
+		tokens += estimate(m.Content) + messageOverheadTokens
+	}
+	for _, doc := range wc.Docs {
+		content, _ := doc.Metadata[PayloadChunkText].(string)
+		tokens += estimate(content) + documentOverheadTokens
+	}
+	return tokens
+}
+
+// truncated returns a copy of the document with its chunk text cut to at most limit runes.
+func (d Document) truncated(limit int) Document {
+	metadata := make(map[string]interface{}, len(d.Metadata))
+	for k, v := range d.Metadata {
+		metadata[k] = v
+	}
+	if content, ok := metadata[PayloadChunkText].(string); ok {
+		metadata[PayloadChunkText] = excerpt(content, limit)
+	}
+	d.Metadata = metadata
+	return d
+}
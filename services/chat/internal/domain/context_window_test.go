@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	if got := EstimateTokens("", 3); got != 0 {
+		t.Errorf("empty string: expected 0, got %d", got)
+	}
+	if got := EstimateTokens("абвгд", 2); got != 3 {
+		t.Errorf("expected 3 tokens for 5 runes at 2 runes/token, got %d", got)
+	}
+	if got := EstimateTokens("abcdef", 0); got != 2 {
+		t.Errorf("expected default ratio to give 2 tokens, got %d", got)
+	}
+}
+
+func TestFitToBudgetDisabled(t *testing.T) {
+	wc := WorkingContext{Messages: []Message{{Role: "user", Content: strings.Repeat("a", 1000)}}}
+
+	fitted, dropped := wc.FitToBudget("q", config.ContextGeneration{})
+
+	if len(fitted.Messages) != 1 || dropped != nil {
+		t.Errorf("expected context to be untouched, got %d messages and %d dropped", len(fitted.Messages), len(dropped))
+	}
+}
+
+func TestFitToBudgetDropsOldestMessages(t *testing.T) {
+	msgs := make([]Message, 6)
+	for i := range msgs {
+		msgs[i] = Message{Role: "user", Content: strings.Repeat("a", 96)} // 32 tokens + overhead
+	}
+	cfg := config.ContextGeneration{MaxTokens: 200, CharsPerToken: 3, RecentMessages: 2}
+
+	fitted, dropped := WorkingContext{Messages: msgs}.FitToBudget("q", cfg)
+
+	if len(fitted.Messages)+len(dropped) != len(msgs) {
+		t.Fatalf("messages lost: %d kept, %d dropped", len(fitted.Messages), len(dropped))
+	}
+	if len(dropped) == 0 {
+		t.Fatal("expected some messages to be dropped")
+	}
+	if len(fitted.Messages) < cfg.RecentMessages {
+		t.Errorf("expected at least %d recent messages to be kept, got %d", cfg.RecentMessages, len(fitted.Messages))
+	}
+	if &dropped[0] != &msgs[0] {
+		t.Error("expected the oldest messages to be dropped first")
+	}
+}
+
+func TestFitToBudgetPrefersHighScoringDocuments(t *testing.T) {
+	doc := func(id string, score float32, runes int) Document {
+		return Document{ID: id, Score: score, Metadata: map[string]interface{}{
+			PayloadChunkText: strings.Repeat("я", runes),
+		}}
+	}
+	docs := []Document{
+		doc("low", 0.1, 300),
+		doc("high", 0.9, 300),
+		doc("mid", 0.5, 600),
+	}
+	// 100 + 32 for "high", then 169 tokens left: "mid" (232) is truncated, "low" dropped.
+	cfg := config.ContextGeneration{MaxTokens: 300 + 3*messageOverheadTokens + 1, CharsPerToken: 3}
+
+	fitted, _ := WorkingContext{Docs: docs}.FitToBudget("", cfg)
+
+	if len(fitted.Docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(fitted.Docs))
+	}
+	if fitted.Docs[0].ID != "high" || fitted.Docs[1].ID != "mid" {
+		t.Errorf("unexpected documents %s, %s", fitted.Docs[0].ID, fitted.Docs[1].ID)
+	}
+	truncated := []rune(fitted.Docs[1].Metadata[PayloadChunkText].(string))
+	if len(truncated) >= 600 {
+		t.Errorf("expected the last document to be truncated, got %d runes", len(truncated))
+	}
+	if len([]rune(docs[2].Metadata[PayloadChunkText].(string))) != 600 {
+		t.Error("truncation must not modify the original document")
+	}
+}
+
+func TestWorkingContextEstimateTokens(t *testing.T) {
+	cfg := config.ContextGeneration{CharsPerToken: 3, BasePrompt: strings.Repeat("a", 30)}
+	wc := WorkingContext{
+		Messages: []Message{{Role: "user", Content: strings.Repeat("b", 30)}},
+		Docs:     []Document{{Metadata: map[string]interface{}{PayloadChunkText: strings.Repeat("c", 60)}}},
+	}
+
+	// base 10 + prompt 1 + overhead 12, message 10+4, document 20+32
+	if got := wc.EstimateTokens("q", cfg); got != 89 {
+		t.Errorf("EstimateTokens() = %d, want 89", got)
+	}
+}
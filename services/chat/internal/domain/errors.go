@@ -1,9 +1,43 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	ErrDocumentWithoutContent = errors.New("document without content")
 	ErrInvalidChatSessionID   = errors.New("invalid chat session id")
 	ErrChatSessionNotFound    = errors.New("chat session not found")
+	ErrInvalidMessageID       = errors.New("invalid message id")
+	ErrMessageNotFound        = errors.New("message not found")
+	ErrNotUserMessage         = errors.New("only user messages can be edited")
+	ErrNothingToRegenerate    = errors.New("no prompt to regenerate")
+	ErrInvalidSessionTitle    = errors.New("session title must not be empty")
+	ErrEmptySearchQuery       = errors.New("search query must not be empty")
+	ErrInvalidCorpus          = errors.New("corpus requires a lowercase slug id, a name, a collection and a temperature between 0 and 2")
+	ErrCorpusNotFound         = errors.New("corpus not found")
+	ErrCorpusExists           = errors.New("corpus already exists")
+	ErrInvalidFilters         = errors.New("search filters allow at most 100 values per list and published_from must not be after published_to")
+	ErrInvalidFeedback        = errors.New("feedback rating must be -1, 0 or 1 and the comment at most 2000 characters")
+	ErrNotAssistantMessage    = errors.New("only assistant answers can be rated")
+	ErrQuotaExceeded          = errors.New("quota exceeded")
+	ErrQueueFull              = errors.New("generation queue is full")
+	ErrNothingToShare         = errors.New("session has no messages to share")
+	ErrShareNotFound          = errors.New("shared transcript not found")
 )
+
+// QuotaExceededError reports the quota a rejected generation would exceed. It matches ErrQuotaExceeded.
+type QuotaExceededError struct {
+	Quota      string        // QuotaRequestsPerMinute or QuotaTokensPerDay.
+	RetryAfter time.Duration // Until the quota window resets.
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota exceeded: %s, retry in %s", e.Quota, e.RetryAfter.Round(time.Second))
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -9,40 +11,422 @@ import (
 
 //go:generate easyjson -all models.go
 
+var corpusIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
 const (
 	AdditionalCountOfTokens = 512 // in tokens
+
+	CitationExcerptLength = 300 // in runes
+
+	// DefaultSessionTitle is given to new sessions until a title is generated or set by the user.
+	DefaultSessionTitle = "Новый чат"
+
+	// MaxFilterValues bounds the number of IDs in every list of SearchFilters.
+	MaxFilterValues = 100
+
+	// MaxFeedbackCommentLength bounds the comment of a Feedback, in runes.
+	MaxFeedbackCommentLength = 2000
+)
+
+// Feedback ratings. FeedbackNone removes the feedback of a message.
+const (
+	FeedbackNegative = -1
+	FeedbackNone     = 0
+	FeedbackPositive = 1
+)
+
+// Payload keys written by the indexing pipeline into every Qdrant point.
+const (
+	PayloadDocumentID = "document_id"
+	PayloadChunkText  = "chunk_text"
+	PayloadOrdinal    = "ordinal"
+
+	PayloadAuthorIDs       = "author_ids"       // Author IDs of all document participants.
+	PayloadTagIDs          = "tag_ids"          // Integer tag IDs of the document.
+	PayloadPublicationDate = "publication_date" // RFC 3339 date.
+	PayloadIsPublic        = "is_public"        // Visibility flag of the documents service.
+)
+
+// Roles allowed to retrieve private documents. Mirrors the roles issued by the auth service.
+const (
+	RoleAdmin      = "Admin"
+	RoleSuperAdmin = "SuperAdmin"
 )
 
+// CanReadPrivateDocuments reports whether users with role may retrieve documents that are not public.
+func CanReadPrivateDocuments(role string) bool {
+	return role == RoleAdmin || role == RoleSuperAdmin
+}
+
 type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	ID        string     `json:"id,omitempty"`        // Empty for messages that are not persisted.
+	ParentID  string     `json:"parent_id,omitempty"` // Previous message of the branch. Empty for the first message.
+	Role      string     `json:"role"`
+	Content   string     `json:"content"`
+	Citations []Citation `json:"citations,omitempty"` // Sources used for an assistant answer. May be empty.
+	Truncated bool       `json:"truncated,omitempty"` // Generation was stopped before the answer was complete.
+	// SiblingIDs lists the alternative versions of this message (including itself) in creation order.
+	// Set only when the message has been regenerated or edited.
+	SiblingIDs []string  `json:"sibling_ids,omitempty"`
+	Feedback   *Feedback `json:"feedback,omitempty"` // Rating of an assistant answer by the user. Nil when not rated.
+}
+
+// Feedback is the user's rating of an assistant answer.
+type Feedback struct {
+	Rating  int    `json:"rating"` // FeedbackPositive or FeedbackNegative; FeedbackNone removes the feedback.
+	Comment string `json:"comment,omitempty"`
+}
+
+// Validate checks the rating and the comment length.
+func (f Feedback) Validate() error {
+	if f.Rating < FeedbackNegative || f.Rating > FeedbackPositive {
+		return ErrInvalidFeedback
+	}
+	if len([]rune(f.Comment)) > MaxFeedbackCommentLength {
+		return ErrInvalidFeedback
+	}
+	return nil
+}
+
+// FeedbackRecord is a rated answer together with the question and the chunks it was generated from,
+// exported to build evaluation sets for prompt and retrieval tuning.
+type FeedbackRecord struct {
+	MessageID   string    `json:"message_id"`
+	SessionID   string    `json:"session_id"`
+	Question    string    `json:"question"`
+	Answer      string    `json:"answer"`
+	ChunkIDs    []string  `json:"chunk_ids"`    // Qdrant points of the context. Empty for answers saved before chunk IDs were recorded.
+	DocumentIDs []string  `json:"document_ids"` // Distinct documents of the context in retrieval order.
+	Rating      int       `json:"rating"`
+	Comment     string    `json:"comment,omitempty"`
+	RatedAt     time.Time `json:"rated_at"`
+}
+
+// ContextIDs returns the chunk IDs of citations and their distinct document IDs, both in citation order.
+// Citations saved without a chunk ID contribute only their document.
+func ContextIDs(citations []Citation) (chunkIDs, documentIDs []string) {
+	chunkIDs = make([]string, 0, len(citations))
+	documentIDs = make([]string, 0, len(citations))
+	seen := make(map[string]struct{}, len(citations))
+	for _, c := range citations {
+		if c.ChunkID != "" {
+			chunkIDs = append(chunkIDs, c.ChunkID)
+		}
+		if _, ok := seen[c.DocumentID]; c.DocumentID == "" || ok {
+			continue
+		}
+		seen[c.DocumentID] = struct{}{}
+		documentIDs = append(documentIDs, c.DocumentID)
+	}
+	return chunkIDs, documentIDs
 }
 
-// ChatSession represents a single chat session with its messages.
+// FeedbackFilter selects the feedback to export. Zero values select everything.
+type FeedbackFilter struct {
+	Since  time.Time
+	Rating int // FeedbackPositive or FeedbackNegative, FeedbackNone for both.
+}
+
+// Citation references a retrieved document chunk that was used to generate an answer.
+type Citation struct {
+	ChunkID      string  `json:"chunk_id,omitempty"` // Qdrant point of the chunk.
+	DocumentID   string  `json:"document_id"`
+	ChunkOrdinal int     `json:"chunk_ordinal"`
+	Score        float32 `json:"score"`
+	Excerpt      string  `json:"excerpt"`
+	// Public is the visibility of the document when the answer was generated.
+	// Only public citations are published in shared transcripts.
+	Public bool `json:"public"`
+}
+
+// ChatSession represents a single chat session.
 type ChatSession struct {
 	ID        string    `json:"id"`
 	UserID    string    `json:"user_id"`
 	Title     string    `json:"title"`
 	CreatedAt string    `json:"created_at"`
 	UpdatedAt string    `json:"updated_at"`
-	Messages  []Message `json:"messages"`
+	Archived  bool      `json:"archived"`
+	Messages  []Message `json:"messages,omitempty"` // Not set in session lists, see MessagePage.
 }
 
-type ChatResponse struct {
-	Done      bool      `json:"done"`
-	Message   *Message  `json:"message"`
+// Types of session events pushed to connected clients.
+const (
+	SessionEventUpdated = "session.updated"
+)
+
+// SessionEvent notifies clients about a change of a session made outside of their requests.
+type SessionEvent struct {
+	Type    string      `json:"type"` // One of the SessionEvent* constants.
+	Session ChatSession `json:"session"`
+}
+
+// SessionPage is a page of the user's sessions, most recently active first.
+type SessionPage struct {
+	Sessions []ChatSession `json:"sessions"`
+	HasMore  bool          `json:"has_more"`
+}
+
+// MessagePage is a page of the active branch of a session in chronological order.
+// Pages are counted from the end of the branch: offset 0 holds the newest messages.
+type MessagePage struct {
+	Messages []Message `json:"messages"`
+	HasMore  bool      `json:"has_more"` // Older messages are available.
+}
+
+// SessionShare is a read-only snapshot of a session published under a random token.
+// Later changes of the session do not affect it; deleting the session revokes it.
+type SessionShare struct {
+	Token     string    `json:"token"`
+	SessionID string    `json:"session_id,omitempty"` // Omitted from the public transcript.
+	Title     string    `json:"title"`
+	Messages  []Message `json:"messages,omitempty"` // Not set in share lists.
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// ShareTranscript returns the messages of a branch as they are published: the questions and answers
+// with the sources from public documents, without IDs, versions and feedback of the owner.
+// Sessions of admins retrieve private documents, their citations never leave the session.
+func ShareTranscript(messages []Message) []Message {
+	transcript := make([]Message, 0, len(messages))
+	for _, m := range messages {
+		if m.Role != "user" && m.Role != "assistant" {
+			continue
+		}
+		transcript = append(transcript, Message{
+			Role:      m.Role,
+			Content:   m.Content,
+			Citations: publicCitations(m.Citations),
+			Truncated: m.Truncated,
+		})
+	}
+	return transcript
+}
+
+// publicCitations returns the citations of public documents.
+// Citations stored before the visibility was recorded count as private.
+func publicCitations(citations []Citation) []Citation {
+	var public []Citation
+	for _, c := range citations {
+		if c.Public {
+			public = append(public, c)
+		}
+	}
+	return public
+}
+
+// SessionUpdate holds the session fields to change. Nil fields are left as is.
+type SessionUpdate struct {
+	Title    *string `json:"title,omitempty"`
+	Archived *bool   `json:"archived,omitempty"`
+}
+
+// MessageSearchHit is a chat message matching a full-text search query.
+type MessageSearchHit struct {
+	MessageID    string    `json:"message_id"`
+	SessionID    string    `json:"session_id"`
+	SessionTitle string    `json:"session_title"`
+	Role         string    `json:"role"`
+	Snippet      string    `json:"snippet"` // Matching fragments with terms wrapped in <b></b>.
+	Rank         float32   `json:"rank"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ChatOptions holds optional per-request settings of a chat generation.
+type ChatOptions struct {
+	CorpusID string        `json:"corpus_id,omitempty"` // Corpus to answer from. The global configuration is used when empty.
+	Filters  SearchFilters `json:"filters"`             // Restricts retrieval to matching documents.
+	// IncludePrivate allows retrieving documents that are not public.
+	// It is derived from the role of the user by the handlers and never read from request bodies.
+	IncludePrivate bool `json:"-"`
+	// UserRole selects the quota the generation is charged to. Like IncludePrivate it is set by the handlers.
+	UserRole string `json:"-"`
+	// CallerID is the authenticated user the generation is charged to when it differs from the owner of the chat,
+	// such as an admin answering in another user's session. It is set by the handlers.
+	CallerID string `json:"-"`
+}
+
+// SearchFilters restricts retrieval to documents matching all of the set fields.
+// Lists match documents having any of the given values.
+type SearchFilters struct {
+	DocumentIDs   []string   `json:"document_ids,omitempty"`
+	AuthorIDs     []string   `json:"author_ids,omitempty"`
+	TagIDs        []int64    `json:"tag_ids,omitempty"`
+	PublishedFrom *time.Time `json:"published_from,omitempty"` // Inclusive.
+	PublishedTo   *time.Time `json:"published_to,omitempty"`   // Inclusive.
+}
+
+// Validate checks the sizes of the lists and the order of the date range.
+func (f SearchFilters) Validate() error {
+	if len(f.DocumentIDs) > MaxFilterValues || len(f.AuthorIDs) > MaxFilterValues || len(f.TagIDs) > MaxFilterValues {
+		return ErrInvalidFilters
+	}
+	if f.PublishedFrom != nil && f.PublishedTo != nil && f.PublishedFrom.After(*f.PublishedTo) {
+		return ErrInvalidFilters
+	}
+	return nil
+}
+
+// SearchScope narrows retrieval. Zero values fall back to the configured defaults.
+type SearchScope struct {
+	Collection     string
+	Filters        SearchFilters
+	IncludePrivate bool   // Otherwise only points with is_public set are retrieved.
+	Limit          uint64 // Number of chunks to retrieve, e.g. to over-fetch candidates for re-ranking.
+}
+
+// Corpus is a set of documents indexed into its own vector collection together with
+// the persona that answers questions about it, e.g. a single philosopher.
+type Corpus struct {
+	ID             string    `json:"id"` // Slug used in requests, e.g. "kant".
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	CollectionName string    `json:"collection_name"`
+	Persona        Persona   `json:"persona"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Validate checks the fields required to route requests to the corpus.
+func (c Corpus) Validate() error {
+	if !corpusIDPattern.MatchString(c.ID) || strings.TrimSpace(c.Name) == "" || strings.TrimSpace(c.CollectionName) == "" {
+		return ErrInvalidCorpus
+	}
+	if t := c.Persona.Temperature; t != nil && (*t < 0 || *t > 2) {
+		return ErrInvalidCorpus
+	}
+	return nil
+}
+
+// Persona overrides the generation settings for a corpus. Empty fields fall back to the global configuration.
+type Persona struct {
+	GenerationModel string   `json:"generation_model,omitempty"`
+	Temperature     *float32 `json:"temperature,omitempty"`
+	BasePrompt      string   `json:"base_prompt,omitempty"`
+	ContextPrompt   string   `json:"context_prompt,omitempty"`
+	QueryPrompt     string   `json:"query_prompt,omitempty"`
+}
+
+// Apply returns cfg with the prompts replaced by the ones set in the persona.
+func (p Persona) Apply(cfg config.ContextGeneration) config.ContextGeneration {
+	if p.BasePrompt != "" {
+		cfg.BasePrompt = p.BasePrompt
+	}
+	if p.ContextPrompt != "" {
+		cfg.ContextPrompt = p.ContextPrompt
+	}
+	if p.QueryPrompt != "" {
+		cfg.QueryPrompt = p.QueryPrompt
+	}
+	return cfg
+}
+
+// Page selects a window of a list.
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// Page size limits of list endpoints.
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Normalize replaces out of range values with defaults.
+func (p Page) Normalize() Page {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	p.Limit = min(p.Limit, MaxPageLimit)
+	p.Offset = max(p.Offset, 0)
+	return p
+}
+
+type ChatResponse struct {
+	RequestID string      `json:"request_id,omitempty"` // Set by transports that support cancellation.
+	Type      string      `json:"type,omitempty"`       // ResponseTypeQueued for queue updates, empty for answer chunks.
+	Position  int         `json:"position,omitempty"`   // 1-based position in the generation queue, set on queue updates.
+	Done      bool        `json:"done"`
+	Message   *Message    `json:"message"`
+	CreatedAt time.Time   `json:"created_at"`
+	Citations []Citation  `json:"citations,omitempty"` // Set only on the final chunk.
+	Truncated bool        `json:"truncated,omitempty"` // Set on the final chunk of a cancelled generation.
+	Quota     *QuotaUsage `json:"quota,omitempty"`     // Remaining quota of the user, set only on the final chunk.
+}
+
+// ResponseTypeQueued marks a ChatResponse reporting the position of a request waiting for the model.
+const ResponseTypeQueued = "queued"
+
+// Quotas limiting the generations of a user.
+const (
+	QuotaRequestsPerMinute = "requests_per_minute"
+	QuotaTokensPerDay      = "tokens_per_day"
+)
+
+// QuotaUsage reports the remaining quota of a user. Unlimited quotas are nil.
+type QuotaUsage struct {
+	Requests *QuotaWindow `json:"requests_per_minute,omitempty"`
+	Tokens   *QuotaWindow `json:"tokens_per_day,omitempty"`
+}
+
+// QuotaWindow is the state of a quota in its current window.
+type QuotaWindow struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"` // End of the window.
+}
+
 type Document struct {
 	ID       string                 `json:"id"`
+	Score    float32                `json:"score"`
 	Metadata map[string]interface{} `json:"metadata"`
 }
 
+// Citation builds a citation for the document chunk.
+// Missing or malformed payload fields are left zero-valued.
+func (d Document) Citation() Citation {
+	citation := Citation{ChunkID: d.ID, Score: d.Score}
+	if documentID, ok := d.Metadata[PayloadDocumentID].(string); ok {
+		citation.DocumentID = documentID
+	}
+	if ordinal, ok := d.Metadata[PayloadOrdinal].(string); ok {
+		citation.ChunkOrdinal, _ = strconv.Atoi(ordinal)
+	}
+	if content, ok := d.Metadata[PayloadChunkText].(string); ok {
+		citation.Excerpt = excerpt(content, CitationExcerptLength)
+	}
+	if public, ok := d.Metadata[PayloadIsPublic].(string); ok {
+		citation.Public, _ = strconv.ParseBool(public)
+	}
+	return citation
+}
+
+// NewCitations converts retrieved documents into citations preserving their order.
+func NewCitations(docs []Document) []Citation {
+	citations := make([]Citation, 0, len(docs))
+	for _, doc := range docs {
+		citations = append(citations, doc.Citation())
+	}
+	return citations
+}
+
+// excerpt cuts s to at most limit runes, appending an ellipsis when truncated.
+func excerpt(s string, limit int) string {
+	s = strings.TrimSpace(s)
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:limit])) + "…"
+}
+
 // WorkingContext holds the context for a chat session, including chat history and retrieved documents.
 type WorkingContext struct {
 	Messages []Message  `json:"messages"`  // Chat history. May be empty.
 	Docs     []Document `json:"documents"` // Retrieved documents. May be empty.
+	Summary  string     `json:"summary"`   // Summary of messages older than Messages. May be empty.
+	Persona  Persona    `json:"persona"`   // Generation settings of the requested corpus. Zero for the default persona.
 }
 
 func (wc *WorkingContext) PrepareContext(query string, cfg config.ContextGeneration) string {
@@ -7,6 +7,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -39,7 +40,7 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain(
 				in.Delim('[')
 				if out.Messages == nil {
 					if !in.IsDelim(']') {
-						out.Messages = make([]Message, 0, 2)
+						out.Messages = make([]Message, 0, 0)
 					} else {
 						out.Messages = []Message{}
 					}
@@ -85,6 +86,18 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain(
 				}
 				in.Delim(']')
 			}
+		case "summary":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Summary = string(in.String())
+			}
+		case "persona":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(out.Persona).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -131,6 +144,16 @@ func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain(
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"summary\":"
+		out.RawString(prefix)
+		out.String(string(in.Summary))
+	}
+	{
+		const prefix string = ",\"persona\":"
+		out.RawString(prefix)
+		(in.Persona).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -157,7 +180,7 @@ func (v *WorkingContext) UnmarshalJSON(data []byte) error {
 func (v *WorkingContext) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain1(in *jlexer.Lexer, out *Message) {
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain1(in *jlexer.Lexer, out *SessionUpdate) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -171,17 +194,33 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain1
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
-		case "role":
+		case "title":
 			if in.IsNull() {
 				in.Skip()
+				out.Title = nil
 			} else {
-				out.Role = string(in.String())
+				if out.Title == nil {
+					out.Title = new(string)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					*out.Title = string(in.String())
+				}
 			}
-		case "content":
+		case "archived":
 			if in.IsNull() {
 				in.Skip()
+				out.Archived = nil
 			} else {
-				out.Content = string(in.String())
+				if out.Archived == nil {
+					out.Archived = new(bool)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					*out.Archived = bool(in.Bool())
+				}
 			}
 		default:
 			in.SkipRecursive()
@@ -193,47 +232,53 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain1
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain1(out *jwriter.Writer, in Message) {
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain1(out *jwriter.Writer, in SessionUpdate) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"role\":"
+	if in.Title != nil {
+		const prefix string = ",\"title\":"
+		first = false
 		out.RawString(prefix[1:])
-		out.String(string(in.Role))
+		out.String(string(*in.Title))
 	}
-	{
-		const prefix string = ",\"content\":"
-		out.RawString(prefix)
-		out.String(string(in.Content))
+	if in.Archived != nil {
+		const prefix string = ",\"archived\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(*in.Archived))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Message) MarshalJSON() ([]byte, error) {
+func (v SessionUpdate) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Message) MarshalEasyJSON(w *jwriter.Writer) {
+func (v SessionUpdate) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Message) UnmarshalJSON(data []byte) error {
+func (v *SessionUpdate) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Message) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *SessionUpdate) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain1(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain2(in *jlexer.Lexer, out *Document) {
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain2(in *jlexer.Lexer, out *SessionShare) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -247,33 +292,58 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain2
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
-		case "id":
+		case "token":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.ID = string(in.String())
+				out.Token = string(in.String())
 			}
-		case "metadata":
+		case "session_id":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				in.Delim('{')
-				out.Metadata = make(map[string]interface{})
-				for !in.IsDelim('}') {
-					key := string(in.String())
-					in.WantColon()
-					var v7 interface{}
-					if m, ok := v7.(easyjson.Unmarshaler); ok {
-						m.UnmarshalEasyJSON(in)
-					} else if m, ok := v7.(json.Unmarshaler); ok {
-						_ = m.UnmarshalJSON(in.Raw())
+				out.SessionID = string(in.String())
+			}
+		case "title":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Title = string(in.String())
+			}
+		case "messages":
+			if in.IsNull() {
+				in.Skip()
+				out.Messages = nil
+			} else {
+				in.Delim('[')
+				if out.Messages == nil {
+					if !in.IsDelim(']') {
+						out.Messages = make([]Message, 0, 0)
+					} else {
+						out.Messages = []Message{}
+					}
+				} else {
+					out.Messages = (out.Messages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 Message
+					if in.IsNull() {
+						in.Skip()
 					} else {
-						v7 = in.Interface()
+						(v7).UnmarshalEasyJSON(in)
 					}
-					(out.Metadata)[key] = v7
+					out.Messages = append(out.Messages, v7)
 					in.WantComma()
 				}
-				in.Delim('}')
+				in.Delim(']')
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
 			}
 		default:
 			in.SkipRecursive()
@@ -285,69 +355,71 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain2
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain2(out *jwriter.Writer, in Document) {
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain2(out *jwriter.Writer, in SessionShare) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"token\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ID))
+		out.String(string(in.Token))
+	}
+	if in.SessionID != "" {
+		const prefix string = ",\"session_id\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionID))
 	}
 	{
-		const prefix string = ",\"metadata\":"
+		const prefix string = ",\"title\":"
 		out.RawString(prefix)
-		if in.Metadata == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
-			out.RawString(`null`)
-		} else {
-			out.RawByte('{')
-			v8First := true
-			for v8Name, v8Value := range in.Metadata {
-				if v8First {
-					v8First = false
-				} else {
+		out.String(string(in.Title))
+	}
+	if len(in.Messages) != 0 {
+		const prefix string = ",\"messages\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Messages {
+				if v8 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v8Name))
-				out.RawByte(':')
-				if m, ok := v8Value.(easyjson.Marshaler); ok {
-					m.MarshalEasyJSON(out)
-				} else if m, ok := v8Value.(json.Marshaler); ok {
-					out.Raw(m.MarshalJSON())
-				} else {
-					out.Raw(json.Marshal(v8Value))
-				}
+				(v9).MarshalEasyJSON(out)
 			}
-			out.RawByte('}')
+			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Document) MarshalJSON() ([]byte, error) {
+func (v SessionShare) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Document) MarshalEasyJSON(w *jwriter.Writer) {
+func (v SessionShare) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Document) UnmarshalJSON(data []byte) error {
+func (v *SessionShare) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Document) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *SessionShare) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain2(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain3(in *jlexer.Lexer, out *ChatSession) {
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain3(in *jlexer.Lexer, out *SessionPage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -361,63 +433,39 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain3
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
-		case "id":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				out.ID = string(in.String())
-			}
-		case "user_id":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				out.UserID = string(in.String())
-			}
-		case "title":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				out.Title = string(in.String())
-			}
-		case "created_at":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				out.CreatedAt = string(in.String())
-			}
-		case "updated_at":
+		case "sessions":
 			if in.IsNull() {
 				in.Skip()
-			} else {
-				out.UpdatedAt = string(in.String())
-			}
-		case "messages":
-			if in.IsNull() {
-				in.Skip()
-				out.Messages = nil
+				out.Sessions = nil
 			} else {
 				in.Delim('[')
-				if out.Messages == nil {
+				if out.Sessions == nil {
 					if !in.IsDelim(']') {
-						out.Messages = make([]Message, 0, 2)
+						out.Sessions = make([]ChatSession, 0, 0)
 					} else {
-						out.Messages = []Message{}
+						out.Sessions = []ChatSession{}
 					}
 				} else {
-					out.Messages = (out.Messages)[:0]
+					out.Sessions = (out.Sessions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v9 Message
+					var v10 ChatSession
 					if in.IsNull() {
 						in.Skip()
 					} else {
-						(v9).UnmarshalEasyJSON(in)
+						(v10).UnmarshalEasyJSON(in)
 					}
-					out.Messages = append(out.Messages, v9)
+					out.Sessions = append(out.Sessions, v10)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "has_more":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HasMore = bool(in.Bool())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -428,78 +476,58 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain3
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain3(out *jwriter.Writer, in ChatSession) {
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain3(out *jwriter.Writer, in SessionPage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"sessions\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ID))
-	}
-	{
-		const prefix string = ",\"user_id\":"
-		out.RawString(prefix)
-		out.String(string(in.UserID))
-	}
-	{
-		const prefix string = ",\"title\":"
-		out.RawString(prefix)
-		out.String(string(in.Title))
-	}
-	{
-		const prefix string = ",\"created_at\":"
-		out.RawString(prefix)
-		out.String(string(in.CreatedAt))
-	}
-	{
-		const prefix string = ",\"updated_at\":"
-		out.RawString(prefix)
-		out.String(string(in.UpdatedAt))
-	}
-	{
-		const prefix string = ",\"messages\":"
-		out.RawString(prefix)
-		if in.Messages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Sessions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v10, v11 := range in.Messages {
-				if v10 > 0 {
+			for v11, v12 := range in.Sessions {
+				if v11 > 0 {
 					out.RawByte(',')
 				}
-				(v11).MarshalEasyJSON(out)
+				(v12).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"has_more\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HasMore))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChatSession) MarshalJSON() ([]byte, error) {
+func (v SessionPage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChatSession) MarshalEasyJSON(w *jwriter.Writer) {
+func (v SessionPage) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChatSession) UnmarshalJSON(data []byte) error {
+func (v *SessionPage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChatSession) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *SessionPage) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain3(l, v)
 }
-func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain4(in *jlexer.Lexer, out *ChatResponse) {
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain4(in *jlexer.Lexer, out *SessionEvent) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -513,33 +541,17 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain4
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
-		case "done":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				out.Done = bool(in.Bool())
-			}
-		case "message":
+		case "type":
 			if in.IsNull() {
 				in.Skip()
-				out.Message = nil
 			} else {
-				if out.Message == nil {
-					out.Message = new(Message)
-				}
-				if in.IsNull() {
-					in.Skip()
-				} else {
-					(*out.Message).UnmarshalEasyJSON(in)
-				}
+				out.Type = string(in.String())
 			}
-		case "created_at":
+		case "session":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				if data := in.Raw(); in.Ok() {
-					in.AddError((out.CreatedAt).UnmarshalJSON(data))
-				}
+				(out.Session).UnmarshalEasyJSON(in)
 			}
 		default:
 			in.SkipRecursive()
@@ -551,52 +563,2467 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain4
 		in.Consumed()
 	}
 }
-func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain4(out *jwriter.Writer, in ChatResponse) {
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain4(out *jwriter.Writer, in SessionEvent) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"done\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.Bool(bool(in.Done))
-	}
-	{
-		const prefix string = ",\"message\":"
-		out.RawString(prefix)
-		if in.Message == nil {
-			out.RawString("null")
-		} else {
-			(*in.Message).MarshalEasyJSON(out)
-		}
+		out.String(string(in.Type))
 	}
 	{
-		const prefix string = ",\"created_at\":"
+		const prefix string = ",\"session\":"
 		out.RawString(prefix)
-		out.Raw((in.CreatedAt).MarshalJSON())
+		(in.Session).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChatResponse) MarshalJSON() ([]byte, error) {
+func (v SessionEvent) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChatResponse) MarshalEasyJSON(w *jwriter.Writer) {
+func (v SessionEvent) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChatResponse) UnmarshalJSON(data []byte) error {
+func (v *SessionEvent) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChatResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *SessionEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain4(l, v)
 }
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain5(in *jlexer.Lexer, out *SearchScope) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "Collection":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Collection = string(in.String())
+			}
+		case "Filters":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(out.Filters).UnmarshalEasyJSON(in)
+			}
+		case "IncludePrivate":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.IncludePrivate = bool(in.Bool())
+			}
+		case "Limit":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Limit = uint64(in.Uint64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain5(out *jwriter.Writer, in SearchScope) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Collection\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Collection))
+	}
+	{
+		const prefix string = ",\"Filters\":"
+		out.RawString(prefix)
+		(in.Filters).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"IncludePrivate\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IncludePrivate))
+	}
+	{
+		const prefix string = ",\"Limit\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Limit))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SearchScope) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SearchScope) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SearchScope) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SearchScope) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain5(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain6(in *jlexer.Lexer, out *SearchFilters) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "document_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.DocumentIDs = nil
+			} else {
+				in.Delim('[')
+				if out.DocumentIDs == nil {
+					if !in.IsDelim(']') {
+						out.DocumentIDs = make([]string, 0, 4)
+					} else {
+						out.DocumentIDs = []string{}
+					}
+				} else {
+					out.DocumentIDs = (out.DocumentIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v13 = string(in.String())
+					}
+					out.DocumentIDs = append(out.DocumentIDs, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "author_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.AuthorIDs = nil
+			} else {
+				in.Delim('[')
+				if out.AuthorIDs == nil {
+					if !in.IsDelim(']') {
+						out.AuthorIDs = make([]string, 0, 4)
+					} else {
+						out.AuthorIDs = []string{}
+					}
+				} else {
+					out.AuthorIDs = (out.AuthorIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v14 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v14 = string(in.String())
+					}
+					out.AuthorIDs = append(out.AuthorIDs, v14)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "tag_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.TagIDs = nil
+			} else {
+				in.Delim('[')
+				if out.TagIDs == nil {
+					if !in.IsDelim(']') {
+						out.TagIDs = make([]int64, 0, 8)
+					} else {
+						out.TagIDs = []int64{}
+					}
+				} else {
+					out.TagIDs = (out.TagIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v15 int64
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v15 = int64(in.Int64())
+					}
+					out.TagIDs = append(out.TagIDs, v15)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "published_from":
+			if in.IsNull() {
+				in.Skip()
+				out.PublishedFrom = nil
+			} else {
+				if out.PublishedFrom == nil {
+					out.PublishedFrom = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.PublishedFrom).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "published_to":
+			if in.IsNull() {
+				in.Skip()
+				out.PublishedTo = nil
+			} else {
+				if out.PublishedTo == nil {
+					out.PublishedTo = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.PublishedTo).UnmarshalJSON(data))
+					}
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain6(out *jwriter.Writer, in SearchFilters) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.DocumentIDs) != 0 {
+		const prefix string = ",\"document_ids\":"
+		first = false
+		out.RawString(prefix[1:])
+		{
+			out.RawByte('[')
+			for v16, v17 := range in.DocumentIDs {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v17))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.AuthorIDs) != 0 {
+		const prefix string = ",\"author_ids\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v18, v19 := range in.AuthorIDs {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v19))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.TagIDs) != 0 {
+		const prefix string = ",\"tag_ids\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v20, v21 := range in.TagIDs {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v21))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.PublishedFrom != nil {
+		const prefix string = ",\"published_from\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.PublishedFrom).MarshalJSON())
+	}
+	if in.PublishedTo != nil {
+		const prefix string = ",\"published_to\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.PublishedTo).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SearchFilters) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SearchFilters) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SearchFilters) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SearchFilters) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain6(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain7(in *jlexer.Lexer, out *QuotaWindow) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "limit":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Limit = int(in.Int())
+			}
+		case "remaining":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Remaining = int(in.Int())
+			}
+		case "reset_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.ResetAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain7(out *jwriter.Writer, in QuotaWindow) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"limit\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Limit))
+	}
+	{
+		const prefix string = ",\"remaining\":"
+		out.RawString(prefix)
+		out.Int(int(in.Remaining))
+	}
+	{
+		const prefix string = ",\"reset_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ResetAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v QuotaWindow) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v QuotaWindow) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *QuotaWindow) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *QuotaWindow) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain7(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain8(in *jlexer.Lexer, out *QuotaUsage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "requests_per_minute":
+			if in.IsNull() {
+				in.Skip()
+				out.Requests = nil
+			} else {
+				if out.Requests == nil {
+					out.Requests = new(QuotaWindow)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.Requests).UnmarshalEasyJSON(in)
+				}
+			}
+		case "tokens_per_day":
+			if in.IsNull() {
+				in.Skip()
+				out.Tokens = nil
+			} else {
+				if out.Tokens == nil {
+					out.Tokens = new(QuotaWindow)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.Tokens).UnmarshalEasyJSON(in)
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain8(out *jwriter.Writer, in QuotaUsage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Requests != nil {
+		const prefix string = ",\"requests_per_minute\":"
+		first = false
+		out.RawString(prefix[1:])
+		(*in.Requests).MarshalEasyJSON(out)
+	}
+	if in.Tokens != nil {
+		const prefix string = ",\"tokens_per_day\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.Tokens).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v QuotaUsage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v QuotaUsage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *QuotaUsage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *QuotaUsage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain8(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain9(in *jlexer.Lexer, out *Persona) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "generation_model":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.GenerationModel = string(in.String())
+			}
+		case "temperature":
+			if in.IsNull() {
+				in.Skip()
+				out.Temperature = nil
+			} else {
+				if out.Temperature == nil {
+					out.Temperature = new(float32)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					*out.Temperature = float32(in.Float32())
+				}
+			}
+		case "base_prompt":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.BasePrompt = string(in.String())
+			}
+		case "context_prompt":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ContextPrompt = string(in.String())
+			}
+		case "query_prompt":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.QueryPrompt = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain9(out *jwriter.Writer, in Persona) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.GenerationModel != "" {
+		const prefix string = ",\"generation_model\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.GenerationModel))
+	}
+	if in.Temperature != nil {
+		const prefix string = ",\"temperature\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float32(float32(*in.Temperature))
+	}
+	if in.BasePrompt != "" {
+		const prefix string = ",\"base_prompt\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.BasePrompt))
+	}
+	if in.ContextPrompt != "" {
+		const prefix string = ",\"context_prompt\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ContextPrompt))
+	}
+	if in.QueryPrompt != "" {
+		const prefix string = ",\"query_prompt\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.QueryPrompt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Persona) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Persona) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Persona) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Persona) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain9(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain10(in *jlexer.Lexer, out *Page) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "Limit":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Limit = int(in.Int())
+			}
+		case "Offset":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Offset = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain10(out *jwriter.Writer, in Page) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Limit\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Limit))
+	}
+	{
+		const prefix string = ",\"Offset\":"
+		out.RawString(prefix)
+		out.Int(int(in.Offset))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Page) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Page) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Page) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Page) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain10(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain11(in *jlexer.Lexer, out *MessageSearchHit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "message_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MessageID = string(in.String())
+			}
+		case "session_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SessionID = string(in.String())
+			}
+		case "session_title":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SessionTitle = string(in.String())
+			}
+		case "role":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Role = string(in.String())
+			}
+		case "snippet":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Snippet = string(in.String())
+			}
+		case "rank":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Rank = float32(in.Float32())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain11(out *jwriter.Writer, in MessageSearchHit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.MessageID))
+	}
+	{
+		const prefix string = ",\"session_id\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionID))
+	}
+	{
+		const prefix string = ",\"session_title\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionTitle))
+	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.String(string(in.Role))
+	}
+	{
+		const prefix string = ",\"snippet\":"
+		out.RawString(prefix)
+		out.String(string(in.Snippet))
+	}
+	{
+		const prefix string = ",\"rank\":"
+		out.RawString(prefix)
+		out.Float32(float32(in.Rank))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MessageSearchHit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MessageSearchHit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MessageSearchHit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MessageSearchHit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain11(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain12(in *jlexer.Lexer, out *MessagePage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "messages":
+			if in.IsNull() {
+				in.Skip()
+				out.Messages = nil
+			} else {
+				in.Delim('[')
+				if out.Messages == nil {
+					if !in.IsDelim(']') {
+						out.Messages = make([]Message, 0, 0)
+					} else {
+						out.Messages = []Message{}
+					}
+				} else {
+					out.Messages = (out.Messages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 Message
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v22).UnmarshalEasyJSON(in)
+					}
+					out.Messages = append(out.Messages, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "has_more":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HasMore = bool(in.Bool())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain12(out *jwriter.Writer, in MessagePage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"messages\":"
+		out.RawString(prefix[1:])
+		if in.Messages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.Messages {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				(v24).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"has_more\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HasMore))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MessagePage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MessagePage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MessagePage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MessagePage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain12(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain13(in *jlexer.Lexer, out *Message) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ID = string(in.String())
+			}
+		case "parent_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ParentID = string(in.String())
+			}
+		case "role":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Role = string(in.String())
+			}
+		case "content":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Content = string(in.String())
+			}
+		case "citations":
+			if in.IsNull() {
+				in.Skip()
+				out.Citations = nil
+			} else {
+				in.Delim('[')
+				if out.Citations == nil {
+					if !in.IsDelim(']') {
+						out.Citations = make([]Citation, 0, 0)
+					} else {
+						out.Citations = []Citation{}
+					}
+				} else {
+					out.Citations = (out.Citations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 Citation
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v25).UnmarshalEasyJSON(in)
+					}
+					out.Citations = append(out.Citations, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "truncated":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Truncated = bool(in.Bool())
+			}
+		case "sibling_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.SiblingIDs = nil
+			} else {
+				in.Delim('[')
+				if out.SiblingIDs == nil {
+					if !in.IsDelim(']') {
+						out.SiblingIDs = make([]string, 0, 4)
+					} else {
+						out.SiblingIDs = []string{}
+					}
+				} else {
+					out.SiblingIDs = (out.SiblingIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v26 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v26 = string(in.String())
+					}
+					out.SiblingIDs = append(out.SiblingIDs, v26)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "feedback":
+			if in.IsNull() {
+				in.Skip()
+				out.Feedback = nil
+			} else {
+				if out.Feedback == nil {
+					out.Feedback = new(Feedback)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.Feedback).UnmarshalEasyJSON(in)
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain13(out *jwriter.Writer, in Message) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != "" {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	if in.ParentID != "" {
+		const prefix string = ",\"parent_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ParentID))
+	}
+	{
+		const prefix string = ",\"role\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Role))
+	}
+	{
+		const prefix string = ",\"content\":"
+		out.RawString(prefix)
+		out.String(string(in.Content))
+	}
+	if len(in.Citations) != 0 {
+		const prefix string = ",\"citations\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v27, v28 := range in.Citations {
+				if v27 > 0 {
+					out.RawByte(',')
+				}
+				(v28).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Truncated {
+		const prefix string = ",\"truncated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Truncated))
+	}
+	if len(in.SiblingIDs) != 0 {
+		const prefix string = ",\"sibling_ids\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v29, v30 := range in.SiblingIDs {
+				if v29 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v30))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Feedback != nil {
+		const prefix string = ",\"feedback\":"
+		out.RawString(prefix)
+		(*in.Feedback).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Message) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Message) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Message) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Message) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain13(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain14(in *jlexer.Lexer, out *FeedbackRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "message_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MessageID = string(in.String())
+			}
+		case "session_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SessionID = string(in.String())
+			}
+		case "question":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Question = string(in.String())
+			}
+		case "answer":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Answer = string(in.String())
+			}
+		case "chunk_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.ChunkIDs = nil
+			} else {
+				in.Delim('[')
+				if out.ChunkIDs == nil {
+					if !in.IsDelim(']') {
+						out.ChunkIDs = make([]string, 0, 4)
+					} else {
+						out.ChunkIDs = []string{}
+					}
+				} else {
+					out.ChunkIDs = (out.ChunkIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v31 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v31 = string(in.String())
+					}
+					out.ChunkIDs = append(out.ChunkIDs, v31)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "document_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.DocumentIDs = nil
+			} else {
+				in.Delim('[')
+				if out.DocumentIDs == nil {
+					if !in.IsDelim(']') {
+						out.DocumentIDs = make([]string, 0, 4)
+					} else {
+						out.DocumentIDs = []string{}
+					}
+				} else {
+					out.DocumentIDs = (out.DocumentIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v32 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v32 = string(in.String())
+					}
+					out.DocumentIDs = append(out.DocumentIDs, v32)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "rating":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Rating = int(in.Int())
+			}
+		case "comment":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Comment = string(in.String())
+			}
+		case "rated_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.RatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain14(out *jwriter.Writer, in FeedbackRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.MessageID))
+	}
+	{
+		const prefix string = ",\"session_id\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionID))
+	}
+	{
+		const prefix string = ",\"question\":"
+		out.RawString(prefix)
+		out.String(string(in.Question))
+	}
+	{
+		const prefix string = ",\"answer\":"
+		out.RawString(prefix)
+		out.String(string(in.Answer))
+	}
+	{
+		const prefix string = ",\"chunk_ids\":"
+		out.RawString(prefix)
+		if in.ChunkIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v33, v34 := range in.ChunkIDs {
+				if v33 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v34))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"document_ids\":"
+		out.RawString(prefix)
+		if in.DocumentIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v35, v36 := range in.DocumentIDs {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v36))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"rating\":"
+		out.RawString(prefix)
+		out.Int(int(in.Rating))
+	}
+	if in.Comment != "" {
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	{
+		const prefix string = ",\"rated_at\":"
+		out.RawString(prefix)
+		out.Raw((in.RatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FeedbackRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FeedbackRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FeedbackRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FeedbackRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain14(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain15(in *jlexer.Lexer, out *FeedbackFilter) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "Since":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.Since).UnmarshalJSON(data))
+				}
+			}
+		case "Rating":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Rating = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain15(out *jwriter.Writer, in FeedbackFilter) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Since\":"
+		out.RawString(prefix[1:])
+		out.Raw((in.Since).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"Rating\":"
+		out.RawString(prefix)
+		out.Int(int(in.Rating))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FeedbackFilter) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FeedbackFilter) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FeedbackFilter) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FeedbackFilter) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain15(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain16(in *jlexer.Lexer, out *Feedback) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "rating":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Rating = int(in.Int())
+			}
+		case "comment":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Comment = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain16(out *jwriter.Writer, in Feedback) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"rating\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Rating))
+	}
+	if in.Comment != "" {
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Feedback) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Feedback) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Feedback) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Feedback) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain16(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain17(in *jlexer.Lexer, out *Document) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ID = string(in.String())
+			}
+		case "score":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Score = float32(in.Float32())
+			}
+		case "metadata":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Metadata = make(map[string]interface{})
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v37 interface{}
+					if m, ok := v37.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v37.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v37 = in.Interface()
+					}
+					(out.Metadata)[key] = v37
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain17(out *jwriter.Writer, in Document) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"score\":"
+		out.RawString(prefix)
+		out.Float32(float32(in.Score))
+	}
+	{
+		const prefix string = ",\"metadata\":"
+		out.RawString(prefix)
+		if in.Metadata == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v38First := true
+			for v38Name, v38Value := range in.Metadata {
+				if v38First {
+					v38First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v38Name))
+				out.RawByte(':')
+				if m, ok := v38Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v38Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v38Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Document) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Document) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Document) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Document) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain17(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain18(in *jlexer.Lexer, out *Corpus) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ID = string(in.String())
+			}
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "description":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Description = string(in.String())
+			}
+		case "collection_name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CollectionName = string(in.String())
+			}
+		case "persona":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(out.Persona).UnmarshalEasyJSON(in)
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		case "updated_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.UpdatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain18(out *jwriter.Writer, in Corpus) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"collection_name\":"
+		out.RawString(prefix)
+		out.String(string(in.CollectionName))
+	}
+	{
+		const prefix string = ",\"persona\":"
+		out.RawString(prefix)
+		(in.Persona).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"updated_at\":"
+		out.RawString(prefix)
+		out.Raw((in.UpdatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Corpus) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Corpus) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain18(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Corpus) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Corpus) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain18(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain19(in *jlexer.Lexer, out *Citation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "chunk_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ChunkID = string(in.String())
+			}
+		case "document_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DocumentID = string(in.String())
+			}
+		case "chunk_ordinal":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ChunkOrdinal = int(in.Int())
+			}
+		case "score":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Score = float32(in.Float32())
+			}
+		case "excerpt":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Excerpt = string(in.String())
+			}
+		case "public":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Public = bool(in.Bool())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain19(out *jwriter.Writer, in Citation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ChunkID != "" {
+		const prefix string = ",\"chunk_id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.ChunkID))
+	}
+	{
+		const prefix string = ",\"document_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.DocumentID))
+	}
+	{
+		const prefix string = ",\"chunk_ordinal\":"
+		out.RawString(prefix)
+		out.Int(int(in.ChunkOrdinal))
+	}
+	{
+		const prefix string = ",\"score\":"
+		out.RawString(prefix)
+		out.Float32(float32(in.Score))
+	}
+	{
+		const prefix string = ",\"excerpt\":"
+		out.RawString(prefix)
+		out.String(string(in.Excerpt))
+	}
+	{
+		const prefix string = ",\"public\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Public))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Citation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain19(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Citation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain19(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Citation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain19(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Citation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain19(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain20(in *jlexer.Lexer, out *ChatSession) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ID = string(in.String())
+			}
+		case "user_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UserID = string(in.String())
+			}
+		case "title":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Title = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CreatedAt = string(in.String())
+			}
+		case "updated_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UpdatedAt = string(in.String())
+			}
+		case "archived":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Archived = bool(in.Bool())
+			}
+		case "messages":
+			if in.IsNull() {
+				in.Skip()
+				out.Messages = nil
+			} else {
+				in.Delim('[')
+				if out.Messages == nil {
+					if !in.IsDelim(']') {
+						out.Messages = make([]Message, 0, 0)
+					} else {
+						out.Messages = []Message{}
+					}
+				} else {
+					out.Messages = (out.Messages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v39 Message
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v39).UnmarshalEasyJSON(in)
+					}
+					out.Messages = append(out.Messages, v39)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain20(out *jwriter.Writer, in ChatSession) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.String(string(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"updated_at\":"
+		out.RawString(prefix)
+		out.String(string(in.UpdatedAt))
+	}
+	{
+		const prefix string = ",\"archived\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Archived))
+	}
+	if len(in.Messages) != 0 {
+		const prefix string = ",\"messages\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v40, v41 := range in.Messages {
+				if v40 > 0 {
+					out.RawByte(',')
+				}
+				(v41).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatSession) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain20(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatSession) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain20(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatSession) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain20(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatSession) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain20(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain21(in *jlexer.Lexer, out *ChatResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "request_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RequestID = string(in.String())
+			}
+		case "type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Type = string(in.String())
+			}
+		case "position":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Position = int(in.Int())
+			}
+		case "done":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Done = bool(in.Bool())
+			}
+		case "message":
+			if in.IsNull() {
+				in.Skip()
+				out.Message = nil
+			} else {
+				if out.Message == nil {
+					out.Message = new(Message)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.Message).UnmarshalEasyJSON(in)
+				}
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		case "citations":
+			if in.IsNull() {
+				in.Skip()
+				out.Citations = nil
+			} else {
+				in.Delim('[')
+				if out.Citations == nil {
+					if !in.IsDelim(']') {
+						out.Citations = make([]Citation, 0, 0)
+					} else {
+						out.Citations = []Citation{}
+					}
+				} else {
+					out.Citations = (out.Citations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v42 Citation
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v42).UnmarshalEasyJSON(in)
+					}
+					out.Citations = append(out.Citations, v42)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "truncated":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Truncated = bool(in.Bool())
+			}
+		case "quota":
+			if in.IsNull() {
+				in.Skip()
+				out.Quota = nil
+			} else {
+				if out.Quota == nil {
+					out.Quota = new(QuotaUsage)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.Quota).UnmarshalEasyJSON(in)
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain21(out *jwriter.Writer, in ChatResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.RequestID != "" {
+		const prefix string = ",\"request_id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.RequestID))
+	}
+	if in.Type != "" {
+		const prefix string = ",\"type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Type))
+	}
+	if in.Position != 0 {
+		const prefix string = ",\"position\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Position))
+	}
+	{
+		const prefix string = ",\"done\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Done))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		if in.Message == nil {
+			out.RawString("null")
+		} else {
+			(*in.Message).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	if len(in.Citations) != 0 {
+		const prefix string = ",\"citations\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v43, v44 := range in.Citations {
+				if v43 > 0 {
+					out.RawByte(',')
+				}
+				(v44).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Truncated {
+		const prefix string = ",\"truncated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Truncated))
+	}
+	if in.Quota != nil {
+		const prefix string = ",\"quota\":"
+		out.RawString(prefix)
+		(*in.Quota).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain21(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain21(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain21(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain21(l, v)
+}
+func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain22(in *jlexer.Lexer, out *ChatOptions) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "corpus_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CorpusID = string(in.String())
+			}
+		case "filters":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(out.Filters).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain22(out *jwriter.Writer, in ChatOptions) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.CorpusID != "" {
+		const prefix string = ",\"corpus_id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.CorpusID))
+	}
+	{
+		const prefix string = ",\"filters\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Filters).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatOptions) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain22(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatOptions) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalDomain22(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatOptions) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain22(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatOptions) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalDomain22(l, v)
+}
@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+)
+
+func TestDocumentCitation(t *testing.T) {
+	doc := Document{
+		ID:    "point-1",
+		Score: 0.87,
+		Metadata: map[string]interface{}{
+			PayloadDocumentID: "9b2f7c1e-0000-0000-0000-000000000001",
+			PayloadOrdinal:    "4",
+			PayloadChunkText:  "  Бытие есть, небытия же нет.  ",
+			PayloadIsPublic:   "true",
+		},
+	}
+
+	citation := doc.Citation()
+
+	if citation.ChunkID != "point-1" {
+		t.Errorf("unexpected chunk id %q", citation.ChunkID)
+	}
+	if citation.DocumentID != "9b2f7c1e-0000-0000-0000-000000000001" {
+		t.Errorf("unexpected document id %q", citation.DocumentID)
+	}
+	if citation.ChunkOrdinal != 4 {
+		t.Errorf("expected ordinal 4, got %d", citation.ChunkOrdinal)
+	}
+	if citation.Score != 0.87 {
+		t.Errorf("expected score 0.87, got %f", citation.Score)
+	}
+	if citation.Excerpt != "Бытие есть, небытия же нет." {
+		t.Errorf("unexpected excerpt %q", citation.Excerpt)
+	}
+	if !citation.Public {
+		t.Error("expected a public citation")
+	}
+}
+
+func TestDocumentCitationTruncatesExcerpt(t *testing.T) {
+	doc := Document{
+		Metadata: map[string]interface{}{
+			PayloadChunkText: strings.Repeat("я", CitationExcerptLength+10),
+		},
+	}
+
+	citation := doc.Citation()
+
+	if got := len([]rune(citation.Excerpt)); got != CitationExcerptLength+1 {
+		t.Errorf("expected %d runes including ellipsis, got %d", CitationExcerptLength+1, got)
+	}
+	if !strings.HasSuffix(citation.Excerpt, "…") {
+		t.Errorf("expected truncated excerpt to end with ellipsis, got %q", citation.Excerpt)
+	}
+}
+
+func TestNewCitationsWithoutPayload(t *testing.T) {
+	citations := NewCitations([]Document{{ID: "1"}, {ID: "2"}})
+
+	if len(citations) != 2 {
+		t.Fatalf("expected 2 citations, got %d", len(citations))
+	}
+	if citations[0].DocumentID != "" || citations[0].ChunkOrdinal != 0 {
+		t.Errorf("expected zero-valued citation, got %+v", citations[0])
+	}
+}
+
+func TestPageNormalize(t *testing.T) {
+	tests := []struct {
+		in, want Page
+	}{
+		{Page{}, Page{Limit: DefaultPageLimit}},
+		{Page{Limit: 5, Offset: 10}, Page{Limit: 5, Offset: 10}},
+		{Page{Limit: 1000, Offset: -3}, Page{Limit: MaxPageLimit}},
+	}
+	for _, tt := range tests {
+		if got := tt.in.Normalize(); got != tt.want {
+			t.Errorf("Normalize(%+v) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCorpusValidate(t *testing.T) {
+	temperature := func(v float32) *float32 { return &v }
+	valid := Corpus{ID: "kant", Name: "Кант", CollectionName: "kant_embeddings"}
+
+	tests := []struct {
+		name   string
+		modify func(*Corpus)
+		valid  bool
+	}{
+		{name: "valid", modify: func(*Corpus) {}, valid: true},
+		{name: "slug with dash", modify: func(c *Corpus) { c.ID = "karl-marx_2" }, valid: true},
+		{name: "temperature", modify: func(c *Corpus) { c.Persona.Temperature = temperature(0.7) }, valid: true},
+		{name: "empty id", modify: func(c *Corpus) { c.ID = "" }},
+		{name: "uppercase id", modify: func(c *Corpus) { c.ID = "Kant" }},
+		{name: "id with slash", modify: func(c *Corpus) { c.ID = "kant/1" }},
+		{name: "empty name", modify: func(c *Corpus) { c.Name = " " }},
+		{name: "empty collection", modify: func(c *Corpus) { c.CollectionName = "" }},
+		{name: "temperature out of range", modify: func(c *Corpus) { c.Persona.Temperature = temperature(3) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			corpus := valid
+			tt.modify(&corpus)
+			if err := corpus.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestSearchFiltersValidate(t *testing.T) {
+	from := time.Date(1781, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(1790, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		filters SearchFilters
+		valid   bool
+	}{
+		{name: "empty", valid: true},
+		{name: "date range", filters: SearchFilters{PublishedFrom: &from, PublishedTo: &to}, valid: true},
+		{name: "single day", filters: SearchFilters{PublishedFrom: &from, PublishedTo: &from}, valid: true},
+		{name: "open range", filters: SearchFilters{PublishedTo: &from}, valid: true},
+		{name: "reversed range", filters: SearchFilters{PublishedFrom: &to, PublishedTo: &from}},
+		{name: "too many documents", filters: SearchFilters{DocumentIDs: make([]string, MaxFilterValues+1)}},
+		{name: "too many tags", filters: SearchFilters{TagIDs: make([]int64, MaxFilterValues+1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filters.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestPersonaApply(t *testing.T) {
+	cfg := config.ContextGeneration{BasePrompt: "base", ContextPrompt: "context", QueryPrompt: "query", MaxTokens: 100}
+
+	got := Persona{BasePrompt: "Ты — Кант."}.Apply(cfg)
+
+	if got.BasePrompt != "Ты — Кант." || got.ContextPrompt != "context" || got.QueryPrompt != "query" || got.MaxTokens != 100 {
+		t.Errorf("unexpected config %+v", got)
+	}
+}
+
+func TestFeedbackValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		feedback Feedback
+		wantErr  bool
+	}{
+		{name: "positive", feedback: Feedback{Rating: FeedbackPositive, Comment: "Точно по тексту"}},
+		{name: "negative", feedback: Feedback{Rating: FeedbackNegative}},
+		{name: "removal", feedback: Feedback{Rating: FeedbackNone}},
+		{name: "out of range", feedback: Feedback{Rating: 5}, wantErr: true},
+		{name: "long comment", feedback: Feedback{Rating: FeedbackNegative, Comment: strings.Repeat("я", MaxFeedbackCommentLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.feedback.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContextIDs(t *testing.T) {
+	chunkIDs, documentIDs := ContextIDs([]Citation{
+		{ChunkID: "p1", DocumentID: "d1"},
+		{ChunkID: "p2", DocumentID: "d2"},
+		{ChunkID: "p3", DocumentID: "d1"},
+		{DocumentID: "d3"}, // saved before chunk IDs were recorded
+	})
+
+	if got := strings.Join(chunkIDs, ","); got != "p1,p2,p3" {
+		t.Errorf("unexpected chunk ids %s", got)
+	}
+	if got := strings.Join(documentIDs, ","); got != "d1,d2,d3" {
+		t.Errorf("unexpected document ids %s", got)
+	}
+}
+
+func TestShareTranscript(t *testing.T) {
+	public := Citation{DocumentID: "d1", ChunkOrdinal: 2, Excerpt: "Тезис", Public: true}
+	private := Citation{DocumentID: "d2", ChunkOrdinal: 1, Excerpt: "Черновик"}
+	messages := []Message{
+		{ID: "m1", Role: "system", Content: "Ты философ"},
+		{ID: "m2", Role: "user", Content: "Что такое диалектика?"},
+		{
+			ID: "m3", ParentID: "m2", Role: "assistant", Content: "Метод", Citations: []Citation{public, private}, Truncated: true,
+			SiblingIDs: []string{"m3", "m4"}, Feedback: &Feedback{Rating: FeedbackPositive},
+		},
+	}
+
+	got := ShareTranscript(messages)
+	want := []Message{
+		{Role: "user", Content: "Что такое диалектика?"},
+		{Role: "assistant", Content: "Метод", Citations: []Citation{public}, Truncated: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ShareTranscript() = %+v, want %+v", got, want)
+	}
+}
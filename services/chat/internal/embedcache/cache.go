@@ -0,0 +1,160 @@
+package embedcache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/neuro"
+)
+
+const keyPrefix = "chat:embedding:"
+
+// Provider caches embeddings of the wrapped provider in an in-memory LRU and in Redis.
+// Keys include the embedding model, so switching models never serves stale vectors.
+// Chat requests are passed through.
+type Provider struct {
+	neuro.Provider
+
+	client  *redis.Client
+	cfg     *config.EmbeddingCache
+	model   string
+	metrics *Metrics
+
+	mu    sync.Mutex
+	lru   *list.List
+	items map[string]*list.Element
+}
+
+type entry struct {
+	key    string
+	vector []float64
+}
+
+// New wraps provider with a cache of embeddings produced by model.
+// A nil client keeps embeddings in memory only.
+func New(provider neuro.Provider, client *redis.Client, cfg *config.EmbeddingCache, model string, metrics *Metrics) *Provider {
+	return &Provider{
+		Provider: provider,
+		client:   client,
+		cfg:      cfg,
+		model:    model,
+		metrics:  metrics,
+		lru:      list.New(),
+		items:    make(map[string]*list.Element, cfg.Size),
+	}
+}
+
+// Embed returns the cached embedding of input or generates and caches it.
+// Redis failures are logged and never fail the request.
+func (p *Provider) Embed(ctx context.Context, input string) ([]float64, error) {
+	key := p.key(input)
+
+	if vector, ok := p.getMemory(key); ok {
+		p.metrics.lookup(resultMemoryHit)
+		return vector, nil
+	}
+
+	if p.client != nil {
+		vector, err := p.getRedis(ctx, key)
+		if err == nil {
+			p.metrics.lookup(resultRedisHit)
+			p.setMemory(key, vector)
+			return vector, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			slog.WarnContext(ctx, "could not read cached embedding", "error", err)
+		}
+	}
+
+	p.metrics.lookup(resultMiss)
+	vector, err := p.Provider.Embed(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	p.setMemory(key, vector)
+	if p.client != nil {
+		if err := p.client.Set(ctx, key, encode(vector), p.cfg.TTL).Err(); err != nil {
+			slog.WarnContext(ctx, "could not cache embedding", "error", err)
+		}
+	}
+	return vector, nil
+}
+
+// key derives the cache key from the model and a hash of the input.
+func (p *Provider) key(input string) string {
+	sum := sha256.Sum256([]byte(input))
+	return keyPrefix + p.model + ":" + hex.EncodeToString(sum[:])
+}
+
+func (p *Provider) getMemory(key string) ([]float64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	el, ok := p.items[key]
+	if !ok {
+		return nil, false
+	}
+	p.lru.MoveToFront(el)
+	return el.Value.(*entry).vector, true
+}
+
+func (p *Provider) setMemory(key string, vector []float64) {
+	if p.cfg.Size <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if el, ok := p.items[key]; ok {
+		el.Value.(*entry).vector = vector
+		p.lru.MoveToFront(el)
+		return
+	}
+	p.items[key] = p.lru.PushFront(&entry{key: key, vector: vector})
+	if p.lru.Len() > p.cfg.Size {
+		oldest := p.lru.Back()
+		p.lru.Remove(oldest)
+		delete(p.items, oldest.Value.(*entry).key)
+	}
+}
+
+func (p *Provider) getRedis(ctx context.Context, key string) ([]float64, error) {
+	data, err := p.client.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return decode(data)
+}
+
+// encode stores vector as little-endian float64 values.
+func encode(vector []float64) []byte {
+	data := make([]byte, 8*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint64(data[8*i:], math.Float64bits(v))
+	}
+	return data
+}
+
+func decode(data []byte) ([]float64, error) {
+	if len(data) == 0 || len(data)%8 != 0 {
+		return nil, fmt.Errorf("invalid cached embedding of %d bytes", len(data))
+	}
+	vector := make([]float64, len(data)/8)
+	for i := range vector {
+		vector[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	return vector, nil
+}
@@ -0,0 +1,112 @@
+package embedcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/neuro"
+)
+
+// fakeProvider embeds every input into a vector holding its length.
+type fakeProvider struct {
+	neuro.Provider
+	calls int
+}
+
+func (f *fakeProvider) Embed(_ context.Context, input string) ([]float64, error) {
+	f.calls++
+	return []float64{float64(len(input)), 0.5}, nil
+}
+
+func lookups(m *Metrics, result string) int {
+	return int(testutil.ToFloat64(m.Lookups.WithLabelValues(result)))
+}
+
+func TestProviderMemoryTier(t *testing.T) {
+	base := &fakeProvider{}
+	metrics := NewMetrics(prometheus.NewRegistry())
+	p := New(base, nil, &config.EmbeddingCache{Size: 2}, "embeddinggemma", metrics)
+	ctx := context.Background()
+
+	for _, input := range []string{"a", "a", "bb", "ccc", "a"} {
+		if _, err := p.Embed(ctx, input); err != nil {
+			t.Fatalf("Embed(%q) error = %v", input, err)
+		}
+	}
+
+	// "a" is evicted by "ccc" once "bb" and "ccc" fill the cache
+	if base.calls != 4 {
+		t.Errorf("expected 4 provider calls, got %d", base.calls)
+	}
+	if hits, misses := lookups(metrics, resultMemoryHit), lookups(metrics, resultMiss); hits != 1 || misses != 4 {
+		t.Errorf("expected 1 hit and 4 misses, got %d and %d", hits, misses)
+	}
+}
+
+func TestProviderKeyIncludesModel(t *testing.T) {
+	cfg := &config.EmbeddingCache{Size: 1}
+	first := New(&fakeProvider{}, nil, cfg, "embeddinggemma", nil)
+	second := New(&fakeProvider{}, nil, cfg, "nomic-embed-text", nil)
+
+	if first.key("Кант") == second.key("Кант") {
+		t.Error("expected different keys for different embedding models")
+	}
+	if first.key("Кант") == first.key("Гегель") {
+		t.Error("expected different keys for different inputs")
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	vector := []float64{0.1, -2.5, 3e-8}
+	got, err := decode(encode(vector))
+	if err != nil {
+		t.Fatalf("decode() error = %v", err)
+	}
+	for i := range vector {
+		if got[i] != vector[i] {
+			t.Fatalf("decode(encode(%v)) = %v", vector, got)
+		}
+	}
+	if _, err := decode([]byte{1, 2, 3}); err == nil {
+		t.Error("expected an error for a truncated value")
+	}
+}
+
+func TestProviderRedisTier(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379", DB: 2})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("Skipping Redis tests: %v", err)
+	}
+	defer client.Close() //nolint:errcheck // safe to ignore
+
+	cfg := &config.EmbeddingCache{Size: 1, TTL: time.Minute}
+	metrics := NewMetrics(prometheus.NewRegistry())
+	writer := New(&fakeProvider{}, client, cfg, "test-model", metrics)
+	client.Del(ctx, writer.key("Кант"))
+	if _, err := writer.Embed(ctx, "Кант"); err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+
+	// Another replica with a cold memory tier reads the vector from Redis
+	base := &fakeProvider{}
+	reader := New(base, client, cfg, "test-model", metrics)
+	vector, err := reader.Embed(ctx, "Кант")
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if base.calls != 0 || len(vector) != 2 || vector[0] != float64(len("Кант")) {
+		t.Errorf("expected the vector from Redis, got %v after %d calls", vector, base.calls)
+	}
+	if hits := lookups(metrics, resultRedisHit); hits != 1 {
+		t.Errorf("expected 1 Redis hit, got %d", hits)
+	}
+	client.Del(ctx, writer.key("Кант"))
+}
@@ -0,0 +1,40 @@
+package embedcache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Lookup results reported by Metrics.
+const (
+	resultMemoryHit = "memory_hit"
+	resultRedisHit  = "redis_hit"
+	resultMiss      = "miss"
+)
+
+// Metrics counts embedding cache lookups.
+type Metrics struct {
+	Lookups *prometheus.CounterVec
+}
+
+// NewMetrics registers the cache metrics in reg, the default registerer when nil.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	factory := promauto.With(reg)
+
+	return &Metrics{
+		Lookups: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "chat_embedding_cache_lookups_total",
+			Help: "The total number of embedding cache lookups by result: memory_hit, redis_hit or miss",
+		}, []string{"result"}),
+	}
+}
+
+func (m *Metrics) lookup(result string) {
+	if m != nil {
+		m.Lookups.WithLabelValues(result).Inc()
+	}
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// subscriberBuffer is the number of events a slow subscriber may lag behind before events are dropped.
+const subscriberBuffer = 16
+
+// Broker delivers session events to the connections of their user.
+// Events are best effort: a subscriber that does not keep up loses events instead of blocking the publisher.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan domain.SessionEvent]struct{} // userID -> subscriber channels
+}
+
+// NewBroker creates a broker without subscribers.
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[string]map[chan domain.SessionEvent]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving events of userID.
+// unsubscribe must be called once the subscriber goes away; it closes the channel.
+func (b *Broker) Subscribe(userID string) (<-chan domain.SessionEvent, func()) {
+	ch := make(chan domain.SessionEvent, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[chan domain.SessionEvent]struct{})
+	}
+	b.subscribers[userID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers[userID], ch)
+			if len(b.subscribers[userID]) == 0 {
+				delete(b.subscribers, userID)
+			}
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
+
+// Publish sends event to every subscriber of userID.
+func (b *Broker) Publish(userID string, event domain.SessionEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[userID] {
+		select {
+		case ch <- event:
+		default:
+			slog.Warn("dropping session event for slow subscriber", "user_id", userID, "type", event.Type)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+func TestBrokerPublishesToUserSubscribers(t *testing.T) {
+	b := NewBroker()
+	first, unsubscribeFirst := b.Subscribe("u1")
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := b.Subscribe("u1")
+	defer unsubscribeSecond()
+	other, unsubscribeOther := b.Subscribe("u2")
+	defer unsubscribeOther()
+
+	b.Publish("u1", domain.SessionEvent{Type: domain.SessionEventUpdated, Session: domain.ChatSession{ID: "s1"}})
+
+	for _, ch := range []<-chan domain.SessionEvent{first, second} {
+		select {
+		case event := <-ch:
+			if event.Session.ID != "s1" {
+				t.Errorf("unexpected event %+v", event)
+			}
+		default:
+			t.Error("expected every subscriber of the user to receive the event")
+		}
+	}
+	select {
+	case event := <-other:
+		t.Errorf("events of other users must not be delivered, got %+v", event)
+	default:
+	}
+}
+
+func TestBrokerDropsEventsForSlowSubscriber(t *testing.T) {
+	b := NewBroker()
+	ch, unsubscribe := b.Subscribe("u1")
+	defer unsubscribe()
+
+	for range subscriberBuffer + 1 {
+		b.Publish("u1", domain.SessionEvent{Type: domain.SessionEventUpdated})
+	}
+
+	if len(ch) != subscriberBuffer {
+		t.Errorf("expected %d buffered events, got %d", subscriberBuffer, len(ch))
+	}
+}
+
+func TestBrokerUnsubscribe(t *testing.T) {
+	b := NewBroker()
+	ch, unsubscribe := b.Subscribe("u1")
+	unsubscribe()
+	unsubscribe() // Must be safe to call twice.
+
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed")
+	}
+	if len(b.subscribers) != 0 {
+		t.Errorf("expected broker to be empty, got %v", b.subscribers)
+	}
+	b.Publish("u1", domain.SessionEvent{Type: domain.SessionEventUpdated})
+}
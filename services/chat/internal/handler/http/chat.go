@@ -1,20 +1,28 @@
 package http
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"math"
+	"strconv"
+	"sync"
 
 	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 
 	"github.com/artmexbet/raibecas/services/chat/internal/domain"
 	"github.com/artmexbet/raibecas/services/chat/internal/handler/models"
 )
 
-// chatHandler handles HTTP chat requests (for testing)
+// chatHandler handles HTTP chat requests, streaming response chunks as NDJSON while they are generated.
+// Session errors reported before the first chunk are mapped to status codes, exceeded quotas to 429 with Retry-After;
+// later errors are sent as a final {"error": "..."} line. The final chunk carries the remaining quota of the user.
+// While the request waits for the model, {"type":"queued","position":N} lines report its place in the queue;
+// a full queue is answered with 503.
 func (h *Handler) chatHandler(c *fiber.Ctx) error {
 	slog.Debug("Received chat request", slog.String("request_id", c.Get(fiber.HeaderXRequestID)))
 
@@ -24,45 +32,158 @@ func (h *Handler) chatHandler(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
 	}
+	req.UserRole = c.Get(headerUserRole)
+	req.IncludePrivate = domain.CanReadPrivateDocuments(req.UserRole)
+
+	slog.Debug("Processing chat input", slog.String("request_id", c.Get(fiber.HeaderXRequestID)))
+	return h.streamResponse(c, func(ctx context.Context, fn func(domain.ChatResponse) error) error {
+		return h.svc.ProcessInput(ctx, req.Input, req.UserID, req.SessionID, req.ChatOptions, fn)
+	})
+}
+
+// regenerateHandler streams a new version of the last answer of the session like chatHandler.
+func (h *Handler) regenerateHandler(c *fiber.Ctx) error {
+	var req models.ChatRequest
+	if err := req.UnmarshalJSON(c.Body()); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	req.UserRole = c.Get(headerUserRole)
+	req.IncludePrivate = domain.CanReadPrivateDocuments(req.UserRole)
+
+	return h.streamResponse(c, func(ctx context.Context, fn func(domain.ChatResponse) error) error {
+		return h.svc.Regenerate(ctx, req.UserID, req.SessionID, req.ChatOptions, fn)
+	})
+}
+
+// editHandler streams the answer to an edited version of the user message message_id like chatHandler.
+func (h *Handler) editHandler(c *fiber.Ctx) error {
+	var req models.ChatRequest
+	if err := req.UnmarshalJSON(c.Body()); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	req.UserRole = c.Get(headerUserRole)
+	req.IncludePrivate = domain.CanReadPrivateDocuments(req.UserRole)
+
+	return h.streamResponse(c, func(ctx context.Context, fn func(domain.ChatResponse) error) error {
+		return h.svc.EditMessage(ctx, req.UserID, req.SessionID, req.MessageID, req.Input, req.ChatOptions, fn)
+	})
+}
+
+// switchBranchHandler activates the newest branch going through message_id and returns its messages.
+func (h *Handler) switchBranchHandler(c *fiber.Ctx) error {
+	var req models.ChatRequest
+	if err := req.UnmarshalJSON(c.Body()); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	messages, err := h.svc.SwitchBranch(c.UserContext(), req.UserID, req.SessionID, req.MessageID)
+	if err != nil {
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		slog.Error("Could not switch branch", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not switch branch"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"messages": messages})
+}
+
+// streamResponse runs generate and writes its chunks as NDJSON.
+func (h *Handler) streamResponse(c *fiber.Ctx, generate func(ctx context.Context, fn func(domain.ChatResponse) error) error) error {
+	stream := startStream(c.UserContext(), generate)
+
+	first, ok := <-stream.chunks
+	if !ok {
+		err := stream.wait()
+		if retryAfter, ok := quotaRetryAfter(err); ok {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+		}
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err != nil {
+			return err
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
 
 	// Set headers for streaming response
 	c.Set("Content-Type", "application/x-ndjson")
-	c.Set("Transfer-Encoding", "chunked")
 	c.Set("X-Content-Type-Options", "nosniff")
 	c.Set("Cache-Control", "no-cache")
 
-	slog.Debug("Processing chat input", slog.String("request_id", c.Get(fiber.HeaderXRequestID)))
-	buf := bytes.NewBuffer(nil)
-	// Stream chunks as they arrive
-	err = h.svc.ProcessInput(
-		c.UserContext(),
-		req.Input, req.UserID, req.SessionID,
-		func(response domain.ChatResponse) error {
-			slog.DebugContext(c.UserContext(), "Sending chat response chunk",
-				slog.String("chunk", response.Message.Content),
-			)
-			// Marshal the response to JSON
-			data, err := json.Marshal(response)
-			if err != nil {
-				return err
-			}
-			buf.WriteString(string(data) + "\n")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer stream.cancel()
 
-			// Flush the response writer to send data immediately
-			return nil
-		})
+		send := func(data []byte) bool {
+			w.Write(data)     //nolint:errcheck // write errors surface on Flush
+			w.WriteByte('\n') //nolint:errcheck // write errors surface on Flush
+			// Flush the response writer to send data immediately.
+			// A failed flush means the client is gone: stop generation.
+			if err := w.Flush(); err != nil {
+				slog.Debug("Client disconnected, cancelling chat generation", slog.String("error", err.Error()))
+				stream.abort()
+				return false
+			}
+			return true
+		}
 
-	if err != nil {
-		if errors.Is(err, domain.ErrInvalidChatSessionID) {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		if !send(first) {
+			return
 		}
-		if errors.Is(err, domain.ErrChatSessionNotFound) {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		for data := range stream.chunks {
+			if !send(data) {
+				return
+			}
+		}
+
+		if err := stream.wait(); err != nil {
+			slog.Error("Chat processing error", slog.String("error", err.Error()))
+			w.WriteString(`{"error":"processing failed"}` + "\n") //nolint:errcheck // best effort
+			w.Flush()                                             //nolint:errcheck // best effort
 		}
-		return err
+	})
+	return nil
+}
+
+// errorStatus maps errors caused by the request to HTTP status codes. It returns 0 for other errors.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidChatSessionID),
+		errors.Is(err, domain.ErrInvalidMessageID),
+		errors.Is(err, domain.ErrNotUserMessage),
+		errors.Is(err, domain.ErrNothingToRegenerate),
+		errors.Is(err, domain.ErrInvalidSessionTitle),
+		errors.Is(err, domain.ErrEmptySearchQuery),
+		errors.Is(err, domain.ErrInvalidCorpus),
+		errors.Is(err, domain.ErrInvalidFilters),
+		errors.Is(err, domain.ErrInvalidFeedback),
+		errors.Is(err, domain.ErrNotAssistantMessage),
+		errors.Is(err, domain.ErrNothingToShare):
+		return fiber.StatusBadRequest
+	case errors.Is(err, domain.ErrChatSessionNotFound),
+		errors.Is(err, domain.ErrMessageNotFound),
+		errors.Is(err, domain.ErrCorpusNotFound),
+		errors.Is(err, domain.ErrShareNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, domain.ErrCorpusExists):
+		return fiber.StatusConflict
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		return fiber.StatusTooManyRequests
+	case errors.Is(err, domain.ErrQueueFull):
+		return fiber.StatusServiceUnavailable
+	default:
+		return 0
 	}
+}
 
-	return c.SendStream(buf)
+// quotaRetryAfter returns the seconds until the quota rejecting err resets.
+func quotaRetryAfter(err error) (int, bool) {
+	var quotaErr *domain.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return 0, false
+	}
+	return int(math.Ceil(quotaErr.RetryAfter.Seconds())), true
 }
 
 // deleteChatHandler clears chat history
@@ -82,7 +203,16 @@ func (h *Handler) deleteChatHandler(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "chat history cleared"})
 }
 
-// wsChatHandler handles WebSocket chat connections
+// wsChatHandler handles WebSocket chat connections.
+// Besides chat messages it accepts {"type":"regenerate"} and {"type":"edit","message_id":...} frames.
+// Chat requests are processed concurrently with reading, so that a {"type":"cancel"} frame
+// can stop a generation in flight. Every response chunk carries the request_id it belongs to.
+// While a request waits for the model, {"type":"queued","request_id":...,"position":N} frames report its place in the queue.
+// Requests rejected by a quota are answered with
+// {"type":"error","code":"quota_exceeded","request_id":...,"error":...,"retry_after":<seconds>}.
+// Session events of the user, e.g. {"type":"session.updated","session":{...}} after a title is generated,
+// are pushed to the connection as they happen.
+// Closing the connection cancels all of its requests.
 func (h *Handler) wsChatHandler(c *websocket.Conn) {
 	// Get userID from query param or header
 	userID := c.Query("userID")
@@ -92,52 +222,134 @@ func (h *Handler) wsChatHandler(c *websocket.Conn) {
 		return
 	}
 
+	// Access to private documents and the quota are fixed for the connection by the role passed on upgrade.
+	userRole := c.Headers(headerUserRole)
+	includePrivate := domain.CanReadPrivateDocuments(userRole)
+	// Generations are charged to the authenticated user, who may be an admin opening another user's chat.
+	callerID := c.Headers(headerCallerID)
+
 	slog.Info("WebSocket chat connection established", "user_id", userID)
 
+	connCtx, closeConn := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	var writeMu sync.Mutex
+	write := func(msgType int, data []byte) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return c.WriteMessage(msgType, data)
+	}
+	writeFrame := func(frame fiber.Map) {
+		data, _ := json.Marshal(frame)
+		if err := write(websocket.TextMessage, data); err != nil {
+			slog.Error("Failed to write error message", "error", err)
+		}
+	}
+	writeError := func(requestID, message string) {
+		writeFrame(fiber.Map{"type": "error", "request_id": requestID, "error": message})
+	}
+
+	sessionEvents, unsubscribe := h.events.Subscribe(userID)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for event := range sessionEvents {
+			data, err := event.MarshalJSON()
+			if err != nil {
+				slog.Error("Failed to marshal session event", "error", err)
+				continue
+			}
+			if err := write(websocket.TextMessage, data); err != nil {
+				slog.Debug("Failed to write session event", "user_id", userID, "error", err)
+			}
+		}
+	}()
+
 	// Handle messages
 	for {
 		msgType, data, err := c.ReadMessage()
 		if err != nil {
 			slog.Debug("WebSocket read error", "user_id", userID, "error", err)
-			return
+			break
 		}
 
 		// Parse the message
 		var req models.ChatRequest
 		if err := req.UnmarshalJSON(data); err != nil {
 			slog.Error("Failed to unmarshal request", "error", err)
-			err = c.WriteMessage(websocket.TextMessage, []byte(`{"error":"invalid request"}`))
-			if err != nil {
-				slog.Error("Failed to write error message", "error", err)
+			writeError("", "invalid request")
+			continue
+		}
+		req.IncludePrivate = includePrivate
+		req.UserRole = userRole
+		req.CallerID = callerID
+
+		var generate func(ctx context.Context, fn func(domain.ChatResponse) error) error
+		switch req.Type {
+		case models.FrameTypeCancel:
+			cancelled := h.inflight.Cancel(userID, req.RequestID)
+			slog.Debug("Chat cancel requested", "user_id", userID, "request_id", req.RequestID, "cancelled", cancelled)
+			continue
+		case "", models.FrameTypeMessage:
+			generate = func(ctx context.Context, fn func(domain.ChatResponse) error) error {
+				return h.svc.ProcessInput(ctx, req.Input, userID, req.SessionID, req.ChatOptions, fn)
 			}
+		case models.FrameTypeRegenerate:
+			generate = func(ctx context.Context, fn func(domain.ChatResponse) error) error {
+				return h.svc.Regenerate(ctx, userID, req.SessionID, req.ChatOptions, fn)
+			}
+		case models.FrameTypeEdit:
+			generate = func(ctx context.Context, fn func(domain.ChatResponse) error) error {
+				return h.svc.EditMessage(ctx, userID, req.SessionID, req.MessageID, req.Input, req.ChatOptions, fn)
+			}
+		default:
+			writeError(req.RequestID, "unknown frame type")
 			continue
 		}
 
-		// Process the chat input and stream responses
-		ctx := context.Background()
-		err = h.svc.ProcessInput(ctx,
-			req.Input, userID, req.SessionID,
-			func(response domain.ChatResponse) error {
+		if req.RequestID == "" {
+			req.RequestID = uuid.NewString()
+		}
+		ctx, release := h.inflight.Start(connCtx, userID, req.RequestID)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer release()
+
+			// Process the request and stream responses
+			err := generate(ctx, func(response domain.ChatResponse) error {
+				response.RequestID = req.RequestID
 				respData, err := json.Marshal(response)
 				if err != nil {
 					return err
 				}
-				return c.WriteMessage(msgType, respData)
+				return write(msgType, respData)
 			})
 
-		if err != nil {
-			slog.Error("Chat processing error", "error", err)
-			errorMessage := "processing failed"
-			if errors.Is(err, domain.ErrInvalidChatSessionID) || errors.Is(err, domain.ErrChatSessionNotFound) {
-				errorMessage = err.Error()
+			if retryAfter, ok := quotaRetryAfter(err); ok {
+				writeFrame(fiber.Map{
+					"type":        "error",
+					"code":        codeQuotaExceeded,
+					"request_id":  req.RequestID,
+					"error":       err.Error(),
+					"retry_after": retryAfter,
+				})
+				return
 			}
-
-			err = c.WriteMessage(websocket.TextMessage, []byte(`{"type":"error","error":"`+errorMessage+`"}`))
 			if err != nil {
-				slog.Error("Failed to write error message", "error", err)
+				slog.Error("Chat processing error", "error", err)
+				errorMessage := "processing failed"
+				if errorStatus(err) != 0 {
+					errorMessage = err.Error()
+				}
+				writeError(req.RequestID, errorMessage)
 			}
-		}
+		}()
 	}
+
+	closeConn()
+	unsubscribe()
+	wg.Wait()
 }
 
 // WSUpgradeHandler upgrades HTTP to WebSocket
@@ -149,14 +361,15 @@ func (h *Handler) WSUpgradeHandler(c *fiber.Ctx) error {
 	return fiber.ErrUpgradeRequired
 }
 
-// getUserSessionsHandler returns all chat sessions for a user
+// getUserSessionsHandler returns a page of the user's sessions.
+// Query parameters: archived (bool), limit, offset.
 func (h *Handler) getUserSessionsHandler(c *fiber.Ctx) error {
 	userID := c.Params("userID")
 	if userID == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "userID is required"})
 	}
 
-	sessions, err := h.svc.GetUserSessions(c.UserContext(), userID)
+	sessions, err := h.svc.GetUserSessions(c.UserContext(), userID, c.QueryBool("archived"), pageFromQuery(c))
 	if err != nil {
 		slog.Error("Could not get user sessions", slog.String("error", err.Error()))
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not get sessions"})
@@ -165,6 +378,96 @@ func (h *Handler) getUserSessionsHandler(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(sessions)
 }
 
+// getSessionMessagesHandler returns a page of the active branch of a session, newest page first.
+// Query parameters: limit, offset.
+func (h *Handler) getSessionMessagesHandler(c *fiber.Ctx) error {
+	messages, err := h.svc.GetSessionMessages(c.UserContext(), c.Params("userID"), c.Params("sessionID"), pageFromQuery(c))
+	if err != nil {
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		slog.Error("Could not get session messages", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not get messages"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(messages)
+}
+
+// updateSessionHandler renames, archives or restores a session
+func (h *Handler) updateSessionHandler(c *fiber.Ctx) error {
+	var update domain.SessionUpdate
+	if err := update.UnmarshalJSON(c.Body()); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	session, err := h.svc.UpdateSession(c.UserContext(), c.Params("userID"), c.Params("sessionID"), update)
+	if err != nil {
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		slog.Error("Could not update session", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not update session"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(session)
+}
+
+// setFeedbackHandler rates an assistant answer. A zero rating removes the feedback.
+func (h *Handler) setFeedbackHandler(c *fiber.Ctx) error {
+	var feedback domain.Feedback
+	if err := feedback.UnmarshalJSON(c.Body()); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	err := h.svc.SetFeedback(c.UserContext(), c.Params("userID"), c.Params("sessionID"), c.Params("messageID"), feedback)
+	if err != nil {
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		slog.Error("Could not save feedback", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not save feedback"})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// deleteSessionHandler deletes a session with its messages
+func (h *Handler) deleteSessionHandler(c *fiber.Ctx) error {
+	err := h.svc.DeleteSession(c.UserContext(), c.Params("userID"), c.Params("sessionID"))
+	if err != nil {
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		slog.Error("Could not delete session", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not delete session"})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// searchMessagesHandler runs a full-text search over the user's messages.
+// Query parameters: q, limit, offset.
+func (h *Handler) searchMessagesHandler(c *fiber.Ctx) error {
+	hits, err := h.svc.SearchMessages(c.UserContext(), c.Params("userID"), c.Query("q"), pageFromQuery(c))
+	if err != nil {
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		slog.Error("Could not search messages", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not search messages"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"results": hits})
+}
+
+// pageFromQuery reads the limit and offset query parameters. The service applies defaults and bounds.
+func pageFromQuery(c *fiber.Ctx) domain.Page {
+	return domain.Page{
+		Limit:  c.QueryInt("limit"),
+		Offset: c.QueryInt("offset"),
+	}
+}
+
 // createSessionHandler creates a new chat session for a user
 func (h *Handler) createSessionHandler(c *fiber.Ctx) error {
 	userID := c.Params("userID")
@@ -0,0 +1,500 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+	"github.com/artmexbet/raibecas/services/chat/internal/events"
+	"github.com/artmexbet/raibecas/services/chat/internal/inflight"
+)
+
+type fakeService struct {
+	chunks []string
+	err    error
+	page   domain.Page        // Last requested page.
+	opts   domain.ChatOptions // Options of the last chat request.
+	corpus domain.Corpus      // Last created or updated corpus.
+}
+
+func (f *fakeService) ProcessInput(_ context.Context, _, _, _ string, opts domain.ChatOptions, fn func(domain.ChatResponse) error) error {
+	f.opts = opts
+	for i, content := range f.chunks {
+		err := fn(domain.ChatResponse{
+			Done:    i == len(f.chunks)-1,
+			Message: &domain.Message{Role: "assistant", Content: content},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return f.err
+}
+
+func (f *fakeService) ClearUserChat(context.Context, string) error { return nil }
+
+func (f *fakeService) GetUserSessions(context.Context, string, bool, domain.Page) (domain.SessionPage, error) {
+	return domain.SessionPage{}, f.err
+}
+
+func (f *fakeService) GetSessionMessages(_ context.Context, _, _ string, page domain.Page) (domain.MessagePage, error) {
+	f.page = page
+	return domain.MessagePage{}, f.err
+}
+
+func (f *fakeService) UpdateSession(_ context.Context, userID, sessionID string, update domain.SessionUpdate) (domain.ChatSession, error) {
+	if f.err != nil {
+		return domain.ChatSession{}, f.err
+	}
+	session := domain.ChatSession{ID: sessionID, UserID: userID}
+	if update.Title != nil {
+		session.Title = *update.Title
+	}
+	if update.Archived != nil {
+		session.Archived = *update.Archived
+	}
+	return session, nil
+}
+
+func (f *fakeService) DeleteSession(context.Context, string, string) error { return f.err }
+
+func (f *fakeService) SearchMessages(context.Context, string, string, domain.Page) ([]domain.MessageSearchHit, error) {
+	return nil, f.err
+}
+
+func (f *fakeService) CreateSession(context.Context, string, string) (string, error) { return "", nil }
+
+func (f *fakeService) Regenerate(ctx context.Context, userID, sessionID string, opts domain.ChatOptions, fn func(domain.ChatResponse) error) error {
+	return f.ProcessInput(ctx, "", userID, sessionID, opts, fn)
+}
+
+func (f *fakeService) EditMessage(
+	ctx context.Context,
+	userID, sessionID, _, input string,
+	opts domain.ChatOptions,
+	fn func(domain.ChatResponse) error,
+) error {
+	return f.ProcessInput(ctx, input, userID, sessionID, opts, fn)
+}
+
+func (f *fakeService) ListCorpora(context.Context) ([]domain.Corpus, error) { return nil, f.err }
+
+func (f *fakeService) GetCorpus(_ context.Context, id string) (domain.Corpus, error) {
+	return domain.Corpus{ID: id}, f.err
+}
+
+func (f *fakeService) CreateCorpus(_ context.Context, corpus domain.Corpus) (domain.Corpus, error) {
+	f.corpus = corpus
+	return corpus, f.err
+}
+
+func (f *fakeService) UpdateCorpus(_ context.Context, corpus domain.Corpus) (domain.Corpus, error) {
+	f.corpus = corpus
+	return corpus, f.err
+}
+
+func (f *fakeService) DeleteCorpus(context.Context, string) error { return f.err }
+
+func (f *fakeService) SwitchBranch(context.Context, string, string, string) ([]domain.Message, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	messages := make([]domain.Message, len(f.chunks))
+	for i, content := range f.chunks {
+		messages[i] = domain.Message{Role: "user", Content: content}
+	}
+	return messages, nil
+}
+
+func (f *fakeService) SetFeedback(context.Context, string, string, string, domain.Feedback) error {
+	return f.err
+}
+
+func (f *fakeService) ShareSession(_ context.Context, _, sessionID string) (domain.SessionShare, error) {
+	return domain.SessionShare{Token: "t1", SessionID: sessionID}, f.err
+}
+
+func (f *fakeService) ListSessionShares(context.Context, string, string) ([]domain.SessionShare, error) {
+	return nil, f.err
+}
+
+func (f *fakeService) RevokeSessionShare(context.Context, string, string) error { return f.err }
+
+func (f *fakeService) GetSharedTranscript(_ context.Context, token string) (domain.SessionShare, error) {
+	if f.err != nil {
+		return domain.SessionShare{}, f.err
+	}
+	return domain.SessionShare{
+		Token:    token,
+		Title:    "Диалектика",
+		Messages: []domain.Message{{Role: "user", Content: "Что такое диалектика?"}},
+	}, nil
+}
+
+func newTestHandler(svc service) *Handler {
+	h := New(&config.HTTP{}, svc, inflight.NewRegistry(), events.NewBroker())
+	h.RegisterRoutes()
+	return h
+}
+
+func postChat(t *testing.T, h *Handler) *bufio.Scanner {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, "/api/v1/chat", strings.NewReader(`{"user_id":"u1","input":"hi"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := h.router.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get(fiber.HeaderContentType); ct != "application/x-ndjson" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	return bufio.NewScanner(resp.Body)
+}
+
+func TestChatHandlerStreamsChunks(t *testing.T) {
+	h := newTestHandler(&fakeService{chunks: []string{"Hel", "lo", ""}})
+
+	scanner := postChat(t, h)
+	var content strings.Builder
+	var lines int
+	var done bool
+	for scanner.Scan() {
+		var chunk domain.ChatResponse
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+		}
+		content.WriteString(chunk.Message.Content)
+		done = chunk.Done
+		lines++
+	}
+
+	if lines != 3 || content.String() != "Hello" || !done {
+		t.Errorf("unexpected stream: %d lines, content %q, done %v", lines, content.String(), done)
+	}
+}
+
+func TestChatHandlerReportsErrorAfterFirstChunk(t *testing.T) {
+	h := newTestHandler(&fakeService{chunks: []string{"partial"}, err: context.DeadlineExceeded})
+
+	scanner := postChat(t, h)
+	var last string
+	for scanner.Scan() {
+		last = scanner.Text()
+	}
+	if !strings.Contains(last, `"error"`) {
+		t.Errorf("expected trailing error line, got %q", last)
+	}
+}
+
+func TestChatHandlerMapsSessionErrors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{domain.ErrInvalidChatSessionID, fiber.StatusBadRequest},
+		{domain.ErrChatSessionNotFound, fiber.StatusNotFound},
+		{domain.ErrQueueFull, fiber.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		h := newTestHandler(&fakeService{err: tt.err})
+
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/chat", strings.NewReader(`{"user_id":"u1","input":"hi"}`))
+		resp, err := h.router.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.status {
+			t.Errorf("%v: expected status %d, got %d", tt.err, tt.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestBranchHandlersMapErrors(t *testing.T) {
+	tests := []struct {
+		path   string
+		err    error
+		status int
+	}{
+		{"/api/v1/chat/regenerate", domain.ErrNothingToRegenerate, fiber.StatusBadRequest},
+		{"/api/v1/chat/edit", domain.ErrNotUserMessage, fiber.StatusBadRequest},
+		{"/api/v1/chat/edit", domain.ErrMessageNotFound, fiber.StatusNotFound},
+		{"/api/v1/chat/branch", domain.ErrInvalidMessageID, fiber.StatusBadRequest},
+		{"/api/v1/chat/branch", domain.ErrMessageNotFound, fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		h := newTestHandler(&fakeService{err: tt.err})
+
+		body := `{"user_id":"u1","session_id":"s1","message_id":"m1","input":"hi"}`
+		req := httptest.NewRequest(fiber.MethodPost, tt.path, strings.NewReader(body))
+		resp, err := h.router.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s %v: expected status %d, got %d", tt.path, tt.err, tt.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestSwitchBranchHandlerReturnsMessages(t *testing.T) {
+	h := newTestHandler(&fakeService{chunks: []string{"first", "second"}})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/api/v1/chat/branch", strings.NewReader(`{"user_id":"u1","message_id":"m1"}`))
+	resp, err := h.router.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Messages []domain.Message `json:"messages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK || len(body.Messages) != 2 {
+		t.Errorf("unexpected response: status %d, %d messages", resp.StatusCode, len(body.Messages))
+	}
+}
+
+func TestSessionHandlers(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		err    error
+		status int
+	}{
+		{fiber.MethodPatch, "/api/v1/chat/u1/sessions/s1", `{"title":"Диалектика"}`, nil, fiber.StatusOK},
+		{fiber.MethodPatch, "/api/v1/chat/u1/sessions/s1", `{"title":" "}`, domain.ErrInvalidSessionTitle, fiber.StatusBadRequest},
+		{fiber.MethodPatch, "/api/v1/chat/u1/sessions/s1", `{"archived":true}`, domain.ErrChatSessionNotFound, fiber.StatusNotFound},
+		{fiber.MethodDelete, "/api/v1/chat/u1/sessions/s1", "", nil, fiber.StatusNoContent},
+		{fiber.MethodDelete, "/api/v1/chat/u1/sessions/bad", "", domain.ErrInvalidChatSessionID, fiber.StatusBadRequest},
+		{fiber.MethodGet, "/api/v1/chat/u1/sessions/s1/messages", "", domain.ErrChatSessionNotFound, fiber.StatusNotFound},
+		{fiber.MethodGet, "/api/v1/chat/u1/search?q=", "", domain.ErrEmptySearchQuery, fiber.StatusBadRequest},
+		{fiber.MethodGet, "/api/v1/chat/u1/search?q=бытие", "", nil, fiber.StatusOK},
+		{fiber.MethodPut, "/api/v1/chat/u1/sessions/s1/messages/m1/feedback", `{"rating":1,"comment":"Точно"}`, nil, fiber.StatusNoContent},
+		{fiber.MethodPut, "/api/v1/chat/u1/sessions/s1/messages/m1/feedback", `{"rating":3}`, domain.ErrInvalidFeedback, fiber.StatusBadRequest},
+		{fiber.MethodPut, "/api/v1/chat/u1/sessions/s1/messages/m1/feedback", `{"rating":-1}`, domain.ErrNotAssistantMessage, fiber.StatusBadRequest},
+		{fiber.MethodPut, "/api/v1/chat/u1/sessions/s1/messages/m1/feedback", `{"rating":-1}`, domain.ErrMessageNotFound, fiber.StatusNotFound},
+		{fiber.MethodPut, "/api/v1/chat/u1/sessions/s1/messages/m1/feedback", `not json`, nil, fiber.StatusBadRequest},
+		{fiber.MethodPost, "/api/v1/chat/u1/sessions/s1/shares", "", nil, fiber.StatusCreated},
+		{fiber.MethodPost, "/api/v1/chat/u1/sessions/s1/shares", "", domain.ErrNothingToShare, fiber.StatusBadRequest},
+		{fiber.MethodPost, "/api/v1/chat/u1/sessions/s1/shares", "", domain.ErrChatSessionNotFound, fiber.StatusNotFound},
+		{fiber.MethodGet, "/api/v1/chat/u1/sessions/s1/shares", "", nil, fiber.StatusOK},
+		{fiber.MethodDelete, "/api/v1/chat/u1/shares/t1", "", nil, fiber.StatusNoContent},
+		{fiber.MethodDelete, "/api/v1/chat/u1/shares/t1", "", domain.ErrShareNotFound, fiber.StatusNotFound},
+		{fiber.MethodGet, "/api/v1/shares/t1", "", domain.ErrShareNotFound, fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		h := newTestHandler(&fakeService{err: tt.err})
+
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		resp, err := h.router.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestSharedTranscriptHandler(t *testing.T) {
+	h := newTestHandler(&fakeService{})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/shares/t1", nil)
+	resp, err := h.router.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var share domain.SessionShare
+	if err := json.NewDecoder(resp.Body).Decode(&share); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	if share.Token != "t1" || share.Title != "Диалектика" || len(share.Messages) != 1 {
+		t.Errorf("unexpected transcript %+v", share)
+	}
+}
+
+func TestSessionMessagesHandlerReadsPage(t *testing.T) {
+	svc := &fakeService{}
+	h := newTestHandler(svc)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/chat/u1/sessions/s1/messages?limit=10&offset=30", nil)
+	resp, err := h.router.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if svc.page != (domain.Page{Limit: 10, Offset: 30}) {
+		t.Errorf("unexpected page %+v", svc.page)
+	}
+}
+
+func TestChatHandlerPassesCorpus(t *testing.T) {
+	svc := &fakeService{chunks: []string{""}}
+	h := newTestHandler(svc)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/api/v1/chat", strings.NewReader(`{"user_id":"u1","input":"hi","corpus_id":"kant"}`))
+	resp, err := h.router.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if svc.opts.CorpusID != "kant" {
+		t.Errorf("expected corpus kant, got %q", svc.opts.CorpusID)
+	}
+}
+
+func TestChatHandlerScopesRetrieval(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           string
+		body           string
+		includePrivate bool
+	}{
+		{name: "user", role: "User", body: `{"user_id":"u1","input":"hi","filters":{"document_ids":["d1"],"tag_ids":[3]}}`},
+		{name: "admin", role: domain.RoleAdmin, body: `{"user_id":"u1","input":"hi","filters":{"document_ids":["d1"],"tag_ids":[3]}}`, includePrivate: true},
+		{name: "body cannot grant access", role: "User", body: `{"user_id":"u1","input":"hi","include_private":true,"IncludePrivate":true,"UserRole":"Admin"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeService{chunks: []string{""}}
+			h := newTestHandler(svc)
+
+			req := httptest.NewRequest(fiber.MethodPost, "/api/v1/chat", strings.NewReader(tt.body))
+			req.Header.Set(headerUserRole, tt.role)
+			resp, err := h.router.Test(req, -1)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+
+			if svc.opts.IncludePrivate != tt.includePrivate {
+				t.Errorf("expected include private %v, got %v", tt.includePrivate, svc.opts.IncludePrivate)
+			}
+			if svc.opts.UserRole != tt.role {
+				t.Errorf("expected quota role %q, got %q", tt.role, svc.opts.UserRole)
+			}
+		})
+	}
+}
+
+func TestChatHandlerReportsExceededQuota(t *testing.T) {
+	h := newTestHandler(&fakeService{err: &domain.QuotaExceededError{
+		Quota:      domain.QuotaRequestsPerMinute,
+		RetryAfter: 12500 * time.Millisecond,
+	}})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/api/v1/chat", strings.NewReader(`{"user_id":"u1","input":"hi"}`))
+	resp, err := h.router.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get(fiber.HeaderRetryAfter); got != "13" {
+		t.Errorf("expected Retry-After 13, got %q", got)
+	}
+}
+
+func TestChatHandlerRejectsInvalidFilters(t *testing.T) {
+	svc := &fakeService{err: domain.ErrInvalidFilters}
+	h := newTestHandler(svc)
+
+	body := `{"user_id":"u1","input":"hi","filters":{"published_from":"2020-01-01T00:00:00Z","published_to":"2019-01-01T00:00:00Z"}}`
+	resp, err := h.router.Test(httptest.NewRequest(fiber.MethodPost, "/api/v1/chat", strings.NewReader(body)), -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+	if svc.opts.Filters.PublishedFrom == nil || svc.opts.Filters.PublishedFrom.Year() != 2020 {
+		t.Errorf("expected filters to be parsed, got %+v", svc.opts.Filters)
+	}
+}
+
+func TestCorpusHandlers(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		err    error
+		status int
+	}{
+		{fiber.MethodGet, "/api/v1/corpora", "", nil, fiber.StatusOK},
+		{fiber.MethodGet, "/api/v1/corpora/kant", "", domain.ErrCorpusNotFound, fiber.StatusNotFound},
+		{fiber.MethodPost, "/api/v1/corpora", `{"id":"kant","name":"Кант","collection_name":"kant"}`, nil, fiber.StatusCreated},
+		{fiber.MethodPost, "/api/v1/corpora", `{"id":"kant"}`, domain.ErrInvalidCorpus, fiber.StatusBadRequest},
+		{fiber.MethodPost, "/api/v1/corpora", `{"id":"kant","name":"Кант","collection_name":"kant"}`, domain.ErrCorpusExists, fiber.StatusConflict},
+		{fiber.MethodPut, "/api/v1/corpora/kant", `{"name":"Кант","collection_name":"kant"}`, nil, fiber.StatusOK},
+		{fiber.MethodDelete, "/api/v1/corpora/kant", "", nil, fiber.StatusNoContent},
+		{fiber.MethodDelete, "/api/v1/corpora/hegel", "", domain.ErrCorpusNotFound, fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		h := newTestHandler(&fakeService{err: tt.err})
+
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		resp, err := h.router.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s %s (%v): expected status %d, got %d", tt.method, tt.path, tt.err, tt.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestUpdateCorpusHandlerUsesPathID(t *testing.T) {
+	svc := &fakeService{}
+	h := newTestHandler(svc)
+
+	body := `{"id":"other","name":"Кант","collection_name":"kant","persona":{"generation_model":"mistral:7b","temperature":0.3}}`
+	req := httptest.NewRequest(fiber.MethodPut, "/api/v1/corpora/kant", strings.NewReader(body))
+	resp, err := h.router.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if svc.corpus.ID != "kant" || svc.corpus.Persona.GenerationModel != "mistral:7b" || svc.corpus.Persona.Temperature == nil {
+		t.Errorf("unexpected corpus %+v", svc.corpus)
+	}
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// listCorporaHandler returns all registered corpora
+func (h *Handler) listCorporaHandler(c *fiber.Ctx) error {
+	corpora, err := h.svc.ListCorpora(c.UserContext())
+	if err != nil {
+		slog.Error("Could not list corpora", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not list corpora"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"corpora": corpora})
+}
+
+// getCorpusHandler returns a corpus with its persona
+func (h *Handler) getCorpusHandler(c *fiber.Ctx) error {
+	corpus, err := h.svc.GetCorpus(c.UserContext(), c.Params("corpusID"))
+	if err != nil {
+		return corpusError(c, err, "could not get corpus")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(corpus)
+}
+
+// createCorpusHandler registers a new corpus
+func (h *Handler) createCorpusHandler(c *fiber.Ctx) error {
+	var corpus domain.Corpus
+	if err := corpus.UnmarshalJSON(c.Body()); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	created, err := h.svc.CreateCorpus(c.UserContext(), corpus)
+	if err != nil {
+		return corpusError(c, err, "could not create corpus")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// updateCorpusHandler replaces the settings of a corpus. The ID is taken from the path.
+func (h *Handler) updateCorpusHandler(c *fiber.Ctx) error {
+	var corpus domain.Corpus
+	if err := corpus.UnmarshalJSON(c.Body()); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	corpus.ID = c.Params("corpusID")
+
+	updated, err := h.svc.UpdateCorpus(c.UserContext(), corpus)
+	if err != nil {
+		return corpusError(c, err, "could not update corpus")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
+
+// deleteCorpusHandler removes a corpus from the registry
+func (h *Handler) deleteCorpusHandler(c *fiber.Ctx) error {
+	if err := h.svc.DeleteCorpus(c.UserContext(), c.Params("corpusID")); err != nil {
+		return corpusError(c, err, "could not delete corpus")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// corpusError writes err with its status code, or a generic message for internal errors.
+func corpusError(c *fiber.Ctx, err error, message string) error {
+	if status := errorStatus(err); status != 0 {
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+	slog.Error(message, slog.String("error", err.Error()))
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": message})
+}
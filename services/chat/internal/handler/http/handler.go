@@ -5,32 +5,81 @@ import (
 
 	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/healthcheck"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	recover2 "github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/artmexbet/raibecas/services/chat/internal/config"
 	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+	"github.com/artmexbet/raibecas/services/chat/internal/events"
+	"github.com/artmexbet/raibecas/services/chat/internal/inflight"
+)
+
+const (
+	// headerUserRole carries the role of the requesting user, set by the gateway.
+	headerUserRole = "X-User-Role"
+	// headerCallerID carries the authenticated user of a WebSocket connection, set by the gateway.
+	headerCallerID = "X-Caller-ID"
+	// codeQuotaExceeded marks WebSocket error frames of requests rejected by a quota.
+	codeQuotaExceeded = "quota_exceeded"
 )
 
 type service interface {
-	ProcessInput(ctx context.Context, input, userID, sessionID string, fn func(response domain.ChatResponse) error) error
+	ProcessInput(
+		ctx context.Context,
+		input, userID, sessionID string,
+		opts domain.ChatOptions,
+		fn func(response domain.ChatResponse) error,
+	) error
 	ClearUserChat(ctx context.Context, userID string) error
-	GetUserSessions(ctx context.Context, userID string) ([]domain.ChatSession, error)
+	GetUserSessions(ctx context.Context, userID string, archived bool, page domain.Page) (domain.SessionPage, error)
+	GetSessionMessages(ctx context.Context, userID, sessionID string, page domain.Page) (domain.MessagePage, error)
+	UpdateSession(ctx context.Context, userID, sessionID string, update domain.SessionUpdate) (domain.ChatSession, error)
+	DeleteSession(ctx context.Context, userID, sessionID string) error
+	SearchMessages(ctx context.Context, userID, query string, page domain.Page) ([]domain.MessageSearchHit, error)
 	CreateSession(ctx context.Context, userID, title string) (string, error)
+	Regenerate(
+		ctx context.Context,
+		userID, sessionID string,
+		opts domain.ChatOptions,
+		fn func(response domain.ChatResponse) error,
+	) error
+	EditMessage(
+		ctx context.Context,
+		userID, sessionID, messageID, input string,
+		opts domain.ChatOptions,
+		fn func(response domain.ChatResponse) error,
+	) error
+	SwitchBranch(ctx context.Context, userID, sessionID, messageID string) ([]domain.Message, error)
+	SetFeedback(ctx context.Context, userID, sessionID, messageID string, feedback domain.Feedback) error
+	ShareSession(ctx context.Context, userID, sessionID string) (domain.SessionShare, error)
+	ListSessionShares(ctx context.Context, userID, sessionID string) ([]domain.SessionShare, error)
+	RevokeSessionShare(ctx context.Context, userID, token string) error
+	GetSharedTranscript(ctx context.Context, token string) (domain.SessionShare, error)
+	ListCorpora(ctx context.Context) ([]domain.Corpus, error)
+	GetCorpus(ctx context.Context, id string) (domain.Corpus, error)
+	CreateCorpus(ctx context.Context, corpus domain.Corpus) (domain.Corpus, error)
+	UpdateCorpus(ctx context.Context, corpus domain.Corpus) (domain.Corpus, error)
+	DeleteCorpus(ctx context.Context, id string) error
 }
 
 // Handler represents the HTTP handler.
 // It used by only for testing the service.
 type Handler struct {
-	router *fiber.App
-	svc    service
-	cfg    *config.HTTP
+	router   *fiber.App
+	svc      service
+	cfg      *config.HTTP
+	inflight *inflight.Registry
+	events   *events.Broker
 }
 
-func New(cfg *config.HTTP, svc service) *Handler {
+// New creates the HTTP handler. requests tracks in-flight WebSocket generations for cancellation,
+// sessionEvents are pushed to WebSocket connections of their user.
+func New(cfg *config.HTTP, svc service, requests *inflight.Registry, sessionEvents *events.Broker) *Handler {
 	router := fiber.New()
 
 	router.Use(cors.New(
@@ -44,21 +93,49 @@ func New(cfg *config.HTTP, svc service) *Handler {
 	router.Use(requestid.New())
 
 	return &Handler{
-		router: router,
-		svc:    svc,
-		cfg:    cfg,
+		router:   router,
+		svc:      svc,
+		cfg:      cfg,
+		inflight: requests,
+		events:   sessionEvents,
 	}
 }
 
 func (h *Handler) RegisterRoutes() {
+	// Prometheus metrics of the default registry, e.g. embedding cache lookups
+	h.router.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+
 	h.router.Post("/api/v1/chat", h.chatHandler)
 
+	// Branching: regenerate the last answer, edit a prompt, switch between versions
+	h.router.Post("/api/v1/chat/regenerate", h.regenerateHandler)
+	h.router.Post("/api/v1/chat/edit", h.editHandler)
+	h.router.Post("/api/v1/chat/branch", h.switchBranchHandler)
+
 	// Clear chat history endpoint
 	h.router.Delete("/api/v1/chat/:userID", h.deleteChatHandler)
 
 	// Sessions (admin) endpoints
 	h.router.Get("/api/v1/chat/:userID/sessions", h.getUserSessionsHandler)
 	h.router.Post("/api/v1/chat/:userID/sessions", h.createSessionHandler)
+	h.router.Patch("/api/v1/chat/:userID/sessions/:sessionID", h.updateSessionHandler)
+	h.router.Delete("/api/v1/chat/:userID/sessions/:sessionID", h.deleteSessionHandler)
+	h.router.Get("/api/v1/chat/:userID/sessions/:sessionID/messages", h.getSessionMessagesHandler)
+	h.router.Put("/api/v1/chat/:userID/sessions/:sessionID/messages/:messageID/feedback", h.setFeedbackHandler)
+	h.router.Get("/api/v1/chat/:userID/search", h.searchMessagesHandler)
+
+	// Read-only snapshots of sessions shared by a link; the transcript route is public in the gateway
+	h.router.Post("/api/v1/chat/:userID/sessions/:sessionID/shares", h.createShareHandler)
+	h.router.Get("/api/v1/chat/:userID/sessions/:sessionID/shares", h.listSharesHandler)
+	h.router.Delete("/api/v1/chat/:userID/shares/:token", h.revokeShareHandler)
+	h.router.Get("/api/v1/shares/:token", h.getSharedTranscriptHandler)
+
+	// Corpora (admin) endpoints: collections and personas chat requests can select with corpus_id
+	h.router.Get("/api/v1/corpora", h.listCorporaHandler)
+	h.router.Post("/api/v1/corpora", h.createCorpusHandler)
+	h.router.Get("/api/v1/corpora/:corpusID", h.getCorpusHandler)
+	h.router.Put("/api/v1/corpora/:corpusID", h.updateCorpusHandler)
+	h.router.Delete("/api/v1/corpora/:corpusID", h.deleteCorpusHandler)
 
 	// WebSocket chat endpoint for Gateway connection
 	h.router.Use("/ws/chat", h.WSUpgradeHandler)
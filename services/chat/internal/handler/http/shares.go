@@ -0,0 +1,63 @@
+package http
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// createShareHandler publishes a read-only snapshot of the active branch of a session.
+func (h *Handler) createShareHandler(c *fiber.Ctx) error {
+	share, err := h.svc.ShareSession(c.UserContext(), c.Params("userID"), c.Params("sessionID"))
+	if err != nil {
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		slog.Error("Could not share session", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not share session"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(share)
+}
+
+// listSharesHandler returns the published snapshots of a session without messages.
+func (h *Handler) listSharesHandler(c *fiber.Ctx) error {
+	shares, err := h.svc.ListSessionShares(c.UserContext(), c.Params("userID"), c.Params("sessionID"))
+	if err != nil {
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		slog.Error("Could not list shares", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not list shares"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"shares": shares})
+}
+
+// revokeShareHandler deletes a snapshot of the user, so that its link stops working.
+func (h *Handler) revokeShareHandler(c *fiber.Ctx) error {
+	err := h.svc.RevokeSessionShare(c.UserContext(), c.Params("userID"), c.Params("token"))
+	if err != nil {
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		slog.Error("Could not revoke share", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not revoke share"})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// getSharedTranscriptHandler returns a published snapshot by its token. It needs no user.
+func (h *Handler) getSharedTranscriptHandler(c *fiber.Ctx) error {
+	share, err := h.svc.GetSharedTranscript(c.UserContext(), c.Params("token"))
+	if err != nil {
+		if status := errorStatus(err); status != 0 {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		slog.Error("Could not get shared transcript", slog.String("error", err.Error()))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "could not get shared transcript"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(share)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// chunkStream runs a chat generation in the background and exposes its encoded chunks.
+// The generation context is detached from the request: the response is written after
+// the fiber handler returns, so only cancel/abort stop the generation.
+type chunkStream struct {
+	chunks chan []byte
+	errCh  chan error
+	cancel context.CancelFunc
+}
+
+// startStream starts generate with a callback that pushes every response chunk into the stream.
+func startStream(
+	parent context.Context,
+	generate func(ctx context.Context, fn func(domain.ChatResponse) error) error,
+) *chunkStream {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(parent))
+	s := &chunkStream{
+		chunks: make(chan []byte),
+		errCh:  make(chan error, 1),
+		cancel: cancel,
+	}
+
+	go func() {
+		defer close(s.chunks)
+		s.errCh <- generate(ctx, func(response domain.ChatResponse) error {
+			data, err := json.Marshal(response)
+			if err != nil {
+				return err
+			}
+			select {
+			case s.chunks <- data:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return s
+}
+
+// wait returns the generation result. It must be called after chunks is closed.
+func (s *chunkStream) wait() error {
+	s.cancel()
+	return <-s.errCh
+}
+
+// abort cancels the generation and drains pending chunks so the producer can exit.
+func (s *chunkStream) abort() {
+	s.cancel()
+	for range s.chunks {
+	}
+}
@@ -1,10 +1,24 @@
 package models
 
+import "github.com/artmexbet/raibecas/services/chat/internal/domain"
+
 //go:generate easyjson -all models.go
 
+// Frame types accepted on the chat WebSocket.
+const (
+	FrameTypeMessage    = "message"
+	FrameTypeCancel     = "cancel"
+	FrameTypeRegenerate = "regenerate"
+	FrameTypeEdit       = "edit"
+)
+
 // ChatRequest represents a request for chat processing.
 type ChatRequest struct {
+	Type      string `json:"type,omitempty"`       // One of the FrameType* constants, FrameTypeMessage by default. WebSocket only.
+	RequestID string `json:"request_id,omitempty"` // Client-chosen ID used to cancel the request. Generated when empty.
 	UserID    string `json:"user_id"`
 	SessionID string `json:"session_id,omitempty"`
+	MessageID string `json:"message_id,omitempty"` // Edited message for edit requests, branch to activate for branch switches.
 	Input     string `json:"input"`
+	domain.ChatOptions
 }
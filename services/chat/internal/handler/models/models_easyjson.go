@@ -31,6 +31,18 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalHandler
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
+		case "type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Type = string(in.String())
+			}
+		case "request_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RequestID = string(in.String())
+			}
 		case "user_id":
 			if in.IsNull() {
 				in.Skip()
@@ -43,12 +55,30 @@ func easyjsonD2b7633eDecodeGithubComArtmexbetRaibecasServicesChatInternalHandler
 			} else {
 				out.SessionID = string(in.String())
 			}
+		case "message_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MessageID = string(in.String())
+			}
 		case "input":
 			if in.IsNull() {
 				in.Skip()
 			} else {
 				out.Input = string(in.String())
 			}
+		case "corpus_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CorpusID = string(in.String())
+			}
+		case "filters":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(out.Filters).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -63,9 +93,30 @@ func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalHandler
 	out.RawByte('{')
 	first := true
 	_ = first
+	if in.Type != "" {
+		const prefix string = ",\"type\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	if in.RequestID != "" {
+		const prefix string = ",\"request_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RequestID))
+	}
 	{
 		const prefix string = ",\"user_id\":"
-		out.RawString(prefix[1:])
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		out.String(string(in.UserID))
 	}
 	if in.SessionID != "" {
@@ -73,11 +124,26 @@ func easyjsonD2b7633eEncodeGithubComArtmexbetRaibecasServicesChatInternalHandler
 		out.RawString(prefix)
 		out.String(string(in.SessionID))
 	}
+	if in.MessageID != "" {
+		const prefix string = ",\"message_id\":"
+		out.RawString(prefix)
+		out.String(string(in.MessageID))
+	}
 	{
 		const prefix string = ",\"input\":"
 		out.RawString(prefix)
 		out.String(string(in.Input))
 	}
+	if in.CorpusID != "" {
+		const prefix string = ",\"corpus_id\":"
+		out.RawString(prefix)
+		out.String(string(in.CorpusID))
+	}
+	{
+		const prefix string = ",\"filters\":"
+		out.RawString(prefix)
+		(in.Filters).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -3,56 +3,128 @@ package nats
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
 
 	"github.com/artmexbet/raibecas/libs/natsw"
 
 	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+	"github.com/artmexbet/raibecas/services/chat/internal/inflight"
+)
+
+const (
+	subjectChatRequest = "chat.request"
+	// subjectChatCancel is chat.cancel.<user_id>; the optional body {"request_id": "..."}
+	// selects a single request, otherwise every request of the user is cancelled.
+	subjectChatCancel = "chat.cancel.*"
+
+	// headerUserRole carries the role of the requesting user. Admins may retrieve private documents.
+	headerUserRole = "X-User-Role"
+
+	// codeQuotaExceeded marks the error chunk of a request rejected by a quota.
+	codeQuotaExceeded = "quota_exceeded"
 )
 
 // ChatRequest represents a chat request from NATS
 type ChatRequest struct {
+	RequestID string `json:"request_id,omitempty"` // Used to cancel the request. Generated when empty.
 	Input     string `json:"input"`
 	UserID    string `json:"user_id"`
 	SessionID string `json:"session_id,omitempty"`
+	domain.ChatOptions
+}
+
+// CancelRequest is the optional body of a chat.cancel.<user_id> message
+type CancelRequest struct {
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // ChatResponseChunk represents a streaming response chunk
 type ChatResponseChunk struct {
-	Done    bool            `json:"done"`
-	Message *domain.Message `json:"message,omitempty"`
+	RequestID string             `json:"request_id"`
+	Type      string             `json:"type,omitempty"`     // "queued" while the request waits for the model.
+	Position  int                `json:"position,omitempty"` // Position in the generation queue of a "queued" chunk.
+	Done      bool               `json:"done"`
+	Message   *domain.Message    `json:"message,omitempty"`
+	Citations []domain.Citation  `json:"citations,omitempty"`
+	Truncated bool               `json:"truncated,omitempty"`
+	Quota     *domain.QuotaUsage `json:"quota,omitempty"` // Remaining quota of the user, set on the final chunk.
+	// Code and RetryAfter (in seconds) are set on the error chunk of a request rejected by a quota.
+	Code       string `json:"code,omitempty"`
+	RetryAfter int    `json:"retry_after,omitempty"`
 }
 
 // Service defines the chat service interface
 type Service interface {
-	ProcessInput(ctx context.Context, input, userID, sessionID string, fn func(response domain.ChatResponse) error) error
+	ProcessInput(
+		ctx context.Context,
+		input, userID, sessionID string,
+		opts domain.ChatOptions,
+		fn func(response domain.ChatResponse) error,
+	) error
 	ClearUserChat(ctx context.Context, userID string) error
 }
 
 // Handler handles NATS messages for chat service
 type Handler struct {
-	client *natsw.Client
-	svc    Service
+	client   *natsw.Client
+	service  *natsw.Service
+	svc      Service
+	inflight *inflight.Registry
 }
 
-// NewHandler creates a new NATS handler
-func NewHandler(client *natsw.Client, svc Service) *Handler {
+// NewHandler creates a new NATS handler. Endpoints are registered in service, responses are published with client.
+// requests tracks in-flight generations for cancellation.
+func NewHandler(client *natsw.Client, service *natsw.Service, svc Service, requests *inflight.Registry) *Handler {
 	return &Handler{
-		client: client,
-		svc:    svc,
+		client:   client,
+		service:  service,
+		svc:      svc,
+		inflight: requests,
 	}
 }
 
 // Subscribe subscribes to NATS subjects
 func (h *Handler) Subscribe() error {
-	// Subscribe to chat requests
-	_, err := h.client.Subscribe("chat.request", h.handleChatRequest)
-	if err != nil {
-		return fmt.Errorf("failed to subscribe to chat.request: %w", err)
+	// Chat requests are shared by the replicas of the queue group
+	if err := h.service.Handle(subjectChatRequest, h.handleChatRequest); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", subjectChatRequest, err)
+	}
+
+	// Cancellation runs on its own subscription so it is not blocked by a request in flight.
+	// Every replica receives it, because the request runs on one of them.
+	if err := h.service.Handle(subjectChatCancel, h.handleChatCancel, natsw.WithQueueGroup("")); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", subjectChatCancel, err)
+	}
+
+	slog.Info("NATS handler subscribed", "subjects", h.service.Subjects())
+	return nil
+}
+
+// Stop drains the subscriptions, waiting for in-flight requests until ctx is done
+func (h *Handler) Stop(ctx context.Context) error {
+	return h.service.Drain(ctx)
+}
+
+// handleChatCancel stops in-flight requests of the user named by the last subject token
+func (h *Handler) handleChatCancel(msg *natsw.Message) error {
+	userID := msg.Subject[strings.LastIndex(msg.Subject, ".")+1:]
+
+	var req CancelRequest
+	if len(msg.Data) > 0 {
+		if err := json.Unmarshal(msg.Data, &req); err != nil {
+			slog.Error("Failed to unmarshal cancel request", "error", err)
+			return err
+		}
 	}
 
-	slog.Info("NATS handler subscribed to chat.request")
+	cancelled := h.inflight.Cancel(userID, req.RequestID)
+	slog.Debug("Chat cancel requested via NATS", "user_id", userID, "request_id", req.RequestID, "cancelled", cancelled)
 	return nil
 }
 
@@ -63,17 +135,29 @@ func (h *Handler) handleChatRequest(msg *natsw.Message) error {
 		slog.Error("Failed to unmarshal chat request", "error", err)
 		return err
 	}
+	req.UserRole = msg.Header.Get(headerUserRole)
+	req.IncludePrivate = domain.CanReadPrivateDocuments(req.UserRole)
 
-	ctx := context.Background()
+	if req.RequestID == "" {
+		req.RequestID = uuid.NewString()
+	}
+	ctx, release := h.inflight.Start(context.Background(), req.UserID, req.RequestID)
+	defer release()
 	responseSubject := fmt.Sprintf("chat.response.%s", req.UserID)
 
 	slog.Debug("Processing chat request via NATS", "user_id", req.UserID)
 
 	// Stream responses back through NATS
-	err := h.svc.ProcessInput(ctx, req.Input, req.UserID, req.SessionID, func(response domain.ChatResponse) error {
+	err := h.svc.ProcessInput(ctx, req.Input, req.UserID, req.SessionID, req.ChatOptions, func(response domain.ChatResponse) error {
 		chunk := ChatResponseChunk{
-			Done:    response.Done,
-			Message: response.Message,
+			RequestID: req.RequestID,
+			Type:      response.Type,
+			Position:  response.Position,
+			Done:      response.Done,
+			Message:   response.Message,
+			Citations: response.Citations,
+			Truncated: response.Truncated,
+			Quota:     response.Quota,
 		}
 
 		data, err := json.Marshal(chunk)
@@ -94,12 +178,18 @@ func (h *Handler) handleChatRequest(msg *natsw.Message) error {
 		slog.Error("Chat processing error", "error", err, "user_id", req.UserID)
 		// Send error response
 		errorChunk := ChatResponseChunk{
-			Done: true,
+			RequestID: req.RequestID,
+			Done:      true,
 			Message: &domain.Message{
 				Role:    "system",
 				Content: fmt.Sprintf("Error: %v", err),
 			},
 		}
+		var quotaErr *domain.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			errorChunk.Code = codeQuotaExceeded
+			errorChunk.RetryAfter = int(math.Ceil(quotaErr.RetryAfter.Seconds()))
+		}
 		data, _ := json.Marshal(errorChunk)
 		err = h.client.Publish(ctx, responseSubject, data)
 		if err != nil {
@@ -0,0 +1,75 @@
+package inflight
+
+import (
+	"context"
+	"sync"
+)
+
+// Registry tracks cancel functions of in-flight chat generations by user and request ID,
+// so that a generation started on one transport can be stopped from another.
+type Registry struct {
+	mu       sync.Mutex
+	requests map[string]map[string]*entry // userID -> requestID -> entry
+}
+
+type entry struct {
+	cancel context.CancelFunc
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		requests: make(map[string]map[string]*entry),
+	}
+}
+
+// Start derives a cancellable context for the request and registers it.
+// release must be called once the request finishes; it also cancels the context.
+// Starting a request with an ID that is already in flight makes the new request the cancellation target.
+func (r *Registry) Start(parent context.Context, userID, requestID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	e := &entry{cancel: cancel}
+
+	r.mu.Lock()
+	if r.requests[userID] == nil {
+		r.requests[userID] = make(map[string]*entry)
+	}
+	r.requests[userID][requestID] = e
+	r.mu.Unlock()
+
+	release := func() {
+		cancel()
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.requests[userID][requestID] == e {
+			delete(r.requests[userID], requestID)
+			if len(r.requests[userID]) == 0 {
+				delete(r.requests, userID)
+			}
+		}
+	}
+	return ctx, release
+}
+
+// Cancel stops the user's request with requestID, or every request of the user when requestID is empty.
+// Returns the number of cancelled requests.
+func (r *Registry) Cancel(userID, requestID string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user := r.requests[userID]
+	if requestID != "" {
+		e, ok := user[requestID]
+		if !ok {
+			return 0
+		}
+		e.cancel()
+		return 1
+	}
+
+	for _, e := range user {
+		e.cancel()
+	}
+	return len(user)
+}
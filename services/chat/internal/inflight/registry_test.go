@@ -0,0 +1,71 @@
+package inflight
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRegistryCancelByRequestID(t *testing.T) {
+	r := NewRegistry()
+	ctx1, release1 := r.Start(context.Background(), "u1", "r1")
+	defer release1()
+	ctx2, release2 := r.Start(context.Background(), "u1", "r2")
+	defer release2()
+
+	if n := r.Cancel("u1", "r1"); n != 1 {
+		t.Fatalf("expected 1 cancelled request, got %d", n)
+	}
+	if ctx1.Err() == nil {
+		t.Error("expected r1 to be cancelled")
+	}
+	if ctx2.Err() != nil {
+		t.Error("expected r2 to keep running")
+	}
+}
+
+func TestRegistryCancelAllUserRequests(t *testing.T) {
+	r := NewRegistry()
+	ctx1, release1 := r.Start(context.Background(), "u1", "r1")
+	defer release1()
+	ctx2, release2 := r.Start(context.Background(), "u1", "r2")
+	defer release2()
+	other, releaseOther := r.Start(context.Background(), "u2", "r1")
+	defer releaseOther()
+
+	if n := r.Cancel("u1", ""); n != 2 {
+		t.Fatalf("expected 2 cancelled requests, got %d", n)
+	}
+	if ctx1.Err() == nil || ctx2.Err() == nil {
+		t.Error("expected all u1 requests to be cancelled")
+	}
+	if other.Err() != nil {
+		t.Error("requests of other users must not be cancelled")
+	}
+}
+
+func TestRegistryRelease(t *testing.T) {
+	r := NewRegistry()
+	_, release := r.Start(context.Background(), "u1", "r1")
+	release()
+
+	if n := r.Cancel("u1", "r1"); n != 0 {
+		t.Errorf("expected released request to be forgotten, got %d cancelled", n)
+	}
+	if len(r.requests) != 0 {
+		t.Errorf("expected registry to be empty, got %v", r.requests)
+	}
+}
+
+func TestRegistryReuseRequestID(t *testing.T) {
+	r := NewRegistry()
+	_, releaseOld := r.Start(context.Background(), "u1", "r1")
+	ctxNew, releaseNew := r.Start(context.Background(), "u1", "r1")
+	defer releaseNew()
+
+	// Releasing the old request must not unregister the new one.
+	releaseOld()
+
+	if n := r.Cancel("u1", "r1"); n != 1 || ctxNew.Err() == nil {
+		t.Error("expected the newest request to be cancellable")
+	}
+}
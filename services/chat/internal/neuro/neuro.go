@@ -5,14 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"net/http"
-	"net/url"
+	"regexp"
 	"strings"
 
-	ollamaApi "github.com/ollama/ollama/api"
-
-	"github.com/artmexbet/raibecas/libs/utils/pointer"
-
 	"github.com/artmexbet/raibecas/services/chat/internal/config"
 	"github.com/artmexbet/raibecas/services/chat/internal/domain"
 )
@@ -21,47 +16,50 @@ const (
 	roleSystem = "system"
 	roleUser   = "user"
 
-	contextContentKey = "chunk_text"
+	summaryHeader = "Summary of the earlier conversation:\n"
+
+	titleTrimChars = "\"'«»“”*#. "
+
+	// variantsInstruction is appended to the rewrite prompt when paraphrases are requested.
+	variantsInstruction = "\nЗатем на отдельных строках напиши ещё %d других формулировок этого запроса."
+	queryTrimChars      = "\"'«»“”*. "
+	questionLabel       = "question: "
+
+	contextContentKey = domain.PayloadChunkText
+)
+
+// listMarker matches numbering and bullets models put before paraphrases.
+var listMarker = regexp.MustCompile(`^(\d+[.)]|[-*•])\s+`)
+
+var (
+	errEmptyTitle = errors.New("model returned an empty title")
+	errEmptyQuery = errors.New("model returned an empty query")
+	errNoScores   = errors.New("model rated none of the passages")
+	// errStreamTruncated is returned when a streamed answer ends before the model finished it.
+	errStreamTruncated = errors.New("chat stream ended before the answer was finished")
 )
 
+// Connector builds prompts from the working context and sends them to the configured LLM provider.
 type Connector struct {
-	client    *ollamaApi.Client
-	cfg       *config.Ollama
-	baseRoute string
+	provider Provider
+	cfg      *config.Ollama
 }
 
-// NewConnector creates a new Connector for interacting with the Ollama API.
-// cfg is the Ollama configuration containing the API address and model settings.
-// Returns a Connector instance or an error if the address is invalid.
-func NewConnector(cfg *config.Ollama) (*Connector, error) {
-	httpClient := &http.Client{}
-	u, err := url.Parse(cfg.GetAddress())
-	if err != nil {
-		return nil, fmt.Errorf("invalid Ollama address: %w", err)
-	}
-
-	client := ollamaApi.NewClient(u, httpClient)
+// NewConnector creates a new Connector on top of the given provider.
+// cfg holds prompts and generation settings shared by all providers.
+func NewConnector(provider Provider, cfg *config.Ollama) *Connector {
 	return &Connector{
-		client:    client,
-		cfg:       cfg,
-		baseRoute: cfg.GetAddress(),
-	}, nil
+		provider: provider,
+		cfg:      cfg,
+	}
 }
 
-// GenerateEmbeddings sends a request to Ollama to generate embeddings for the given input text.
+// GenerateEmbeddings asks the provider to generate embeddings for the given input text.
 func (e *Connector) GenerateEmbeddings(ctx context.Context, input string) ([]float64, error) {
-	reqData := &ollamaApi.EmbeddingRequest{
-		Model:  e.cfg.EmbeddingModel,
-		Prompt: input,
-	}
-	resp, err := e.client.Embeddings(ctx, reqData)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
-	}
-	return resp.Embedding, nil
+	return e.provider.Embed(ctx, input)
 }
 
-// Chat sends a chat request to Ollama with the provided chat history and new prompt.
+// Chat sends a chat request with the provided chat history and new prompt.
 // - fn is a callback function that processes each chunk of the streaming response.
 func (e *Connector) Chat(
 	ctx context.Context,
@@ -72,19 +70,28 @@ func (e *Connector) Chat(
 	// Prepare chat messages history
 	chatHistory := workingContext.Messages
 	docs := workingContext.Docs
+	persona := workingContext.Persona
+	contextCfg := persona.Apply(e.cfg.Context)
 
 	slog.DebugContext(ctx, "Chat request", "history_length", len(chatHistory), "new_prompt", newPrompt, "docs_count", len(docs))
 
 	// Construct messages for the chat request
-	msgs := make([]ollamaApi.Message, 0, len(docs)+len(chatHistory)+2)
-	msgs = append(msgs, ollamaApi.Message{
+	msgs := make([]domain.Message, 0, len(docs)+len(chatHistory)+3)
+	msgs = append(msgs, domain.Message{
 		Role:    roleSystem,
-		Content: e.cfg.Context.BasePrompt,
+		Content: contextCfg.BasePrompt,
 	})
 
+	if workingContext.Summary != "" {
+		msgs = append(msgs, domain.Message{
+			Role:    roleSystem,
+			Content: summaryHeader + workingContext.Summary,
+		})
+	}
+
 	for _, doc := range docs {
 		if content, err := e.prepareDoc(doc); err == nil {
-			msgs = append(msgs, ollamaApi.Message{
+			msgs = append(msgs, domain.Message{
 				Role:    roleSystem,
 				Content: content, // Add context document content
 			})
@@ -94,44 +101,163 @@ func (e *Connector) Chat(
 	}
 
 	for _, m := range chatHistory {
-		msgs = append(msgs, ollamaApi.Message{
+		msgs = append(msgs, domain.Message{
 			Role:    m.Role,
 			Content: m.Content,
 		})
 	}
 
-	preparedContext := workingContext.PrepareContext(newPrompt, e.cfg.Context)
+	preparedContext := workingContext.PrepareContext(newPrompt, contextCfg)
 
 	// Add the new user prompt with prepared context (e.g., including relevant documents)
-	msgs = append(msgs, ollamaApi.Message{
+	msgs = append(msgs, domain.Message{
 		Role:    roleUser,
 		Content: preparedContext,
 	})
 
-	reqData := &ollamaApi.ChatRequest{
-		Model:    e.cfg.GenerationModel,
-		Messages: msgs,
-		Stream:   pointer.To(e.cfg.StreamAnswers),
-		Options: map[string]interface{}{
-			"temperature": e.cfg.Temperature, // Controls randomness in generation
-		},
-	}
-	err := e.client.Chat(ctx, reqData, func(resp ollamaApi.ChatResponse) error {
-		// Map ollamaApi.ChatResponse to domain.ChatResponse for every chunk
-		return fn(domain.ChatResponse{
-			Done:      resp.Done,
-			CreatedAt: resp.CreatedAt,
-			Message: &domain.Message{
-				Role:    resp.Message.Role,
-				Content: resp.Message.Content,
-			},
-		})
-	})
+	req := e.request(msgs, e.cfg.StreamAnswers)
+	req.Model = persona.GenerationModel
+	if persona.Temperature != nil {
+		req.Temperature = *persona.Temperature
+	}
+	return e.provider.Chat(ctx, req, fn)
+}
+
+// Summarize condenses messages into a short summary, extending previousSummary when it is not empty.
+func (e *Connector) Summarize(ctx context.Context, previousSummary string, messages []domain.Message) (string, error) {
+	transcript := strings.Builder{}
+	if previousSummary != "" {
+		transcript.WriteString(summaryHeader)
+		transcript.WriteString(previousSummary)
+		transcript.WriteString("\n\n")
+	}
+	for _, m := range messages {
+		transcript.WriteString(m.Role)
+		transcript.WriteString(": ")
+		transcript.WriteString(m.Content)
+		transcript.WriteString("\n")
+	}
+
+	msgs := []domain.Message{
+		{Role: roleSystem, Content: e.cfg.Context.SummaryPrompt},
+		{Role: roleUser, Content: transcript.String()},
+	}
+
+	return e.complete(ctx, msgs)
+}
+
+// GenerateTitle proposes a short session title from the first question and answer of the session.
+func (e *Connector) GenerateTitle(ctx context.Context, question, answer string) (string, error) {
+	msgs := []domain.Message{
+		{Role: roleSystem, Content: e.cfg.Context.TitlePrompt},
+		{Role: roleUser, Content: roleUser + ": " + question + "\nassistant: " + answer},
+	}
+
+	title, err := e.complete(ctx, msgs)
+	if err != nil {
+		return "", err
+	}
+	title = cleanTitle(title, e.cfg.Context.TitleMaxLength)
+	if title == "" {
+		return "", errEmptyTitle
+	}
+	return title, nil
+}
+
+// RewriteQuery condenses the history and the question into a standalone search query,
+// followed by up to variants paraphrases of it. The standalone query always comes first.
+func (e *Connector) RewriteQuery(ctx context.Context, history []domain.Message, question string, variants int) ([]string, error) {
+	transcript := strings.Builder{}
+	for _, m := range history {
+		transcript.WriteString(m.Role)
+		transcript.WriteString(": ")
+		transcript.WriteString(m.Content)
+		transcript.WriteString("\n")
+	}
+	transcript.WriteString(questionLabel)
+	transcript.WriteString(question)
+
+	prompt := e.cfg.Context.RewritePrompt
+	if variants > 0 {
+		prompt += fmt.Sprintf(variantsInstruction, variants)
+	}
+	msgs := []domain.Message{
+		{Role: roleSystem, Content: prompt},
+		{Role: roleUser, Content: transcript.String()},
+	}
+
+	answer, err := e.complete(ctx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	queries := parseQueries(answer, variants+1)
+	if len(queries) == 0 {
+		return nil, errEmptyQuery
+	}
+	return queries, nil
+}
 
+// complete sends a non-streaming request and returns the whole answer.
+func (e *Connector) complete(ctx context.Context, msgs []domain.Message) (string, error) {
+	answer := strings.Builder{}
+	err := e.provider.Chat(ctx, e.request(msgs, false), func(resp domain.ChatResponse) error {
+		if resp.Message != nil {
+			answer.WriteString(resp.Message.Content)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("error making request: %w", err)
+		return "", err
+	}
+	return strings.TrimSpace(answer.String()), nil
+}
+
+// cleanTitle keeps the first line of a generated title without surrounding quotes and punctuation,
+// cut to maxLength runes. Zero maxLength disables cutting.
+func cleanTitle(title string, maxLength int) string {
+	title, _, _ = strings.Cut(strings.TrimSpace(title), "\n")
+	title = strings.TrimSpace(strings.Trim(title, titleTrimChars))
+
+	if runes := []rune(title); maxLength > 0 && len(runes) > maxLength {
+		title = strings.TrimSpace(string(runes[:maxLength])) + "…"
+	}
+	return title
+}
+
+// parseQueries returns up to limit distinct non-empty lines of answer
+// without list markers, numbering and surrounding quotes.
+func parseQueries(answer string, limit int) []string {
+	queries := make([]string, 0, limit)
+	seen := make(map[string]struct{}, limit)
+	for _, line := range strings.Split(answer, "\n") {
+		line = strings.TrimPrefix(strings.TrimSpace(line), questionLabel)
+		line = listMarker.ReplaceAllString(line, "")
+		line = strings.TrimSpace(strings.Trim(line, queryTrimChars))
+		if line == "" {
+			continue
+		}
+
+		key := strings.ToLower(line)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		queries = append(queries, line)
+		if len(queries) == limit {
+			break
+		}
+	}
+	return queries
+}
+
+// request wraps messages with generation settings shared by all chat requests.
+func (e *Connector) request(msgs []domain.Message, stream bool) ChatRequest {
+	return ChatRequest{
+		Messages:    msgs,
+		Stream:      stream,
+		Temperature: e.cfg.Temperature,
+		MaxTokens:   e.cfg.Context.MaxTokens,
 	}
-	return nil
 }
 
 func (e *Connector) prepareDoc(doc domain.Document) (string, error) {
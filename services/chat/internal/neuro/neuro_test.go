@@ -0,0 +1,147 @@
+package neuro
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+func TestCleanTitle(t *testing.T) {
+	tests := []struct {
+		name      string
+		title     string
+		maxLength int
+		want      string
+	}{
+		{name: "plain", title: "Диалектика Гегеля", maxLength: 60, want: "Диалектика Гегеля"},
+		{name: "quotes and dot", title: " «Диалектика Гегеля». ", maxLength: 60, want: "Диалектика Гегеля"},
+		{name: "markdown", title: "**Диалектика Гегеля**", maxLength: 60, want: "Диалектика Гегеля"},
+		{name: "first line only", title: "Диалектика\nЭто название отражает вопрос", maxLength: 60, want: "Диалектика"},
+		{name: "cut to max length", title: "Категорический императив Канта", maxLength: 10, want: "Категориче…"},
+		{name: "no limit", title: "Категорический императив Канта", maxLength: 0, want: "Категорический императив Канта"},
+		{name: "only punctuation", title: `"..."`, maxLength: 60, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanTitle(tt.title, tt.maxLength); got != tt.want {
+				t.Errorf("cleanTitle(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectorChatAppliesPersona(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		var req openAIChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.Model != "kant-model" || req.Temperature != 0.7 {
+			t.Errorf("unexpected model %q or temperature %v", req.Model, req.Temperature)
+		}
+		if len(req.Messages) == 0 || req.Messages[0].Content != "Ты — Кант." {
+			t.Errorf("expected persona system prompt, got %+v", req.Messages)
+		}
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`)
+	})
+	connector := NewConnector(provider, &config.Ollama{Context: config.ContextGeneration{BasePrompt: "default"}})
+
+	temperature := float32(0.7)
+	wc := domain.WorkingContext{Persona: domain.Persona{
+		GenerationModel: "kant-model",
+		Temperature:     &temperature,
+		BasePrompt:      "Ты — Кант.",
+	}}
+	if err := connector.Chat(context.Background(), wc, "Что такое вещь в себе?", func(domain.ChatResponse) error { return nil }); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+}
+
+func TestParseQueries(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer string
+		limit  int
+		want   []string
+	}{
+		{name: "single", answer: "Что Кант писал о свободе воли?", limit: 1, want: []string{"Что Кант писал о свободе воли?"}},
+		{name: "numbered", answer: "1. Свобода воли у Канта\n2) Кант об автономии воли\n- «Кант и свобода»", limit: 3,
+			want: []string{"Свобода воли у Канта", "Кант об автономии воли", "Кант и свобода"}},
+		{name: "duplicates and blanks", answer: "Свобода воли\n\nсвобода воли\nАвтономия", limit: 3, want: []string{"Свобода воли", "Автономия"}},
+		{name: "limit", answer: "a\nb\nc", limit: 2, want: []string{"a", "b"}},
+		{name: "label", answer: "question: Кант о свободе", limit: 1, want: []string{"Кант о свободе"}},
+		{name: "number kept", answer: "1984 Оруэлла", limit: 1, want: []string{"1984 Оруэлла"}},
+		{name: "empty", answer: " \n«»", limit: 2, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseQueries(tt.answer, tt.limit)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("parseQueries(%q) = %q, want %q", tt.answer, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectorRewriteQuery(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		var req openAIChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if len(req.Messages) != 2 || !strings.Contains(req.Messages[0].Content, "2 других формулировок") {
+			t.Errorf("expected rewrite prompt asking for paraphrases, got %+v", req.Messages)
+		}
+		if !strings.HasSuffix(req.Messages[1].Content, "question: А что он писал позже?") {
+			t.Errorf("expected the question after the history, got %q", req.Messages[1].Content)
+		}
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"Поздние работы Канта о свободе\n1. Кант о свободе после 1790 года\n2. Свобода в «Метафизике нравов»"}}]}`)
+	})
+	connector := NewConnector(provider, &config.Ollama{Context: config.ContextGeneration{RewritePrompt: "Перепиши вопрос."}})
+
+	history := []domain.Message{{Role: "user", Content: "Что Кант писал о свободе?"}, {Role: "assistant", Content: "..."}}
+	queries, err := connector.RewriteQuery(context.Background(), history, "А что он писал позже?", 2)
+	if err != nil {
+		t.Fatalf("RewriteQuery() error = %v", err)
+	}
+	if len(queries) != 3 || queries[0] != "Поздние работы Канта о свободе" {
+		t.Errorf("unexpected queries %q", queries)
+	}
+}
+
+func TestParseScores(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  string
+		want    []float64
+		wantErr error
+	}{
+		{name: "plain", answer: "1: 8\n2: 3\n3: 10", want: []float64{0.8, 0.3, 1}},
+		{name: "formatting", answer: "[1] - 7,5\n**2**: 2\nФрагмент 3 — 0", want: []float64{0.75, 0.2, 0}},
+		{name: "missing and out of range", answer: "Оценки:\n2: 6\n4: 9", want: []float64{0, 0.6, 0}},
+		{name: "capped", answer: "1: 15", want: []float64{1, 0, 0}},
+		{name: "nothing rated", answer: "Все фрагменты релевантны.", wantErr: errNoScores},
+		{name: "only out of range", answer: "4: 9\n0: 5", wantErr: errNoScores},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseScores(tt.answer, 3)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseScores(%q) error = %v, want %v", tt.answer, err, tt.wantErr)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("parseScores(%q) = %v, want %v", tt.answer, got, tt.want)
+			}
+		})
+	}
+}
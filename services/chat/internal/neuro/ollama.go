@@ -0,0 +1,94 @@
+package neuro
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ollamaApi "github.com/ollama/ollama/api"
+
+	"github.com/artmexbet/raibecas/libs/utils/pointer"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// OllamaProvider talks to a native Ollama server.
+type OllamaProvider struct {
+	client *ollamaApi.Client
+	cfg    *config.Ollama
+}
+
+// NewOllamaProvider creates a provider for the Ollama API.
+// Returns an error if the address is invalid.
+func NewOllamaProvider(cfg *config.Ollama) (*OllamaProvider, error) {
+	u, err := url.Parse(cfg.GetAddress())
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ollama address: %w", err)
+	}
+
+	return &OllamaProvider{
+		client: ollamaApi.NewClient(u, &http.Client{}),
+		cfg:    cfg,
+	}, nil
+}
+
+// Embed sends a request to Ollama to generate embeddings for the given input text.
+func (p *OllamaProvider) Embed(ctx context.Context, input string) ([]float64, error) {
+	reqData := &ollamaApi.EmbeddingRequest{
+		Model:  p.cfg.EmbeddingModel,
+		Prompt: input,
+	}
+	resp, err := p.client.Embeddings(ctx, reqData)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	return resp.Embedding, nil
+}
+
+// Chat sends a chat request to Ollama and maps every response chunk to domain.ChatResponse.
+func (p *OllamaProvider) Chat(ctx context.Context, req ChatRequest, fn func(domain.ChatResponse) error) error {
+	msgs := make([]ollamaApi.Message, len(req.Messages))
+	for i, m := range req.Messages {
+		msgs[i] = ollamaApi.Message{
+			Role:    m.Role,
+			Content: m.Content,
+		}
+	}
+
+	options := map[string]interface{}{
+		"temperature": req.Temperature, // Controls randomness in generation
+	}
+	if req.MaxTokens > 0 {
+		options["num_ctx"] = req.MaxTokens // Keep the model window in line with the context budget
+	}
+
+	model := p.cfg.GenerationModel
+	if req.Model != "" {
+		model = req.Model
+	}
+
+	reqData := &ollamaApi.ChatRequest{
+		Model:    model,
+		Messages: msgs,
+		Stream:   pointer.To(req.Stream),
+		Options:  options,
+	}
+	err := p.client.Chat(ctx, reqData, func(resp ollamaApi.ChatResponse) error {
+		// Map ollamaApi.ChatResponse to domain.ChatResponse for every chunk
+		return fn(domain.ChatResponse{
+			Done:      resp.Done,
+			CreatedAt: resp.CreatedAt,
+			Message: &domain.Message{
+				Role:    resp.Message.Role,
+				Content: resp.Message.Content,
+			},
+		})
+	})
+
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package neuro
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+const (
+	sseDataPrefix = "data:"
+	sseDone       = "[DONE]"
+
+	roleAssistant = "assistant"
+
+	maxErrorBodySize = 4 << 10
+)
+
+// OpenAIProvider talks to any server implementing the OpenAI chat completions and embeddings API.
+type OpenAIProvider struct {
+	httpClient *http.Client
+	cfg        *config.OpenAI
+	baseURL    string
+}
+
+// NewOpenAIProvider creates a provider for an OpenAI-compatible API rooted at cfg.BaseURL (e.g. http://host:8000/v1).
+func NewOpenAIProvider(cfg *config.OpenAI) (*OpenAIProvider, error) {
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("openai base url is empty")
+	}
+
+	return &OpenAIProvider{
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		cfg:        cfg,
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+	}, nil
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages"`
+	Stream      bool            `json:"stream"`
+	Temperature float32         `json:"temperature"`
+}
+
+type openAIChatResponse struct {
+	Created int64 `json:"created"`
+	Choices []struct {
+		Message      *openAIMessage `json:"message"`
+		Delta        *openAIMessage `json:"delta"`
+		FinishReason *string        `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+type openAIEmbeddingRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed calls POST /embeddings and returns the first embedding.
+func (p *OpenAIProvider) Embed(ctx context.Context, input string) ([]float64, error) {
+	resp, err := p.post(ctx, "/embeddings", openAIEmbeddingRequest{
+		Model: p.cfg.EmbeddingModel,
+		Input: input,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body openAIEmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("cannot decode embeddings response: %w", err)
+	}
+	if len(body.Data) == 0 {
+		return nil, fmt.Errorf("embeddings response has no data")
+	}
+	return body.Data[0].Embedding, nil
+}
+
+// Chat calls POST /chat/completions. Streaming responses are read as server-sent events.
+func (p *OpenAIProvider) Chat(ctx context.Context, req ChatRequest, fn func(domain.ChatResponse) error) error {
+	msgs := make([]openAIMessage, len(req.Messages))
+	for i, m := range req.Messages {
+		msgs[i] = openAIMessage{Role: m.Role, Content: m.Content}
+	}
+
+	model := p.cfg.GenerationModel
+	if req.Model != "" {
+		model = req.Model
+	}
+
+	resp, err := p.post(ctx, "/chat/completions", openAIChatRequest{
+		Model:       model,
+		Messages:    msgs,
+		Stream:      req.Stream,
+		Temperature: req.Temperature,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !req.Stream {
+		var body openAIChatResponse
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return fmt.Errorf("cannot decode chat response: %w", err)
+		}
+		content := ""
+		if len(body.Choices) > 0 && body.Choices[0].Message != nil {
+			content = body.Choices[0].Message.Content
+		}
+		return fn(chatChunk(body.Created, content, true))
+	}
+
+	return p.readStream(resp.Body, fn)
+}
+
+// readStream forwards every SSE content delta to fn and finishes with a Done chunk
+// once the answer is complete, i.e. on the [DONE] sentinel or after a finish_reason.
+// A stream that ends before that fails with errStreamTruncated, so a partial answer is never completed.
+func (p *OpenAIProvider) readStream(body io.Reader, fn func(domain.ChatResponse) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+
+	var created int64
+	finished := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, sseDataPrefix) {
+			continue // blank separators, comments and other SSE fields
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, sseDataPrefix))
+		if data == sseDone {
+			finished = true
+			break
+		}
+
+		var event openAIChatResponse
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return fmt.Errorf("cannot decode chat stream event: %w", err)
+		}
+		created = event.Created
+		if len(event.Choices) > 0 && event.Choices[0].FinishReason != nil {
+			finished = true
+		}
+		if len(event.Choices) == 0 || event.Choices[0].Delta == nil || event.Choices[0].Delta.Content == "" {
+			continue
+		}
+		if err := fn(chatChunk(created, event.Choices[0].Delta.Content, false)); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading chat stream: %w", err)
+	}
+	if !finished {
+		return errStreamTruncated
+	}
+
+	return fn(chatChunk(created, "", true))
+}
+
+func (p *OpenAIProvider) post(ctx context.Context, path string, payload any) (*http.Response, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.cfg.APIKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return nil, fmt.Errorf("unexpected status %d from %s: %s", resp.StatusCode, path, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+func chatChunk(created int64, content string, done bool) domain.ChatResponse {
+	createdAt := time.Now()
+	if created > 0 {
+		createdAt = time.Unix(created, 0)
+	}
+	return domain.ChatResponse{
+		Done:      done,
+		CreatedAt: createdAt,
+		Message: &domain.Message{
+			Role:    roleAssistant,
+			Content: content,
+		},
+	}
+}
@@ -0,0 +1,161 @@
+package neuro
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// newTestProvider starts an OpenAI-compatible stand-in and returns a provider pointed at it.
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *OpenAIProvider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider, err := NewOpenAIProvider(&config.OpenAI{
+		BaseURL:         server.URL + "/v1/",
+		APIKey:          "secret",
+		EmbeddingModel:  "embed-model",
+		GenerationModel: "chat-model",
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+	return provider
+}
+
+func TestOpenAIProviderEmbed(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected authorization header %q", got)
+		}
+		var req openAIEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.Model != "embed-model" || req.Input != "hello" {
+			t.Errorf("unexpected request %+v", req)
+		}
+		fmt.Fprint(w, `{"data":[{"embedding":[0.1,0.2,0.3]}]}`)
+	})
+
+	vec, err := provider.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(vec) != 3 || vec[2] != 0.3 {
+		t.Errorf("unexpected embedding %v", vec)
+	}
+}
+
+func TestOpenAIProviderChatStream(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var req openAIChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if !req.Stream || req.Model != "chat-model" || len(req.Messages) != 2 {
+			t.Errorf("unexpected request %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, `data: {"created":1700000000,"choices":[{"delta":{"role":"assistant"}}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"created":1700000000,"choices":[{"delta":{"content":"При"}}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"created":1700000000,"choices":[{"delta":{"content":"вет"},"finish_reason":"stop"}]}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	var chunks []domain.ChatResponse
+	err := provider.Chat(context.Background(), ChatRequest{
+		Messages: []domain.Message{{Role: "system", Content: "be brief"}, {Role: "user", Content: "hi"}},
+		Stream:   true,
+	}, func(resp domain.ChatResponse) error {
+		chunks = append(chunks, resp)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(chunks))
+	}
+	if chunks[0].Message.Content+chunks[1].Message.Content != "Привет" {
+		t.Errorf("unexpected content %q%q", chunks[0].Message.Content, chunks[1].Message.Content)
+	}
+	if chunks[0].Done || chunks[1].Done || !chunks[2].Done {
+		t.Error("expected only the last chunk to be done")
+	}
+	if chunks[2].CreatedAt.Unix() != 1700000000 {
+		t.Errorf("unexpected created_at %v", chunks[2].CreatedAt)
+	}
+}
+
+func TestOpenAIProviderChatNonStream(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"created":1700000000,"choices":[{"message":{"role":"assistant","content":"Готово"},"finish_reason":"stop"}]}`)
+	})
+
+	var chunks []domain.ChatResponse
+	err := provider.Chat(context.Background(), ChatRequest{}, func(resp domain.ChatResponse) error {
+		chunks = append(chunks, resp)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if len(chunks) != 1 || !chunks[0].Done || chunks[0].Message.Content != "Готово" {
+		t.Errorf("unexpected chunks %+v", chunks)
+	}
+}
+
+func TestOpenAIProviderErrorStatus(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":{"message":"model not found"}}`, http.StatusNotFound)
+	})
+
+	err := provider.Chat(context.Background(), ChatRequest{Stream: true}, func(domain.ChatResponse) error {
+		t.Error("callback must not be called on error")
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "model not found") {
+		t.Errorf("expected error with server message, got %v", err)
+	}
+}
+
+func TestOpenAIProviderChatStreamClosedEarly(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"created":1700000000,"choices":[{"delta":{"content":"Начало ответа"}}]}`+"\n\n")
+		// The upstream dies without finish_reason or [DONE].
+	})
+
+	var chunks []domain.ChatResponse
+	err := provider.Chat(context.Background(), ChatRequest{Stream: true}, func(resp domain.ChatResponse) error {
+		chunks = append(chunks, resp)
+		return nil
+	})
+	if !errors.Is(err, errStreamTruncated) {
+		t.Fatalf("expected errStreamTruncated, got %v", err)
+	}
+	for _, chunk := range chunks {
+		if chunk.Done {
+			t.Errorf("a truncated answer must not be completed, got %+v", chunk)
+		}
+	}
+}
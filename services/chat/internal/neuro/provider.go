@@ -0,0 +1,40 @@
+package neuro
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// Provider is a language model backend able to embed text and generate chat completions.
+type Provider interface {
+	// Embed returns the embedding vector of input.
+	Embed(ctx context.Context, input string) ([]float64, error)
+	// Chat generates a completion for req.Messages and passes it to fn.
+	// When req.Stream is set fn receives every chunk, otherwise a single final chunk.
+	// The last chunk always has Done set.
+	Chat(ctx context.Context, req ChatRequest, fn func(domain.ChatResponse) error) error
+}
+
+// ChatRequest is a provider-agnostic chat completion request.
+type ChatRequest struct {
+	Messages    []domain.Message
+	Model       string // Generation model. Empty selects the model configured for the provider.
+	Stream      bool
+	Temperature float32
+	MaxTokens   int // Context window size hint. Zero leaves the provider default.
+}
+
+// NewProvider creates the provider selected by cfg.LLM.Provider.
+func NewProvider(cfg *config.Config) (Provider, error) {
+	switch cfg.LLM.Provider {
+	case config.ProviderOllama, "":
+		return NewOllamaProvider(&cfg.Ollama)
+	case config.ProviderOpenAI:
+		return NewOpenAIProvider(&cfg.LLM.OpenAI)
+	default:
+		return nil, fmt.Errorf("unknown LLM provider %q", cfg.LLM.Provider)
+	}
+}
@@ -0,0 +1,88 @@
+package neuro
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+const (
+	// passageMaxRunes bounds every passage in the scoring prompt so that all candidates fit the context window.
+	passageMaxRunes = 1000
+	maxPassageScore = 10
+)
+
+// scoreLine matches "number: score" lines of the scoring answer whatever the markup around the numbers.
+var scoreLine = regexp.MustCompile(`^\D*?(\d+)\D+?(\d+(?:[.,]\d+)?)`)
+
+// Scorer rates retrieved chunks with the generation model, asking for all ratings in a single prompt.
+type Scorer struct {
+	connector *Connector
+	cfg       *config.Rerank
+}
+
+// NewScorer creates a Scorer sending the configured prompt through connector.
+func NewScorer(connector *Connector, cfg *config.Rerank) *Scorer {
+	return &Scorer{
+		connector: connector,
+		cfg:       cfg,
+	}
+}
+
+// ScoreDocuments returns a relevance score in [0, 1] for every document.
+// Documents the model did not rate score zero; an answer rating none of them is an error.
+func (s *Scorer) ScoreDocuments(ctx context.Context, query string, docs []domain.Document) ([]float64, error) {
+	passages := strings.Builder{}
+	for i, doc := range docs {
+		content, _ := doc.Metadata[contextContentKey].(string)
+		if runes := []rune(content); len(runes) > passageMaxRunes {
+			content = string(runes[:passageMaxRunes]) + "…"
+		}
+		fmt.Fprintf(&passages, "[%d]\n%s\n\n", i+1, content)
+	}
+	passages.WriteString(questionLabel)
+	passages.WriteString(query)
+
+	msgs := []domain.Message{
+		{Role: roleSystem, Content: s.cfg.Prompt},
+		{Role: roleUser, Content: passages.String()},
+	}
+	answer, err := s.connector.complete(ctx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	return parseScores(answer, len(docs))
+}
+
+// parseScores reads "number: score" lines into count scores scaled to [0, 1].
+// Numbers are 1-based; out of range numbers and unparsable lines are ignored.
+// Returns errNoScores when no line could be read, so that a malformed answer does not rate everything zero.
+func parseScores(answer string, count int) ([]float64, error) {
+	scores := make([]float64, count)
+	parsed := false
+	for _, line := range strings.Split(answer, "\n") {
+		m := scoreLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		n, err := strconv.Atoi(m[1])
+		if err != nil || n < 1 || n > count {
+			continue
+		}
+		score, err := strconv.ParseFloat(strings.Replace(m[2], ",", ".", 1), 64)
+		if err != nil {
+			continue
+		}
+		scores[n-1] = min(score, maxPassageScore) / maxPassageScore
+		parsed = true
+	}
+	if !parsed {
+		return nil, errNoScores
+	}
+	return scores, nil
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+	"github.com/artmexbet/raibecas/services/chat/internal/postgres/queries"
+)
+
+// ListCorpora returns all registered corpora ordered by name.
+func (s *Store) ListCorpora(ctx context.Context) ([]domain.Corpus, error) {
+	rows, err := s.q().ListCorpora(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListCorpora: %w", err)
+	}
+
+	corpora := make([]domain.Corpus, len(rows))
+	for i, row := range rows {
+		corpora[i] = toDomainCorpus(row)
+	}
+	return corpora, nil
+}
+
+// GetCorpus returns the corpus with the given ID.
+func (s *Store) GetCorpus(ctx context.Context, id string) (domain.Corpus, error) {
+	row, err := s.q().GetCorpus(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Corpus{}, domain.ErrCorpusNotFound
+		}
+		return domain.Corpus{}, fmt.Errorf("GetCorpus: %w", err)
+	}
+	return toDomainCorpus(row), nil
+}
+
+// CreateCorpus registers a new corpus. Returns domain.ErrCorpusExists if the ID is taken.
+func (s *Store) CreateCorpus(ctx context.Context, corpus domain.Corpus) (domain.Corpus, error) {
+	row, err := s.q().InsertCorpus(ctx, queries.InsertCorpusParams{
+		ID:              corpus.ID,
+		Name:            corpus.Name,
+		Description:     corpus.Description,
+		CollectionName:  corpus.CollectionName,
+		GenerationModel: corpus.Persona.GenerationModel,
+		Temperature:     corpus.Persona.Temperature,
+		BasePrompt:      corpus.Persona.BasePrompt,
+		ContextPrompt:   corpus.Persona.ContextPrompt,
+		QueryPrompt:     corpus.Persona.QueryPrompt,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.Corpus{}, domain.ErrCorpusExists
+		}
+		return domain.Corpus{}, fmt.Errorf("CreateCorpus: %w", err)
+	}
+	return toDomainCorpus(row), nil
+}
+
+// UpdateCorpus replaces all settings of an existing corpus.
+func (s *Store) UpdateCorpus(ctx context.Context, corpus domain.Corpus) (domain.Corpus, error) {
+	row, err := s.q().UpdateCorpus(ctx, queries.UpdateCorpusParams{
+		ID:              corpus.ID,
+		Name:            corpus.Name,
+		Description:     corpus.Description,
+		CollectionName:  corpus.CollectionName,
+		GenerationModel: corpus.Persona.GenerationModel,
+		Temperature:     corpus.Persona.Temperature,
+		BasePrompt:      corpus.Persona.BasePrompt,
+		ContextPrompt:   corpus.Persona.ContextPrompt,
+		QueryPrompt:     corpus.Persona.QueryPrompt,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Corpus{}, domain.ErrCorpusNotFound
+		}
+		return domain.Corpus{}, fmt.Errorf("UpdateCorpus: %w", err)
+	}
+	return toDomainCorpus(row), nil
+}
+
+// DeleteCorpus removes a corpus from the registry. The vector collection is left untouched.
+func (s *Store) DeleteCorpus(ctx context.Context, id string) error {
+	deleted, err := s.q().DeleteCorpus(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DeleteCorpus: %w", err)
+	}
+	if deleted == 0 {
+		return domain.ErrCorpusNotFound
+	}
+	return nil
+}
+
+func toDomainCorpus(row queries.Corpus) domain.Corpus {
+	return domain.Corpus{
+		ID:             row.ID,
+		Name:           row.Name,
+		Description:    row.Description,
+		CollectionName: row.CollectionName,
+		Persona: domain.Persona{
+			GenerationModel: row.GenerationModel,
+			Temperature:     row.Temperature,
+			BasePrompt:      row.BasePrompt,
+			ContextPrompt:   row.ContextPrompt,
+			QueryPrompt:     row.QueryPrompt,
+		},
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
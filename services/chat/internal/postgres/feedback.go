@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+	"github.com/artmexbet/raibecas/services/chat/internal/postgres/queries"
+)
+
+// SetMessageFeedback stores the user's rating of a message, replacing the previous one.
+// FeedbackNone removes the feedback.
+func (s *Store) SetMessageFeedback(ctx context.Context, userID, sessionID, messageID string, feedback domain.Feedback) error {
+	resolvedSessionID, parsedMessageID, err := s.resolveMessage(ctx, userID, sessionID, messageID)
+	if err != nil {
+		return err
+	}
+
+	params := queries.SetMessageFeedbackParams{
+		ID:        parsedMessageID,
+		SessionID: resolvedSessionID,
+	}
+	if feedback.Rating != domain.FeedbackNone {
+		rating := int16(feedback.Rating)
+		params.Rating = &rating
+		params.Comment = feedback.Comment
+	}
+	if err := s.q().SetMessageFeedback(ctx, params); err != nil {
+		return fmt.Errorf("SetMessageFeedback: %w", err)
+	}
+	return nil
+}
+
+// ListFeedback returns rated answers matching filter in the order they were rated.
+func (s *Store) ListFeedback(ctx context.Context, filter domain.FeedbackFilter) ([]domain.FeedbackRecord, error) {
+	rows, err := s.q().ListFeedback(ctx, queries.ListFeedbackParams{
+		Since:  pgtype.Timestamptz{Time: filter.Since, Valid: true},
+		Rating: int16(filter.Rating),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListFeedback: %w", err)
+	}
+
+	records := make([]domain.FeedbackRecord, len(rows))
+	for i, row := range rows {
+		var citations []domain.Citation
+		if len(row.Citations) > 0 {
+			if err := json.Unmarshal(row.Citations, &citations); err != nil {
+				return nil, fmt.Errorf("decode citations: %w", err)
+			}
+		}
+		records[i] = domain.FeedbackRecord{
+			MessageID: row.ID.String(),
+			SessionID: row.SessionID.String(),
+			Question:  row.Question,
+			Answer:    row.Answer,
+			Rating:    int(row.Rating),
+			Comment:   row.FeedbackComment,
+			RatedAt:   row.RatedAt.Time,
+		}
+		records[i].ChunkIDs, records[i].DocumentIDs = domain.ContextIDs(citations)
+	}
+	return records, nil
+}
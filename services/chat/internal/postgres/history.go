@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -57,7 +58,7 @@ func (s *Store) resolveSessionID(ctx context.Context, userID, sessionID string,
 
 	createdSessionID, err := s.q().InsertSession(ctx, queries.InsertSessionParams{
 		UserID: userID,
-		Title:  "Новый чат",
+		Title:  domain.DefaultSessionTitle,
 	})
 	if err != nil {
 		return uuid.UUID{}, fmt.Errorf("resolveSessionID insert: %w", err)
@@ -71,7 +72,7 @@ func (s *Store) ensureSession(ctx context.Context, userID string) (uuid.UUID, er
 	return s.resolveSessionID(ctx, userID, "", true)
 }
 
-// RetrieveChatHistory returns all messages from the latest session for userID.
+// RetrieveChatHistory returns the messages of the active branch of the session (the latest one if sessionID is empty).
 func (s *Store) RetrieveChatHistory(ctx context.Context, userID, sessionID string) ([]domain.Message, error) {
 	resolvedSessionID, err := s.resolveSessionID(ctx, userID, sessionID, false)
 	if err != nil {
@@ -81,42 +82,221 @@ func (s *Store) RetrieveChatHistory(ctx context.Context, userID, sessionID strin
 		return nil, fmt.Errorf("RetrieveChatHistory session: %w", err)
 	}
 
-	rows, err := s.q().GetSessionMessages(ctx, resolvedSessionID)
+	rows, err := s.q().GetActiveBranchMessages(ctx, resolvedSessionID)
 	if err != nil {
 		return nil, fmt.Errorf("RetrieveChatHistory messages: %w", err)
 	}
 
-	msgs := make([]domain.Message, len(rows))
+	return toDomainMessages(rows)
+}
+
+// RetrieveBranch returns the messages of the branch ending at messageID without activating it.
+// An empty messageID is the empty branch before the first message.
+func (s *Store) RetrieveBranch(ctx context.Context, userID, sessionID, messageID string) ([]domain.Message, error) {
+	if messageID == "" {
+		return []domain.Message{}, nil
+	}
+
+	resolvedSessionID, parsedMessageID, err := s.resolveMessage(ctx, userID, sessionID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.q().GetBranchMessages(ctx, queries.GetBranchMessagesParams{
+		ID:        parsedMessageID,
+		SessionID: resolvedSessionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveBranch: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, domain.ErrMessageNotFound
+	}
+
+	branch := make([]queries.GetActiveBranchMessagesRow, len(rows))
 	for i, r := range rows {
-		msgs[i] = domain.Message{Role: r.Role, Content: r.Content}
+		branch[i] = queries.GetActiveBranchMessagesRow(r)
 	}
-	return msgs, nil
+	return toDomainMessages(branch)
+}
+
+// ResolveSession returns the ID of the session of userID, or of the latest one when sessionID is empty.
+// The ID is empty when the user has no session yet.
+func (s *Store) ResolveSession(ctx context.Context, userID, sessionID string) (string, error) {
+	resolvedSessionID, err := s.resolveSessionID(ctx, userID, sessionID, false)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return resolvedSessionID.String(), nil
 }
 
-// SaveMessage saves a single message to the latest (or newly created) session.
-func (s *Store) SaveMessage(ctx context.Context, userID, sessionID string, message domain.Message) error {
+// SaveMessage stores a message of the latest (or newly created) session as a child of message.ParentID,
+// a new root when it is empty, makes it the leaf of the active branch and returns its ID.
+func (s *Store) SaveMessage(ctx context.Context, userID, sessionID string, message domain.Message) (string, error) {
+	var parentID *uuid.UUID
+	if message.ParentID != "" {
+		parsedParentID, err := uuid.Parse(message.ParentID)
+		if err != nil {
+			return "", domain.ErrInvalidMessageID
+		}
+		parentID = &parsedParentID
+	}
+
 	resolvedSessionID, err := s.resolveSessionID(ctx, userID, sessionID, true)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	citations, err := marshalCitations(message.Citations)
+	if err != nil {
+		return "", fmt.Errorf("SaveMessage citations: %w", err)
+	}
+
+	// The message and the branch pointer change together: a failed activation must not leave a stored message off the active branch.
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", fmt.Errorf("SaveMessage begin: %w", err)
 	}
+	defer tx.Rollback(ctx) //nolint:errcheck // safe to ignore
 
-	if err := s.q().InsertMessage(ctx, queries.InsertMessageParams{
+	q := s.q().WithTx(tx)
+	id, err := q.InsertMessage(ctx, queries.InsertMessageParams{
 		SessionID: resolvedSessionID,
+		ParentID:  parentID,
 		Role:      message.Role,
 		Content:   message.Content,
+		Citations: citations,
+		Truncated: message.Truncated,
+	})
+	if err != nil {
+		return "", fmt.Errorf("SaveMessage insert: %w", err)
+	}
+
+	// Moving the branch pointer also bumps updated_at so latest-session detection stays correct.
+	if err := q.SetActiveMessage(ctx, queries.SetActiveMessageParams{
+		ID:              resolvedSessionID,
+		ActiveMessageID: &id,
 	}); err != nil {
-		return fmt.Errorf("SaveMessage insert: %w", err)
+		return "", fmt.Errorf("SaveMessage activate: %w", err)
 	}
 
-	// Bump updated_at so latest-session detection stays correct.
-	if err := s.q().BumpSessionUpdatedAt(ctx, resolvedSessionID); err != nil {
-		return fmt.Errorf("SaveMessage bump session: %w", err)
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("SaveMessage commit: %w", err)
+	}
+	return id.String(), nil
+}
+
+// GetMessage returns a message of the session by its ID, regardless of the branch it belongs to.
+func (s *Store) GetMessage(ctx context.Context, userID, sessionID, messageID string) (domain.Message, error) {
+	resolvedSessionID, parsedMessageID, err := s.resolveMessage(ctx, userID, sessionID, messageID)
+	if err != nil {
+		return domain.Message{}, err
+	}
+
+	row, err := s.q().GetSessionMessage(ctx, queries.GetSessionMessageParams{
+		ID:        parsedMessageID,
+		SessionID: resolvedSessionID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Message{}, domain.ErrMessageNotFound
+		}
+		return domain.Message{}, fmt.Errorf("GetMessage: %w", err)
 	}
 
+	return domain.Message{
+		ID:       row.ID.String(),
+		ParentID: uuidString(row.ParentID),
+		Role:     row.Role,
+		Content:  row.Content,
+	}, nil
+}
+
+// GetLatestLeaf returns the most recently created message in the subtree of messageID,
+// i.e. the leaf of the newest branch going through it.
+func (s *Store) GetLatestLeaf(ctx context.Context, userID, sessionID, messageID string) (string, error) {
+	resolvedSessionID, parsedMessageID, err := s.resolveMessage(ctx, userID, sessionID, messageID)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := s.q().GetLatestDescendant(ctx, queries.GetLatestDescendantParams{
+		ID:        parsedMessageID,
+		SessionID: resolvedSessionID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrMessageNotFound
+		}
+		return "", fmt.Errorf("GetLatestLeaf: %w", err)
+	}
+	return id.String(), nil
+}
+
+// SetActiveMessage makes messageID the leaf of the active branch of the session.
+// An empty messageID activates an empty branch.
+func (s *Store) SetActiveMessage(ctx context.Context, userID, sessionID, messageID string) error {
+	if messageID == "" {
+		resolvedSessionID, err := s.resolveSessionID(ctx, userID, sessionID, false)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrChatSessionNotFound
+			}
+			return fmt.Errorf("SetActiveMessage session: %w", err)
+		}
+		if err := s.q().SetActiveMessage(ctx, queries.SetActiveMessageParams{ID: resolvedSessionID}); err != nil {
+			return fmt.Errorf("SetActiveMessage: %w", err)
+		}
+		return nil
+	}
+
+	resolvedSessionID, parsedMessageID, err := s.resolveMessage(ctx, userID, sessionID, messageID)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the message belongs to the session before pointing the branch at it.
+	if _, err := s.q().GetSessionMessage(ctx, queries.GetSessionMessageParams{
+		ID:        parsedMessageID,
+		SessionID: resolvedSessionID,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrMessageNotFound
+		}
+		return fmt.Errorf("SetActiveMessage message: %w", err)
+	}
+
+	if err := s.q().SetActiveMessage(ctx, queries.SetActiveMessageParams{
+		ID:              resolvedSessionID,
+		ActiveMessageID: &parsedMessageID,
+	}); err != nil {
+		return fmt.Errorf("SetActiveMessage: %w", err)
+	}
 	return nil
 }
 
-// ClearChatHistory deletes all messages from the latest session for userID.
+// resolveMessage resolves the session of userID and parses messageID.
+func (s *Store) resolveMessage(ctx context.Context, userID, sessionID, messageID string) (uuid.UUID, uuid.UUID, error) {
+	parsedMessageID, err := uuid.Parse(messageID)
+	if err != nil {
+		return uuid.UUID{}, uuid.UUID{}, domain.ErrInvalidMessageID
+	}
+
+	resolvedSessionID, err := s.resolveSessionID(ctx, userID, sessionID, false)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.UUID{}, uuid.UUID{}, domain.ErrMessageNotFound
+		}
+		return uuid.UUID{}, uuid.UUID{}, err
+	}
+	return resolvedSessionID, parsedMessageID, nil
+}
+
+// ClearChatHistory deletes all messages from the latest session for userID
+// and drops its rolled-up summary in the same transaction.
 func (s *Store) ClearChatHistory(ctx context.Context, userID string) error {
 	sessionID, err := s.q().GetLatestSession(ctx, userID)
 	if err != nil {
@@ -126,9 +306,33 @@ func (s *Store) ClearChatHistory(ctx context.Context, userID string) error {
 		return fmt.Errorf("ClearChatHistory session: %w", err)
 	}
 
-	if err := s.q().DeleteSessionMessages(ctx, sessionID); err != nil {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("ClearChatHistory begin: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // safe to ignore
+
+	if err := clearSession(ctx, s.q().WithTx(tx), sessionID); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("ClearChatHistory commit: %w", err)
+	}
+	return nil
+}
+
+// clearSession deletes the messages of the session, resets its active branch and its summary.
+// A summary left behind would still describe the deleted messages in the next prompt.
+func clearSession(ctx context.Context, q *queries.Queries, sessionID uuid.UUID) error {
+	if err := q.DeleteSessionMessages(ctx, sessionID); err != nil {
 		return fmt.Errorf("ClearChatHistory delete: %w", err)
 	}
+	if err := q.SetActiveMessage(ctx, queries.SetActiveMessageParams{ID: sessionID}); err != nil {
+		return fmt.Errorf("ClearChatHistory reset branch: %w", err)
+	}
+	if err := q.UpdateSessionSummary(ctx, queries.UpdateSessionSummaryParams{ID: sessionID}); err != nil {
+		return fmt.Errorf("ClearChatHistory reset summary: %w", err)
+	}
 	return nil
 }
 
@@ -141,37 +345,201 @@ func (s *Store) GetChatSize(ctx context.Context, userID string) (int, error) {
 	return len(history), nil
 }
 
+// GetSessionSummary returns the rolled-up summary of the session, or an empty summary if there is none yet.
+func (s *Store) GetSessionSummary(ctx context.Context, userID, sessionID string) (domain.SessionSummary, error) {
+	resolvedSessionID, err := s.resolveSessionID(ctx, userID, sessionID, false)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.SessionSummary{}, nil
+		}
+		return domain.SessionSummary{}, fmt.Errorf("GetSessionSummary session: %w", err)
+	}
+
+	row, err := s.q().GetSessionSummary(ctx, resolvedSessionID)
+	if err != nil {
+		return domain.SessionSummary{}, fmt.Errorf("GetSessionSummary: %w", err)
+	}
+	return domain.SessionSummary{
+		Content:      row.Summary,
+		MessageCount: int(row.SummaryMessageCount),
+	}, nil
+}
+
+// SaveSessionSummary replaces the rolled-up summary of the session.
+func (s *Store) SaveSessionSummary(ctx context.Context, userID, sessionID string, summary domain.SessionSummary) error {
+	resolvedSessionID, err := s.resolveSessionID(ctx, userID, sessionID, false)
+	if err != nil {
+		return fmt.Errorf("SaveSessionSummary session: %w", err)
+	}
+
+	if err := s.q().UpdateSessionSummary(ctx, queries.UpdateSessionSummaryParams{
+		ID:                  resolvedSessionID,
+		Summary:             summary.Content,
+		SummaryMessageCount: int32(summary.MessageCount),
+	}); err != nil {
+		return fmt.Errorf("SaveSessionSummary update: %w", err)
+	}
+	return nil
+}
+
 // --- Admin/History API methods ---
 
-// GetUserSessions returns all sessions with their messages for a given userID.
-func (s *Store) GetUserSessions(ctx context.Context, userID string) ([]domain.ChatSession, error) {
-	rows, err := s.q().GetUserSessions(ctx, userID)
+// GetUserSessions returns a page of the user's active or archived sessions without messages.
+func (s *Store) GetUserSessions(ctx context.Context, userID string, archived bool, page domain.Page) (domain.SessionPage, error) {
+	// One extra row tells whether there is a next page.
+	rows, err := s.q().GetUserSessions(ctx, queries.GetUserSessionsParams{
+		UserID:     userID,
+		Archived:   archived,
+		PageLimit:  int32(page.Limit + 1),
+		PageOffset: int32(page.Offset),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("GetUserSessions: %w", err)
+		return domain.SessionPage{}, fmt.Errorf("GetUserSessions: %w", err)
 	}
 
-	sessions := make([]domain.ChatSession, 0, len(rows))
+	result := domain.SessionPage{Sessions: make([]domain.ChatSession, 0, len(rows))}
+	if len(rows) > page.Limit {
+		result.HasMore = true
+		rows = rows[:page.Limit]
+	}
 	for _, row := range rows {
-		msgs, err := s.getSessionMessages(ctx, row.ID)
-		if err != nil {
-			return nil, err
+		result.Sessions = append(result.Sessions, toDomainSession(row))
+	}
+	return result, nil
+}
+
+// GetSessionMessages returns a page of the active branch of the session, counted from its newest message.
+func (s *Store) GetSessionMessages(ctx context.Context, userID, sessionID string, page domain.Page) (domain.MessagePage, error) {
+	ownedSessionID, err := s.getOwnedSessionID(ctx, userID, sessionID)
+	if err != nil {
+		return domain.MessagePage{}, err
+	}
+
+	rows, err := s.q().GetActiveBranchMessagesPage(ctx, queries.GetActiveBranchMessagesPageParams{
+		ID:     ownedSessionID,
+		Limit:  int32(page.Limit + 1),
+		Offset: int32(page.Offset),
+	})
+	if err != nil {
+		return domain.MessagePage{}, fmt.Errorf("GetSessionMessages: %w", err)
+	}
+
+	var result domain.MessagePage
+	if len(rows) > page.Limit {
+		result.HasMore = true
+		rows = rows[:page.Limit]
+	}
+	// Rows come newest first.
+	branch := make([]queries.GetActiveBranchMessagesRow, len(rows))
+	for i, r := range rows {
+		branch[len(rows)-1-i] = queries.GetActiveBranchMessagesRow(r)
+	}
+	result.Messages, err = toDomainMessages(branch)
+	if err != nil {
+		return domain.MessagePage{}, err
+	}
+	return result, nil
+}
+
+// UpdateSession renames, archives or restores a session of userID.
+func (s *Store) UpdateSession(ctx context.Context, userID, sessionID string, update domain.SessionUpdate) (domain.ChatSession, error) {
+	parsedSessionID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return domain.ChatSession{}, domain.ErrInvalidChatSessionID
+	}
+
+	row, err := s.q().UpdateSession(ctx, queries.UpdateSessionParams{
+		Title:    update.Title,
+		Archived: update.Archived,
+		ID:       parsedSessionID,
+		UserID:   userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ChatSession{}, domain.ErrChatSessionNotFound
+		}
+		return domain.ChatSession{}, fmt.Errorf("UpdateSession: %w", err)
+	}
+	return toDomainSession(row), nil
+}
+
+// SetGeneratedTitle sets a generated title of the session (the latest one if sessionID is empty)
+// unless the session has already been renamed. Reports whether the title was changed.
+func (s *Store) SetGeneratedTitle(ctx context.Context, userID, sessionID, title string) (domain.ChatSession, bool, error) {
+	resolvedSessionID, err := s.resolveSessionID(ctx, userID, sessionID, false)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ChatSession{}, false, domain.ErrChatSessionNotFound
+		}
+		return domain.ChatSession{}, false, err
+	}
+
+	row, err := s.q().SetGeneratedSessionTitle(ctx, queries.SetGeneratedSessionTitleParams{
+		Title:        title,
+		ID:           resolvedSessionID,
+		UserID:       userID,
+		DefaultTitle: domain.DefaultSessionTitle,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ChatSession{}, false, nil
+		}
+		return domain.ChatSession{}, false, fmt.Errorf("SetGeneratedTitle: %w", err)
+	}
+	return toDomainSession(row), true, nil
+}
+
+// DeleteSession deletes a session of userID together with its messages.
+func (s *Store) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	parsedSessionID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return domain.ErrInvalidChatSessionID
+	}
+
+	deleted, err := s.q().DeleteSession(ctx, queries.DeleteSessionParams{
+		ID:     parsedSessionID,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteSession: %w", err)
+	}
+	if deleted == 0 {
+		return domain.ErrChatSessionNotFound
+	}
+	return nil
+}
+
+// SearchMessages runs a full-text search over all messages of userID, best matches first.
+func (s *Store) SearchMessages(ctx context.Context, userID, query string, page domain.Page) ([]domain.MessageSearchHit, error) {
+	rows, err := s.q().SearchUserMessages(ctx, queries.SearchUserMessagesParams{
+		Query:      query,
+		UserID:     userID,
+		PageLimit:  int32(page.Limit),
+		PageOffset: int32(page.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SearchMessages: %w", err)
+	}
+
+	hits := make([]domain.MessageSearchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = domain.MessageSearchHit{
+			MessageID:    row.ID.String(),
+			SessionID:    row.SessionID.String(),
+			SessionTitle: row.SessionTitle,
+			Role:         row.Role,
+			Snippet:      row.Snippet,
+			Rank:         row.Rank,
+			CreatedAt:    row.CreatedAt.Time,
 		}
-		sessions = append(sessions, domain.ChatSession{
-			ID:        row.ID.String(),
-			UserID:    row.UserID,
-			Title:     row.Title,
-			CreatedAt: row.CreatedAt.Time.String(),
-			UpdatedAt: row.UpdatedAt.Time.String(),
-			Messages:  msgs,
-		})
 	}
-	return sessions, nil
+	return hits, nil
 }
 
 // CreateSession creates a new chat session for userID and returns its ID.
 func (s *Store) CreateSession(ctx context.Context, userID, title string) (string, error) {
 	if title == "" {
-		title = "Новый чат"
+		title = domain.DefaultSessionTitle
 	}
 	id, err := s.q().InsertSession(ctx, queries.InsertSessionParams{
 		UserID: userID,
@@ -183,16 +551,57 @@ func (s *Store) CreateSession(ctx context.Context, userID, title string) (string
 	return id.String(), nil
 }
 
-// getSessionMessages fetches all messages for a session by its UUID.
-func (s *Store) getSessionMessages(ctx context.Context, sessionID uuid.UUID) ([]domain.Message, error) {
-	rows, err := s.q().GetSessionMessages(ctx, sessionID)
-	if err != nil {
-		return nil, fmt.Errorf("getSessionMessages: %w", err)
+// toDomainSession converts a session row into a domain session without messages.
+func toDomainSession(row queries.ChatSession) domain.ChatSession {
+	return domain.ChatSession{
+		ID:        row.ID.String(),
+		UserID:    row.UserID,
+		Title:     row.Title,
+		CreatedAt: row.CreatedAt.Time.String(),
+		UpdatedAt: row.UpdatedAt.Time.String(),
+		Archived:  row.ArchivedAt.Valid,
 	}
+}
 
+// toDomainMessages converts message rows into domain messages, decoding stored citations.
+func toDomainMessages(rows []queries.GetActiveBranchMessagesRow) ([]domain.Message, error) {
 	msgs := make([]domain.Message, len(rows))
 	for i, r := range rows {
-		msgs[i] = domain.Message{Role: r.Role, Content: r.Content}
+		msgs[i] = domain.Message{
+			ID:        r.ID.String(),
+			ParentID:  uuidString(r.ParentID),
+			Role:      r.Role,
+			Content:   r.Content,
+			Truncated: r.Truncated,
+		}
+		if len(r.SiblingIds) > 1 {
+			msgs[i].SiblingIDs = r.SiblingIds
+		}
+		if r.FeedbackRating != nil {
+			msgs[i].Feedback = &domain.Feedback{Rating: int(*r.FeedbackRating), Comment: r.FeedbackComment}
+		}
+		if len(r.Citations) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(r.Citations, &msgs[i].Citations); err != nil {
+			return nil, fmt.Errorf("decode citations: %w", err)
+		}
 	}
 	return msgs, nil
 }
+
+// uuidString formats a nullable UUID, returning an empty string for NULL.
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// marshalCitations encodes citations for the JSONB column, storing an empty array instead of null.
+func marshalCitations(citations []domain.Citation) ([]byte, error) {
+	if citations == nil {
+		citations = []domain.Citation{}
+	}
+	return json.Marshal(citations)
+}
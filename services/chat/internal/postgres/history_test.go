@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/postgres/queries"
+)
+
+type execCall struct {
+	sql  string
+	args []interface{}
+}
+
+// recordingDB records the statements executed through it.
+type recordingDB struct {
+	execs []execCall
+}
+
+func (d *recordingDB) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	d.execs = append(d.execs, execCall{sql: sql, args: args})
+	return pgconn.CommandTag{}, nil
+}
+
+func (d *recordingDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	panic("unexpected Query")
+}
+
+func (d *recordingDB) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	panic("unexpected QueryRow")
+}
+
+func TestClearSessionResetsSummary(t *testing.T) {
+	t.Parallel()
+
+	db := &recordingDB{}
+	sessionID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+	if err := clearSession(context.Background(), queries.New(db), sessionID); err != nil {
+		t.Fatalf("clearSession: %v", err)
+	}
+
+	for _, call := range db.execs {
+		if !strings.Contains(call.sql, "SET summary = $2, summary_message_count = $3") {
+			continue
+		}
+		if len(call.args) != 3 || call.args[0] != sessionID || call.args[1] != "" || call.args[2] != int32(0) {
+			t.Fatalf("expected the summary of %s to be reset, got args %v", sessionID, call.args)
+		}
+		return
+	}
+	t.Fatalf("expected the summary to be reset, executed %d statements", len(db.execs))
+}
@@ -10,17 +10,51 @@ import (
 )
 
 type ChatMessage struct {
-	ID        uuid.UUID
-	SessionID uuid.UUID
-	Role      string
-	Content   string
-	CreatedAt pgtype.Timestamptz
+	ID              uuid.UUID
+	SessionID       uuid.UUID
+	Role            string
+	Content         string
+	CreatedAt       pgtype.Timestamptz
+	Citations       []byte
+	Truncated       bool
+	ParentID        *uuid.UUID
+	SearchVector    interface{}
+	FeedbackRating  *int16
+	FeedbackComment string
+	FeedbackAt      pgtype.Timestamptz
 }
 
 type ChatSession struct {
-	ID        uuid.UUID
+	ID                  uuid.UUID
+	UserID              string
+	Title               string
+	CreatedAt           pgtype.Timestamptz
+	UpdatedAt           pgtype.Timestamptz
+	Summary             string
+	SummaryMessageCount int32
+	ActiveMessageID     *uuid.UUID
+	ArchivedAt          pgtype.Timestamptz
+}
+
+type ChatSessionShare struct {
+	Token     string
+	SessionID uuid.UUID
 	UserID    string
 	Title     string
+	Messages  []byte
 	CreatedAt pgtype.Timestamptz
-	UpdatedAt pgtype.Timestamptz
+}
+
+type Corpus struct {
+	ID              string
+	Name            string
+	Description     string
+	CollectionName  string
+	GenerationModel string
+	Temperature     *float32
+	BasePrompt      string
+	ContextPrompt   string
+	QueryPrompt     string
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
 }
@@ -1,6 +1,6 @@
 -- name: GetLatestSession :one
 SELECT id FROM chat_sessions
-WHERE user_id = $1
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY updated_at DESC
 LIMIT 1;
 
@@ -10,23 +10,93 @@ VALUES ($1, $2)
 RETURNING id;
 
 -- name: GetSessionByIDForUser :one
-SELECT id, user_id, title, created_at, updated_at
+SELECT id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at
 FROM chat_sessions
 WHERE id = $1 AND user_id = $2
 LIMIT 1;
 
--- name: GetSessionMessages :many
-SELECT role, content FROM chat_messages
-WHERE session_id = $1
-ORDER BY created_at ASC;
+-- name: GetActiveBranchMessages :many
+WITH RECURSIVE branch AS (
+    SELECT m.id, m.parent_id, 1 AS depth
+    FROM chat_messages m
+    JOIN chat_sessions s ON s.active_message_id = m.id
+    WHERE s.id = $1
+    UNION ALL
+    SELECT m.id, m.parent_id, b.depth + 1
+    FROM chat_messages m
+    JOIN branch b ON m.id = b.parent_id
+)
+SELECT m.id, m.parent_id, m.role, m.content, m.citations, m.truncated, m.feedback_rating, m.feedback_comment,
+    (SELECT array_agg(s.id::text ORDER BY s.created_at)
+     FROM chat_messages s
+     WHERE s.session_id = m.session_id AND s.parent_id IS NOT DISTINCT FROM m.parent_id)::text[] AS sibling_ids
+FROM branch b
+JOIN chat_messages m ON m.id = b.id
+ORDER BY b.depth DESC;
+
+-- name: GetBranchMessages :many
+WITH RECURSIVE branch AS (
+    SELECT m.id, m.parent_id, 1 AS depth
+    FROM chat_messages m
+    WHERE m.id = $1 AND m.session_id = $2
+    UNION ALL
+    SELECT m.id, m.parent_id, b.depth + 1
+    FROM chat_messages m
+    JOIN branch b ON m.id = b.parent_id
+)
+SELECT m.id, m.parent_id, m.role, m.content, m.citations, m.truncated, m.feedback_rating, m.feedback_comment,
+    (SELECT array_agg(s.id::text ORDER BY s.created_at)
+     FROM chat_messages s
+     WHERE s.session_id = m.session_id AND s.parent_id IS NOT DISTINCT FROM m.parent_id)::text[] AS sibling_ids
+FROM branch b
+JOIN chat_messages m ON m.id = b.id
+ORDER BY b.depth DESC;
+
+-- name: GetActiveBranchMessagesPage :many
+WITH RECURSIVE branch AS (
+    SELECT m.id, m.parent_id, 1 AS depth
+    FROM chat_messages m
+    JOIN chat_sessions s ON s.active_message_id = m.id
+    WHERE s.id = $1
+    UNION ALL
+    SELECT m.id, m.parent_id, b.depth + 1
+    FROM chat_messages m
+    JOIN branch b ON m.id = b.parent_id
+)
+SELECT m.id, m.parent_id, m.role, m.content, m.citations, m.truncated, m.feedback_rating, m.feedback_comment,
+    (SELECT array_agg(s.id::text ORDER BY s.created_at)
+     FROM chat_messages s
+     WHERE s.session_id = m.session_id AND s.parent_id IS NOT DISTINCT FROM m.parent_id)::text[] AS sibling_ids
+FROM branch b
+JOIN chat_messages m ON m.id = b.id
+ORDER BY b.depth ASC
+LIMIT $2 OFFSET $3;
+
+-- name: GetSessionMessage :one
+SELECT id, parent_id, role, content FROM chat_messages
+WHERE id = $1 AND session_id = $2;
 
--- name: InsertMessage :exec
-INSERT INTO chat_messages (session_id, role, content)
-VALUES ($1, $2, $3);
+-- name: GetLatestDescendant :one
+WITH RECURSIVE subtree AS (
+    SELECT id, created_at FROM chat_messages
+    WHERE chat_messages.id = $1 AND session_id = $2
+    UNION ALL
+    SELECT m.id, m.created_at
+    FROM chat_messages m
+    JOIN subtree t ON m.parent_id = t.id
+)
+SELECT id FROM subtree
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: InsertMessage :one
+INSERT INTO chat_messages (session_id, parent_id, role, content, citations, truncated)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id;
 
--- name: BumpSessionUpdatedAt :exec
+-- name: SetActiveMessage :exec
 UPDATE chat_sessions
-SET updated_at = NOW()
+SET active_message_id = $2, updated_at = NOW()
 WHERE id = $1;
 
 -- name: DeleteSessionMessages :exec
@@ -34,8 +104,121 @@ DELETE FROM chat_messages
 WHERE session_id = $1;
 
 -- name: GetUserSessions :many
-SELECT id, user_id, title, created_at, updated_at
+SELECT id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at
 FROM chat_sessions
-WHERE user_id = $1
-ORDER BY updated_at DESC;
+WHERE user_id = sqlc.arg(user_id) AND (archived_at IS NOT NULL) = sqlc.arg(archived)::boolean
+ORDER BY updated_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: UpdateSession :one
+UPDATE chat_sessions
+SET title = COALESCE(sqlc.narg(title), title),
+    archived_at = CASE
+        WHEN sqlc.narg(archived)::boolean IS NULL THEN archived_at
+        WHEN sqlc.narg(archived)::boolean THEN COALESCE(archived_at, NOW())
+        ELSE NULL
+    END
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)
+RETURNING id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at;
+
+-- name: SetGeneratedSessionTitle :one
+UPDATE chat_sessions
+SET title = sqlc.arg(title)
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id) AND title = sqlc.arg(default_title)
+RETURNING id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at;
+
+-- name: DeleteSession :execrows
+DELETE FROM chat_sessions
+WHERE id = $1 AND user_id = $2;
+
+-- name: SearchUserMessages :many
+SELECT m.id, m.session_id, s.title AS session_title, m.role,
+    ts_headline('russian', m.content, plainto_tsquery('russian', sqlc.arg(query)::text),
+        'MaxFragments=2, MaxWords=20, MinWords=5')::text AS snippet,
+    ts_rank(m.search_vector, plainto_tsquery('russian', sqlc.arg(query)::text))::real AS rank,
+    m.created_at
+FROM chat_messages m
+JOIN chat_sessions s ON s.id = m.session_id
+WHERE s.user_id = sqlc.arg(user_id)
+  AND m.search_vector @@ plainto_tsquery('russian', sqlc.arg(query)::text)
+ORDER BY rank DESC, m.created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: GetSessionSummary :one
+SELECT summary, summary_message_count FROM chat_sessions
+WHERE id = $1;
+
+-- name: UpdateSessionSummary :exec
+UPDATE chat_sessions
+SET summary = $2, summary_message_count = $3
+WHERE id = $1;
+
+-- name: ListCorpora :many
+SELECT id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at
+FROM corpora
+ORDER BY name, id;
+
+-- name: GetCorpus :one
+SELECT id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at
+FROM corpora
+WHERE id = $1;
+
+-- name: InsertCorpus :one
+INSERT INTO corpora (id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at;
+
+-- name: UpdateCorpus :one
+UPDATE corpora
+SET name = $2,
+    description = $3,
+    collection_name = $4,
+    generation_model = $5,
+    temperature = $6,
+    base_prompt = $7,
+    context_prompt = $8,
+    query_prompt = $9,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at;
+
+-- name: DeleteCorpus :execrows
+DELETE FROM corpora
+WHERE id = $1;
+
+-- name: SetMessageFeedback :exec
+UPDATE chat_messages
+SET feedback_rating = sqlc.narg(rating),
+    feedback_comment = sqlc.arg(comment),
+    feedback_at = CASE WHEN sqlc.narg(rating)::smallint IS NULL THEN NULL ELSE NOW() END
+WHERE id = sqlc.arg(id) AND session_id = sqlc.arg(session_id);
+
+-- name: ListFeedback :many
+SELECT a.id, a.session_id, COALESCE(q.content, '')::text AS question, a.content AS answer, a.citations,
+    a.feedback_rating::smallint AS rating, a.feedback_comment, a.feedback_at::timestamptz AS rated_at
+FROM chat_messages a
+LEFT JOIN chat_messages q ON q.id = a.parent_id
+WHERE a.feedback_rating IS NOT NULL
+    AND a.feedback_at >= sqlc.arg(since)::timestamptz
+    AND (sqlc.arg(rating)::smallint = 0 OR a.feedback_rating = sqlc.arg(rating)::smallint)
+ORDER BY a.feedback_at, a.id;
+
+-- name: InsertSessionShare :one
+INSERT INTO chat_session_shares (token, session_id, user_id, title, messages)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING token, session_id, user_id, title, messages, created_at;
+
+-- name: GetSessionShare :one
+SELECT token, session_id, user_id, title, messages, created_at
+FROM chat_session_shares
+WHERE token = $1;
+
+-- name: ListSessionShares :many
+SELECT token, session_id, user_id, title, created_at
+FROM chat_session_shares
+WHERE session_id = $1
+ORDER BY created_at DESC, token;
 
+-- name: DeleteSessionShare :execrows
+DELETE FROM chat_session_shares
+WHERE token = $1 AND user_id = $2;
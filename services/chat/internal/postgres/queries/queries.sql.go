@@ -9,22 +9,46 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const bumpSessionUpdatedAt = `-- name: BumpSessionUpdatedAt :exec
-UPDATE chat_sessions
-SET updated_at = NOW()
+const deleteCorpus = `-- name: DeleteCorpus :execrows
+DELETE FROM corpora
 WHERE id = $1
 `
 
-// BumpSessionUpdatedAt
+// DeleteCorpus
 //
-//	UPDATE chat_sessions
-//	SET updated_at = NOW()
+//	DELETE FROM corpora
 //	WHERE id = $1
-func (q *Queries) BumpSessionUpdatedAt(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, bumpSessionUpdatedAt, id)
-	return err
+func (q *Queries) DeleteCorpus(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCorpus, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteSession = `-- name: DeleteSession :execrows
+DELETE FROM chat_sessions
+WHERE id = $1 AND user_id = $2
+`
+
+type DeleteSessionParams struct {
+	ID     uuid.UUID
+	UserID string
+}
+
+// DeleteSession
+//
+//	DELETE FROM chat_sessions
+//	WHERE id = $1 AND user_id = $2
+func (q *Queries) DeleteSession(ctx context.Context, arg DeleteSessionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSession, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const deleteSessionMessages = `-- name: DeleteSessionMessages :exec
@@ -41,9 +65,354 @@ func (q *Queries) DeleteSessionMessages(ctx context.Context, sessionID uuid.UUID
 	return err
 }
 
+const deleteSessionShare = `-- name: DeleteSessionShare :execrows
+DELETE FROM chat_session_shares
+WHERE token = $1 AND user_id = $2
+`
+
+type DeleteSessionShareParams struct {
+	Token  string
+	UserID string
+}
+
+// DeleteSessionShare
+//
+//	DELETE FROM chat_session_shares
+//	WHERE token = $1 AND user_id = $2
+func (q *Queries) DeleteSessionShare(ctx context.Context, arg DeleteSessionShareParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSessionShare, arg.Token, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getActiveBranchMessages = `-- name: GetActiveBranchMessages :many
+WITH RECURSIVE branch AS (
+    SELECT m.id, m.parent_id, 1 AS depth
+    FROM chat_messages m
+    JOIN chat_sessions s ON s.active_message_id = m.id
+    WHERE s.id = $1
+    UNION ALL
+    SELECT m.id, m.parent_id, b.depth + 1
+    FROM chat_messages m
+    JOIN branch b ON m.id = b.parent_id
+)
+SELECT m.id, m.parent_id, m.role, m.content, m.citations, m.truncated, m.feedback_rating, m.feedback_comment,
+    (SELECT array_agg(s.id::text ORDER BY s.created_at)
+     FROM chat_messages s
+     WHERE s.session_id = m.session_id AND s.parent_id IS NOT DISTINCT FROM m.parent_id)::text[] AS sibling_ids
+FROM branch b
+JOIN chat_messages m ON m.id = b.id
+ORDER BY b.depth DESC
+`
+
+type GetActiveBranchMessagesRow struct {
+	ID              uuid.UUID
+	ParentID        *uuid.UUID
+	Role            string
+	Content         string
+	Citations       []byte
+	Truncated       bool
+	FeedbackRating  *int16
+	FeedbackComment string
+	SiblingIds      []string
+}
+
+// GetActiveBranchMessages
+//
+//	WITH RECURSIVE branch AS (
+//	    SELECT m.id, m.parent_id, 1 AS depth
+//	    FROM chat_messages m
+//	    JOIN chat_sessions s ON s.active_message_id = m.id
+//	    WHERE s.id = $1
+//	    UNION ALL
+//	    SELECT m.id, m.parent_id, b.depth + 1
+//	    FROM chat_messages m
+//	    JOIN branch b ON m.id = b.parent_id
+//	)
+//	SELECT m.id, m.parent_id, m.role, m.content, m.citations, m.truncated, m.feedback_rating, m.feedback_comment,
+//	    (SELECT array_agg(s.id::text ORDER BY s.created_at)
+//	     FROM chat_messages s
+//	     WHERE s.session_id = m.session_id AND s.parent_id IS NOT DISTINCT FROM m.parent_id)::text[] AS sibling_ids
+//	FROM branch b
+//	JOIN chat_messages m ON m.id = b.id
+//	ORDER BY b.depth DESC
+func (q *Queries) GetActiveBranchMessages(ctx context.Context, id uuid.UUID) ([]GetActiveBranchMessagesRow, error) {
+	rows, err := q.db.Query(ctx, getActiveBranchMessages, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetActiveBranchMessagesRow{}
+	for rows.Next() {
+		var i GetActiveBranchMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ParentID,
+			&i.Role,
+			&i.Content,
+			&i.Citations,
+			&i.Truncated,
+			&i.FeedbackRating,
+			&i.FeedbackComment,
+			&i.SiblingIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getActiveBranchMessagesPage = `-- name: GetActiveBranchMessagesPage :many
+WITH RECURSIVE branch AS (
+    SELECT m.id, m.parent_id, 1 AS depth
+    FROM chat_messages m
+    JOIN chat_sessions s ON s.active_message_id = m.id
+    WHERE s.id = $1
+    UNION ALL
+    SELECT m.id, m.parent_id, b.depth + 1
+    FROM chat_messages m
+    JOIN branch b ON m.id = b.parent_id
+)
+SELECT m.id, m.parent_id, m.role, m.content, m.citations, m.truncated, m.feedback_rating, m.feedback_comment,
+    (SELECT array_agg(s.id::text ORDER BY s.created_at)
+     FROM chat_messages s
+     WHERE s.session_id = m.session_id AND s.parent_id IS NOT DISTINCT FROM m.parent_id)::text[] AS sibling_ids
+FROM branch b
+JOIN chat_messages m ON m.id = b.id
+ORDER BY b.depth ASC
+LIMIT $2 OFFSET $3
+`
+
+type GetActiveBranchMessagesPageParams struct {
+	ID     uuid.UUID
+	Limit  int32
+	Offset int32
+}
+
+type GetActiveBranchMessagesPageRow struct {
+	ID              uuid.UUID
+	ParentID        *uuid.UUID
+	Role            string
+	Content         string
+	Citations       []byte
+	Truncated       bool
+	FeedbackRating  *int16
+	FeedbackComment string
+	SiblingIds      []string
+}
+
+// GetActiveBranchMessagesPage
+//
+//	WITH RECURSIVE branch AS (
+//	    SELECT m.id, m.parent_id, 1 AS depth
+//	    FROM chat_messages m
+//	    JOIN chat_sessions s ON s.active_message_id = m.id
+//	    WHERE s.id = $1
+//	    UNION ALL
+//	    SELECT m.id, m.parent_id, b.depth + 1
+//	    FROM chat_messages m
+//	    JOIN branch b ON m.id = b.parent_id
+//	)
+//	SELECT m.id, m.parent_id, m.role, m.content, m.citations, m.truncated, m.feedback_rating, m.feedback_comment,
+//	    (SELECT array_agg(s.id::text ORDER BY s.created_at)
+//	     FROM chat_messages s
+//	     WHERE s.session_id = m.session_id AND s.parent_id IS NOT DISTINCT FROM m.parent_id)::text[] AS sibling_ids
+//	FROM branch b
+//	JOIN chat_messages m ON m.id = b.id
+//	ORDER BY b.depth ASC
+//	LIMIT $2 OFFSET $3
+func (q *Queries) GetActiveBranchMessagesPage(ctx context.Context, arg GetActiveBranchMessagesPageParams) ([]GetActiveBranchMessagesPageRow, error) {
+	rows, err := q.db.Query(ctx, getActiveBranchMessagesPage, arg.ID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetActiveBranchMessagesPageRow{}
+	for rows.Next() {
+		var i GetActiveBranchMessagesPageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ParentID,
+			&i.Role,
+			&i.Content,
+			&i.Citations,
+			&i.Truncated,
+			&i.FeedbackRating,
+			&i.FeedbackComment,
+			&i.SiblingIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBranchMessages = `-- name: GetBranchMessages :many
+WITH RECURSIVE branch AS (
+    SELECT m.id, m.parent_id, 1 AS depth
+    FROM chat_messages m
+    WHERE m.id = $1 AND m.session_id = $2
+    UNION ALL
+    SELECT m.id, m.parent_id, b.depth + 1
+    FROM chat_messages m
+    JOIN branch b ON m.id = b.parent_id
+)
+SELECT m.id, m.parent_id, m.role, m.content, m.citations, m.truncated, m.feedback_rating, m.feedback_comment,
+    (SELECT array_agg(s.id::text ORDER BY s.created_at)
+     FROM chat_messages s
+     WHERE s.session_id = m.session_id AND s.parent_id IS NOT DISTINCT FROM m.parent_id)::text[] AS sibling_ids
+FROM branch b
+JOIN chat_messages m ON m.id = b.id
+ORDER BY b.depth DESC
+`
+
+type GetBranchMessagesParams struct {
+	ID        uuid.UUID
+	SessionID uuid.UUID
+}
+
+type GetBranchMessagesRow struct {
+	ID              uuid.UUID
+	ParentID        *uuid.UUID
+	Role            string
+	Content         string
+	Citations       []byte
+	Truncated       bool
+	FeedbackRating  *int16
+	FeedbackComment string
+	SiblingIds      []string
+}
+
+// GetBranchMessages
+//
+//	WITH RECURSIVE branch AS (
+//	    SELECT m.id, m.parent_id, 1 AS depth
+//	    FROM chat_messages m
+//	    WHERE m.id = $1 AND m.session_id = $2
+//	    UNION ALL
+//	    SELECT m.id, m.parent_id, b.depth + 1
+//	    FROM chat_messages m
+//	    JOIN branch b ON m.id = b.parent_id
+//	)
+//	SELECT m.id, m.parent_id, m.role, m.content, m.citations, m.truncated, m.feedback_rating, m.feedback_comment,
+//	    (SELECT array_agg(s.id::text ORDER BY s.created_at)
+//	     FROM chat_messages s
+//	     WHERE s.session_id = m.session_id AND s.parent_id IS NOT DISTINCT FROM m.parent_id)::text[] AS sibling_ids
+//	FROM branch b
+//	JOIN chat_messages m ON m.id = b.id
+//	ORDER BY b.depth DESC
+func (q *Queries) GetBranchMessages(ctx context.Context, arg GetBranchMessagesParams) ([]GetBranchMessagesRow, error) {
+	rows, err := q.db.Query(ctx, getBranchMessages, arg.ID, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBranchMessagesRow{}
+	for rows.Next() {
+		var i GetBranchMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ParentID,
+			&i.Role,
+			&i.Content,
+			&i.Citations,
+			&i.Truncated,
+			&i.FeedbackRating,
+			&i.FeedbackComment,
+			&i.SiblingIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCorpus = `-- name: GetCorpus :one
+SELECT id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at
+FROM corpora
+WHERE id = $1
+`
+
+// GetCorpus
+//
+//	SELECT id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at
+//	FROM corpora
+//	WHERE id = $1
+func (q *Queries) GetCorpus(ctx context.Context, id string) (Corpus, error) {
+	row := q.db.QueryRow(ctx, getCorpus, id)
+	var i Corpus
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CollectionName,
+		&i.GenerationModel,
+		&i.Temperature,
+		&i.BasePrompt,
+		&i.ContextPrompt,
+		&i.QueryPrompt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getLatestDescendant = `-- name: GetLatestDescendant :one
+WITH RECURSIVE subtree AS (
+    SELECT id, created_at FROM chat_messages
+    WHERE chat_messages.id = $1 AND session_id = $2
+    UNION ALL
+    SELECT m.id, m.created_at
+    FROM chat_messages m
+    JOIN subtree t ON m.parent_id = t.id
+)
+SELECT id FROM subtree
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+type GetLatestDescendantParams struct {
+	ID        uuid.UUID
+	SessionID uuid.UUID
+}
+
+// GetLatestDescendant
+//
+//	WITH RECURSIVE subtree AS (
+//	    SELECT id, created_at FROM chat_messages
+//	    WHERE chat_messages.id = $1 AND session_id = $2
+//	    UNION ALL
+//	    SELECT m.id, m.created_at
+//	    FROM chat_messages m
+//	    JOIN subtree t ON m.parent_id = t.id
+//	)
+//	SELECT id FROM subtree
+//	ORDER BY created_at DESC
+//	LIMIT 1
+func (q *Queries) GetLatestDescendant(ctx context.Context, arg GetLatestDescendantParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getLatestDescendant, arg.ID, arg.SessionID)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getLatestSession = `-- name: GetLatestSession :one
 SELECT id FROM chat_sessions
-WHERE user_id = $1
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY updated_at DESC
 LIMIT 1
 `
@@ -51,7 +420,7 @@ LIMIT 1
 // GetLatestSession
 //
 //	SELECT id FROM chat_sessions
-//	WHERE user_id = $1
+//	WHERE user_id = $1 AND archived_at IS NULL
 //	ORDER BY updated_at DESC
 //	LIMIT 1
 func (q *Queries) GetLatestSession(ctx context.Context, userID string) (uuid.UUID, error) {
@@ -62,7 +431,7 @@ func (q *Queries) GetLatestSession(ctx context.Context, userID string) (uuid.UUI
 }
 
 const getSessionByIDForUser = `-- name: GetSessionByIDForUser :one
-SELECT id, user_id, title, created_at, updated_at
+SELECT id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at
 FROM chat_sessions
 WHERE id = $1 AND user_id = $2
 LIMIT 1
@@ -75,7 +444,7 @@ type GetSessionByIDForUserParams struct {
 
 // GetSessionByIDForUser
 //
-//	SELECT id, user_id, title, created_at, updated_at
+//	SELECT id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at
 //	FROM chat_sessions
 //	WHERE id = $1 AND user_id = $2
 //	LIMIT 1
@@ -88,61 +457,122 @@ func (q *Queries) GetSessionByIDForUser(ctx context.Context, arg GetSessionByIDF
 		&i.Title,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Summary,
+		&i.SummaryMessageCount,
+		&i.ActiveMessageID,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
 
-const getSessionMessages = `-- name: GetSessionMessages :many
-SELECT role, content FROM chat_messages
-WHERE session_id = $1
-ORDER BY created_at ASC
+const getSessionMessage = `-- name: GetSessionMessage :one
+SELECT id, parent_id, role, content FROM chat_messages
+WHERE id = $1 AND session_id = $2
 `
 
-type GetSessionMessagesRow struct {
-	Role    string
-	Content string
+type GetSessionMessageParams struct {
+	ID        uuid.UUID
+	SessionID uuid.UUID
 }
 
-// GetSessionMessages
+type GetSessionMessageRow struct {
+	ID       uuid.UUID
+	ParentID *uuid.UUID
+	Role     string
+	Content  string
+}
+
+// GetSessionMessage
 //
-//	SELECT role, content FROM chat_messages
-//	WHERE session_id = $1
-//	ORDER BY created_at ASC
-func (q *Queries) GetSessionMessages(ctx context.Context, sessionID uuid.UUID) ([]GetSessionMessagesRow, error) {
-	rows, err := q.db.Query(ctx, getSessionMessages, sessionID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []GetSessionMessagesRow{}
-	for rows.Next() {
-		var i GetSessionMessagesRow
-		if err := rows.Scan(&i.Role, &i.Content); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+//	SELECT id, parent_id, role, content FROM chat_messages
+//	WHERE id = $1 AND session_id = $2
+func (q *Queries) GetSessionMessage(ctx context.Context, arg GetSessionMessageParams) (GetSessionMessageRow, error) {
+	row := q.db.QueryRow(ctx, getSessionMessage, arg.ID, arg.SessionID)
+	var i GetSessionMessageRow
+	err := row.Scan(
+		&i.ID,
+		&i.ParentID,
+		&i.Role,
+		&i.Content,
+	)
+	return i, err
+}
+
+const getSessionShare = `-- name: GetSessionShare :one
+SELECT token, session_id, user_id, title, messages, created_at
+FROM chat_session_shares
+WHERE token = $1
+`
+
+// GetSessionShare
+//
+//	SELECT token, session_id, user_id, title, messages, created_at
+//	FROM chat_session_shares
+//	WHERE token = $1
+func (q *Queries) GetSessionShare(ctx context.Context, token string) (ChatSessionShare, error) {
+	row := q.db.QueryRow(ctx, getSessionShare, token)
+	var i ChatSessionShare
+	err := row.Scan(
+		&i.Token,
+		&i.SessionID,
+		&i.UserID,
+		&i.Title,
+		&i.Messages,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSessionSummary = `-- name: GetSessionSummary :one
+SELECT summary, summary_message_count FROM chat_sessions
+WHERE id = $1
+`
+
+type GetSessionSummaryRow struct {
+	Summary             string
+	SummaryMessageCount int32
+}
+
+// GetSessionSummary
+//
+//	SELECT summary, summary_message_count FROM chat_sessions
+//	WHERE id = $1
+func (q *Queries) GetSessionSummary(ctx context.Context, id uuid.UUID) (GetSessionSummaryRow, error) {
+	row := q.db.QueryRow(ctx, getSessionSummary, id)
+	var i GetSessionSummaryRow
+	err := row.Scan(&i.Summary, &i.SummaryMessageCount)
+	return i, err
 }
 
 const getUserSessions = `-- name: GetUserSessions :many
-SELECT id, user_id, title, created_at, updated_at
+SELECT id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at
 FROM chat_sessions
-WHERE user_id = $1
+WHERE user_id = $1 AND (archived_at IS NOT NULL) = $2::boolean
 ORDER BY updated_at DESC
+LIMIT $3 OFFSET $4
 `
 
+type GetUserSessionsParams struct {
+	UserID     string
+	Archived   bool
+	PageLimit  int32
+	PageOffset int32
+}
+
 // GetUserSessions
 //
-//	SELECT id, user_id, title, created_at, updated_at
+//	SELECT id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at
 //	FROM chat_sessions
-//	WHERE user_id = $1
+//	WHERE user_id = $1 AND (archived_at IS NOT NULL) = $2::boolean
 //	ORDER BY updated_at DESC
-func (q *Queries) GetUserSessions(ctx context.Context, userID string) ([]ChatSession, error) {
-	rows, err := q.db.Query(ctx, getUserSessions, userID)
+//	LIMIT $3 OFFSET $4
+func (q *Queries) GetUserSessions(ctx context.Context, arg GetUserSessionsParams) ([]ChatSession, error) {
+	rows, err := q.db.Query(ctx, getUserSessions,
+		arg.UserID,
+		arg.Archived,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +586,10 @@ func (q *Queries) GetUserSessions(ctx context.Context, userID string) ([]ChatSes
 			&i.Title,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Summary,
+			&i.SummaryMessageCount,
+			&i.ActiveMessageID,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -167,24 +601,90 @@ func (q *Queries) GetUserSessions(ctx context.Context, userID string) ([]ChatSes
 	return items, nil
 }
 
-const insertMessage = `-- name: InsertMessage :exec
-INSERT INTO chat_messages (session_id, role, content)
-VALUES ($1, $2, $3)
+const insertCorpus = `-- name: InsertCorpus :one
+INSERT INTO corpora (id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at
+`
+
+type InsertCorpusParams struct {
+	ID              string
+	Name            string
+	Description     string
+	CollectionName  string
+	GenerationModel string
+	Temperature     *float32
+	BasePrompt      string
+	ContextPrompt   string
+	QueryPrompt     string
+}
+
+// InsertCorpus
+//
+//	INSERT INTO corpora (id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt)
+//	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+//	RETURNING id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at
+func (q *Queries) InsertCorpus(ctx context.Context, arg InsertCorpusParams) (Corpus, error) {
+	row := q.db.QueryRow(ctx, insertCorpus,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.CollectionName,
+		arg.GenerationModel,
+		arg.Temperature,
+		arg.BasePrompt,
+		arg.ContextPrompt,
+		arg.QueryPrompt,
+	)
+	var i Corpus
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CollectionName,
+		&i.GenerationModel,
+		&i.Temperature,
+		&i.BasePrompt,
+		&i.ContextPrompt,
+		&i.QueryPrompt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const insertMessage = `-- name: InsertMessage :one
+INSERT INTO chat_messages (session_id, parent_id, role, content, citations, truncated)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id
 `
 
 type InsertMessageParams struct {
 	SessionID uuid.UUID
+	ParentID  *uuid.UUID
 	Role      string
 	Content   string
+	Citations []byte
+	Truncated bool
 }
 
 // InsertMessage
 //
-//	INSERT INTO chat_messages (session_id, role, content)
-//	VALUES ($1, $2, $3)
-func (q *Queries) InsertMessage(ctx context.Context, arg InsertMessageParams) error {
-	_, err := q.db.Exec(ctx, insertMessage, arg.SessionID, arg.Role, arg.Content)
-	return err
+//	INSERT INTO chat_messages (session_id, parent_id, role, content, citations, truncated)
+//	VALUES ($1, $2, $3, $4, $5, $6)
+//	RETURNING id
+func (q *Queries) InsertMessage(ctx context.Context, arg InsertMessageParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, insertMessage,
+		arg.SessionID,
+		arg.ParentID,
+		arg.Role,
+		arg.Content,
+		arg.Citations,
+		arg.Truncated,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
 }
 
 const insertSession = `-- name: InsertSession :one
@@ -209,3 +709,514 @@ func (q *Queries) InsertSession(ctx context.Context, arg InsertSessionParams) (u
 	err := row.Scan(&id)
 	return id, err
 }
+
+const insertSessionShare = `-- name: InsertSessionShare :one
+INSERT INTO chat_session_shares (token, session_id, user_id, title, messages)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING token, session_id, user_id, title, messages, created_at
+`
+
+type InsertSessionShareParams struct {
+	Token     string
+	SessionID uuid.UUID
+	UserID    string
+	Title     string
+	Messages  []byte
+}
+
+// InsertSessionShare
+//
+//	INSERT INTO chat_session_shares (token, session_id, user_id, title, messages)
+//	VALUES ($1, $2, $3, $4, $5)
+//	RETURNING token, session_id, user_id, title, messages, created_at
+func (q *Queries) InsertSessionShare(ctx context.Context, arg InsertSessionShareParams) (ChatSessionShare, error) {
+	row := q.db.QueryRow(ctx, insertSessionShare,
+		arg.Token,
+		arg.SessionID,
+		arg.UserID,
+		arg.Title,
+		arg.Messages,
+	)
+	var i ChatSessionShare
+	err := row.Scan(
+		&i.Token,
+		&i.SessionID,
+		&i.UserID,
+		&i.Title,
+		&i.Messages,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listCorpora = `-- name: ListCorpora :many
+SELECT id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at
+FROM corpora
+ORDER BY name, id
+`
+
+// ListCorpora
+//
+//	SELECT id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at
+//	FROM corpora
+//	ORDER BY name, id
+func (q *Queries) ListCorpora(ctx context.Context) ([]Corpus, error) {
+	rows, err := q.db.Query(ctx, listCorpora)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Corpus{}
+	for rows.Next() {
+		var i Corpus
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CollectionName,
+			&i.GenerationModel,
+			&i.Temperature,
+			&i.BasePrompt,
+			&i.ContextPrompt,
+			&i.QueryPrompt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFeedback = `-- name: ListFeedback :many
+SELECT a.id, a.session_id, COALESCE(q.content, '')::text AS question, a.content AS answer, a.citations,
+    a.feedback_rating::smallint AS rating, a.feedback_comment, a.feedback_at::timestamptz AS rated_at
+FROM chat_messages a
+LEFT JOIN chat_messages q ON q.id = a.parent_id
+WHERE a.feedback_rating IS NOT NULL
+    AND a.feedback_at >= $1::timestamptz
+    AND ($2::smallint = 0 OR a.feedback_rating = $2::smallint)
+ORDER BY a.feedback_at, a.id
+`
+
+type ListFeedbackParams struct {
+	Since  pgtype.Timestamptz
+	Rating int16
+}
+
+type ListFeedbackRow struct {
+	ID              uuid.UUID
+	SessionID       uuid.UUID
+	Question        string
+	Answer          string
+	Citations       []byte
+	Rating          int16
+	FeedbackComment string
+	RatedAt         pgtype.Timestamptz
+}
+
+// ListFeedback
+//
+//	SELECT a.id, a.session_id, COALESCE(q.content, '')::text AS question, a.content AS answer, a.citations,
+//	    a.feedback_rating::smallint AS rating, a.feedback_comment, a.feedback_at::timestamptz AS rated_at
+//	FROM chat_messages a
+//	LEFT JOIN chat_messages q ON q.id = a.parent_id
+//	WHERE a.feedback_rating IS NOT NULL
+//	    AND a.feedback_at >= $1::timestamptz
+//	    AND ($2::smallint = 0 OR a.feedback_rating = $2::smallint)
+//	ORDER BY a.feedback_at, a.id
+func (q *Queries) ListFeedback(ctx context.Context, arg ListFeedbackParams) ([]ListFeedbackRow, error) {
+	rows, err := q.db.Query(ctx, listFeedback, arg.Since, arg.Rating)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListFeedbackRow{}
+	for rows.Next() {
+		var i ListFeedbackRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.Question,
+			&i.Answer,
+			&i.Citations,
+			&i.Rating,
+			&i.FeedbackComment,
+			&i.RatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionShares = `-- name: ListSessionShares :many
+SELECT token, session_id, user_id, title, created_at
+FROM chat_session_shares
+WHERE session_id = $1
+ORDER BY created_at DESC, token
+`
+
+type ListSessionSharesRow struct {
+	Token     string
+	SessionID uuid.UUID
+	UserID    string
+	Title     string
+	CreatedAt pgtype.Timestamptz
+}
+
+// ListSessionShares
+//
+//	SELECT token, session_id, user_id, title, created_at
+//	FROM chat_session_shares
+//	WHERE session_id = $1
+//	ORDER BY created_at DESC, token
+func (q *Queries) ListSessionShares(ctx context.Context, sessionID uuid.UUID) ([]ListSessionSharesRow, error) {
+	rows, err := q.db.Query(ctx, listSessionShares, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSessionSharesRow{}
+	for rows.Next() {
+		var i ListSessionSharesRow
+		if err := rows.Scan(
+			&i.Token,
+			&i.SessionID,
+			&i.UserID,
+			&i.Title,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchUserMessages = `-- name: SearchUserMessages :many
+SELECT m.id, m.session_id, s.title AS session_title, m.role,
+    ts_headline('russian', m.content, plainto_tsquery('russian', $1::text),
+        'MaxFragments=2, MaxWords=20, MinWords=5')::text AS snippet,
+    ts_rank(m.search_vector, plainto_tsquery('russian', $1::text))::real AS rank,
+    m.created_at
+FROM chat_messages m
+JOIN chat_sessions s ON s.id = m.session_id
+WHERE s.user_id = $2
+  AND m.search_vector @@ plainto_tsquery('russian', $1::text)
+ORDER BY rank DESC, m.created_at DESC
+LIMIT $3 OFFSET $4
+`
+
+type SearchUserMessagesParams struct {
+	Query      string
+	UserID     string
+	PageLimit  int32
+	PageOffset int32
+}
+
+type SearchUserMessagesRow struct {
+	ID           uuid.UUID
+	SessionID    uuid.UUID
+	SessionTitle string
+	Role         string
+	Snippet      string
+	Rank         float32
+	CreatedAt    pgtype.Timestamptz
+}
+
+// SearchUserMessages
+//
+//	SELECT m.id, m.session_id, s.title AS session_title, m.role,
+//	    ts_headline('russian', m.content, plainto_tsquery('russian', $1::text),
+//	        'MaxFragments=2, MaxWords=20, MinWords=5')::text AS snippet,
+//	    ts_rank(m.search_vector, plainto_tsquery('russian', $1::text))::real AS rank,
+//	    m.created_at
+//	FROM chat_messages m
+//	JOIN chat_sessions s ON s.id = m.session_id
+//	WHERE s.user_id = $2
+//	  AND m.search_vector @@ plainto_tsquery('russian', $1::text)
+//	ORDER BY rank DESC, m.created_at DESC
+//	LIMIT $3 OFFSET $4
+func (q *Queries) SearchUserMessages(ctx context.Context, arg SearchUserMessagesParams) ([]SearchUserMessagesRow, error) {
+	rows, err := q.db.Query(ctx, searchUserMessages,
+		arg.Query,
+		arg.UserID,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchUserMessagesRow{}
+	for rows.Next() {
+		var i SearchUserMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.SessionTitle,
+			&i.Role,
+			&i.Snippet,
+			&i.Rank,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setActiveMessage = `-- name: SetActiveMessage :exec
+UPDATE chat_sessions
+SET active_message_id = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetActiveMessageParams struct {
+	ID              uuid.UUID
+	ActiveMessageID *uuid.UUID
+}
+
+// SetActiveMessage
+//
+//	UPDATE chat_sessions
+//	SET active_message_id = $2, updated_at = NOW()
+//	WHERE id = $1
+func (q *Queries) SetActiveMessage(ctx context.Context, arg SetActiveMessageParams) error {
+	_, err := q.db.Exec(ctx, setActiveMessage, arg.ID, arg.ActiveMessageID)
+	return err
+}
+
+const setGeneratedSessionTitle = `-- name: SetGeneratedSessionTitle :one
+UPDATE chat_sessions
+SET title = $1
+WHERE id = $2 AND user_id = $3 AND title = $4
+RETURNING id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at
+`
+
+type SetGeneratedSessionTitleParams struct {
+	Title        string
+	ID           uuid.UUID
+	UserID       string
+	DefaultTitle string
+}
+
+// SetGeneratedSessionTitle
+//
+//	UPDATE chat_sessions
+//	SET title = $1
+//	WHERE id = $2 AND user_id = $3 AND title = $4
+//	RETURNING id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at
+func (q *Queries) SetGeneratedSessionTitle(ctx context.Context, arg SetGeneratedSessionTitleParams) (ChatSession, error) {
+	row := q.db.QueryRow(ctx, setGeneratedSessionTitle,
+		arg.Title,
+		arg.ID,
+		arg.UserID,
+		arg.DefaultTitle,
+	)
+	var i ChatSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Summary,
+		&i.SummaryMessageCount,
+		&i.ActiveMessageID,
+		&i.ArchivedAt,
+	)
+	return i, err
+}
+
+const setMessageFeedback = `-- name: SetMessageFeedback :exec
+UPDATE chat_messages
+SET feedback_rating = $1,
+    feedback_comment = $2,
+    feedback_at = CASE WHEN $1::smallint IS NULL THEN NULL ELSE NOW() END
+WHERE id = $3 AND session_id = $4
+`
+
+type SetMessageFeedbackParams struct {
+	Rating    *int16
+	Comment   string
+	ID        uuid.UUID
+	SessionID uuid.UUID
+}
+
+// SetMessageFeedback
+//
+//	UPDATE chat_messages
+//	SET feedback_rating = $1,
+//	    feedback_comment = $2,
+//	    feedback_at = CASE WHEN $1::smallint IS NULL THEN NULL ELSE NOW() END
+//	WHERE id = $3 AND session_id = $4
+func (q *Queries) SetMessageFeedback(ctx context.Context, arg SetMessageFeedbackParams) error {
+	_, err := q.db.Exec(ctx, setMessageFeedback,
+		arg.Rating,
+		arg.Comment,
+		arg.ID,
+		arg.SessionID,
+	)
+	return err
+}
+
+const updateCorpus = `-- name: UpdateCorpus :one
+UPDATE corpora
+SET name = $2,
+    description = $3,
+    collection_name = $4,
+    generation_model = $5,
+    temperature = $6,
+    base_prompt = $7,
+    context_prompt = $8,
+    query_prompt = $9,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at
+`
+
+type UpdateCorpusParams struct {
+	ID              string
+	Name            string
+	Description     string
+	CollectionName  string
+	GenerationModel string
+	Temperature     *float32
+	BasePrompt      string
+	ContextPrompt   string
+	QueryPrompt     string
+}
+
+// UpdateCorpus
+//
+//	UPDATE corpora
+//	SET name = $2,
+//	    description = $3,
+//	    collection_name = $4,
+//	    generation_model = $5,
+//	    temperature = $6,
+//	    base_prompt = $7,
+//	    context_prompt = $8,
+//	    query_prompt = $9,
+//	    updated_at = NOW()
+//	WHERE id = $1
+//	RETURNING id, name, description, collection_name, generation_model, temperature, base_prompt, context_prompt, query_prompt, created_at, updated_at
+func (q *Queries) UpdateCorpus(ctx context.Context, arg UpdateCorpusParams) (Corpus, error) {
+	row := q.db.QueryRow(ctx, updateCorpus,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.CollectionName,
+		arg.GenerationModel,
+		arg.Temperature,
+		arg.BasePrompt,
+		arg.ContextPrompt,
+		arg.QueryPrompt,
+	)
+	var i Corpus
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CollectionName,
+		&i.GenerationModel,
+		&i.Temperature,
+		&i.BasePrompt,
+		&i.ContextPrompt,
+		&i.QueryPrompt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateSession = `-- name: UpdateSession :one
+UPDATE chat_sessions
+SET title = COALESCE($1, title),
+    archived_at = CASE
+        WHEN $2::boolean IS NULL THEN archived_at
+        WHEN $2::boolean THEN COALESCE(archived_at, NOW())
+        ELSE NULL
+    END
+WHERE id = $3 AND user_id = $4
+RETURNING id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at
+`
+
+type UpdateSessionParams struct {
+	Title    *string
+	Archived *bool
+	ID       uuid.UUID
+	UserID   string
+}
+
+// UpdateSession
+//
+//	UPDATE chat_sessions
+//	SET title = COALESCE($1, title),
+//	    archived_at = CASE
+//	        WHEN $2::boolean IS NULL THEN archived_at
+//	        WHEN $2::boolean THEN COALESCE(archived_at, NOW())
+//	        ELSE NULL
+//	    END
+//	WHERE id = $3 AND user_id = $4
+//	RETURNING id, user_id, title, created_at, updated_at, summary, summary_message_count, active_message_id, archived_at
+func (q *Queries) UpdateSession(ctx context.Context, arg UpdateSessionParams) (ChatSession, error) {
+	row := q.db.QueryRow(ctx, updateSession,
+		arg.Title,
+		arg.Archived,
+		arg.ID,
+		arg.UserID,
+	)
+	var i ChatSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Summary,
+		&i.SummaryMessageCount,
+		&i.ActiveMessageID,
+		&i.ArchivedAt,
+	)
+	return i, err
+}
+
+const updateSessionSummary = `-- name: UpdateSessionSummary :exec
+UPDATE chat_sessions
+SET summary = $2, summary_message_count = $3
+WHERE id = $1
+`
+
+type UpdateSessionSummaryParams struct {
+	ID                  uuid.UUID
+	Summary             string
+	SummaryMessageCount int32
+}
+
+// UpdateSessionSummary
+//
+//	UPDATE chat_sessions
+//	SET summary = $2, summary_message_count = $3
+//	WHERE id = $1
+func (q *Queries) UpdateSessionSummary(ctx context.Context, arg UpdateSessionSummaryParams) error {
+	_, err := q.db.Exec(ctx, updateSessionSummary, arg.ID, arg.Summary, arg.SummaryMessageCount)
+	return err
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+	"github.com/artmexbet/raibecas/services/chat/internal/postgres/queries"
+)
+
+// CreateSessionShare stores a snapshot of the active branch of a session of userID under token.
+// It fails with domain.ErrNothingToShare when the branch is empty.
+func (s *Store) CreateSessionShare(ctx context.Context, userID, sessionID, token string) (domain.SessionShare, error) {
+	parsedSessionID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return domain.SessionShare{}, domain.ErrInvalidChatSessionID
+	}
+	session, err := s.q().GetSessionByIDForUser(ctx, queries.GetSessionByIDForUserParams{
+		ID:     parsedSessionID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.SessionShare{}, domain.ErrChatSessionNotFound
+		}
+		return domain.SessionShare{}, fmt.Errorf("CreateSessionShare session: %w", err)
+	}
+
+	rows, err := s.q().GetActiveBranchMessages(ctx, parsedSessionID)
+	if err != nil {
+		return domain.SessionShare{}, fmt.Errorf("CreateSessionShare messages: %w", err)
+	}
+	messages, err := toDomainMessages(rows)
+	if err != nil {
+		return domain.SessionShare{}, err
+	}
+	transcript := domain.ShareTranscript(messages)
+	if len(transcript) == 0 {
+		return domain.SessionShare{}, domain.ErrNothingToShare
+	}
+	encoded, err := json.Marshal(transcript)
+	if err != nil {
+		return domain.SessionShare{}, fmt.Errorf("CreateSessionShare encode: %w", err)
+	}
+
+	row, err := s.q().InsertSessionShare(ctx, queries.InsertSessionShareParams{
+		Token:     token,
+		SessionID: parsedSessionID,
+		UserID:    userID,
+		Title:     session.Title,
+		Messages:  encoded,
+	})
+	if err != nil {
+		return domain.SessionShare{}, fmt.Errorf("CreateSessionShare insert: %w", err)
+	}
+	return toDomainShare(row)
+}
+
+// GetSessionShare returns the snapshot published under token.
+func (s *Store) GetSessionShare(ctx context.Context, token string) (domain.SessionShare, error) {
+	row, err := s.q().GetSessionShare(ctx, token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.SessionShare{}, domain.ErrShareNotFound
+		}
+		return domain.SessionShare{}, fmt.Errorf("GetSessionShare: %w", err)
+	}
+	return toDomainShare(row)
+}
+
+// ListSessionShares returns the published snapshots of a session of userID without messages, newest first.
+func (s *Store) ListSessionShares(ctx context.Context, userID, sessionID string) ([]domain.SessionShare, error) {
+	parsedSessionID, err := s.getOwnedSessionID(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.q().ListSessionShares(ctx, parsedSessionID)
+	if err != nil {
+		return nil, fmt.Errorf("ListSessionShares: %w", err)
+	}
+	shares := make([]domain.SessionShare, len(rows))
+	for i, row := range rows {
+		shares[i] = domain.SessionShare{
+			Token:     row.Token,
+			SessionID: row.SessionID.String(),
+			Title:     row.Title,
+			CreatedAt: row.CreatedAt.Time,
+		}
+	}
+	return shares, nil
+}
+
+// DeleteSessionShare revokes a snapshot published by userID.
+func (s *Store) DeleteSessionShare(ctx context.Context, userID, token string) error {
+	deleted, err := s.q().DeleteSessionShare(ctx, queries.DeleteSessionShareParams{
+		Token:  token,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteSessionShare: %w", err)
+	}
+	if deleted == 0 {
+		return domain.ErrShareNotFound
+	}
+	return nil
+}
+
+func toDomainShare(row queries.ChatSessionShare) (domain.SessionShare, error) {
+	share := domain.SessionShare{
+		Token:     row.Token,
+		SessionID: row.SessionID.String(),
+		Title:     row.Title,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if err := json.Unmarshal(row.Messages, &share.Messages); err != nil {
+		return domain.SessionShare{}, fmt.Errorf("decode shared messages: %w", err)
+	}
+	return share, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
 
 	"github.com/qdrant/go-client/qdrant"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/artmexbet/raibecas/libs/utils/pointer"
 
@@ -13,6 +15,9 @@ import (
 	"github.com/artmexbet/raibecas/services/chat/internal/domain"
 )
 
+// chunksPerDocument bounds how many chunks are fetched per document in RetrieveDocumentChunks.
+const chunksPerDocument = 3
+
 type QdrantWrapper struct {
 	client *qdrant.Client
 	cfg    *config.Qdrant
@@ -51,36 +56,150 @@ func (q *QdrantWrapper) CheckConnection(ctx context.Context) error {
 
 // RetrieveVectors queries the Qdrant vector database for similar vectors based on the input vector.
 // It takes a context for cancellation and a slice of float64 representing the query vector.
-// Returns an error if the retrieval fails.
-func (q *QdrantWrapper) RetrieveVectors(ctx context.Context, vector []float64) ([]domain.Document, error) {
-	convertedVector := make([]float32, len(vector))
-	for i, v := range vector {
-		convertedVector[i] = float32(v)
+// The raw query text is not used by pure vector search. scope selects the collection
+// and the payload filters. Returns an error if the retrieval fails.
+func (q *QdrantWrapper) RetrieveVectors(ctx context.Context, _ string, vector []float64, scope domain.SearchScope) ([]domain.Document, error) {
+	result, err := q.client.Query(
+		ctx,
+		&qdrant.QueryPoints{
+			CollectionName: q.collection(scope),
+			Query:          qdrant.NewQuery(toFloat32(vector)...),
+			Filter:         scopeFilter(scope),
+			WithPayload:    qdrant.NewWithPayload(q.cfg.RetrievePayload),
+			Limit:          pointer.To(q.limit(scope)),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve the vectors: %w", err)
+	}
+
+	return toDocuments(result), nil
+}
+
+// RetrieveDocumentChunks returns chunks of the given documents ordered by similarity to the vector.
+// At most chunksPerDocument chunks per requested document are fetched. Documents outside of scope are skipped.
+func (q *QdrantWrapper) RetrieveDocumentChunks(
+	ctx context.Context,
+	vector []float64,
+	documentIDs []string,
+	scope domain.SearchScope,
+) ([]domain.Document, error) {
+	if len(documentIDs) == 0 {
+		return []domain.Document{}, nil
 	}
 
+	filter := scopeFilter(scope)
+	if filter == nil {
+		filter = &qdrant.Filter{}
+	}
+	filter.Must = append(filter.Must, qdrant.NewMatchKeywords(domain.PayloadDocumentID, documentIDs...))
+
 	result, err := q.client.Query(
 		ctx,
 		&qdrant.QueryPoints{
-			CollectionName: q.cfg.CollectionName,
-			Query:          qdrant.NewQuery(convertedVector...),
+			CollectionName: q.collection(scope),
+			Query:          qdrant.NewQuery(toFloat32(vector)...),
+			Filter:         filter,
 			WithPayload:    qdrant.NewWithPayload(q.cfg.RetrievePayload),
-			Limit:          pointer.To(q.cfg.CountOfResults),
+			Limit:          pointer.To(uint64(len(documentIDs)) * chunksPerDocument),
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("cannot retrieve the vectors: %w", err)
+		return nil, fmt.Errorf("cannot retrieve document chunks: %w", err)
+	}
+
+	return toDocuments(result), nil
+}
+
+// limit returns the number of chunks to retrieve for scope, CountOfResults by default.
+func (q *QdrantWrapper) limit(scope domain.SearchScope) uint64 {
+	if scope.Limit > 0 {
+		return scope.Limit
 	}
+	return q.cfg.CountOfResults
+}
+
+// collection returns the collection of scope, the configured one by default.
+func (q *QdrantWrapper) collection(scope domain.SearchScope) string {
+	if scope.Collection != "" {
+		return scope.Collection
+	}
+	return q.cfg.CollectionName
+}
+
+// scopeFilter translates the filters of scope into payload conditions that must all match.
+// Unless private documents are allowed, only points marked is_public are kept,
+// so points indexed without the flag are visible to privileged users only.
+// Returns nil when nothing is filtered.
+func scopeFilter(scope domain.SearchScope) *qdrant.Filter {
+	var must []*qdrant.Condition
+	if !scope.IncludePrivate {
+		must = append(must, qdrant.NewMatchBool(domain.PayloadIsPublic, true))
+	}
+
+	f := scope.Filters
+	if len(f.DocumentIDs) > 0 {
+		must = append(must, qdrant.NewMatchKeywords(domain.PayloadDocumentID, f.DocumentIDs...))
+	}
+	if len(f.AuthorIDs) > 0 {
+		must = append(must, qdrant.NewMatchKeywords(domain.PayloadAuthorIDs, f.AuthorIDs...))
+	}
+	if len(f.TagIDs) > 0 {
+		must = append(must, qdrant.NewMatchInts(domain.PayloadTagIDs, f.TagIDs...))
+	}
+	if f.PublishedFrom != nil || f.PublishedTo != nil {
+		dateRange := &qdrant.DatetimeRange{}
+		if f.PublishedFrom != nil {
+			dateRange.Gte = timestamppb.New(*f.PublishedFrom)
+		}
+		if f.PublishedTo != nil {
+			dateRange.Lte = timestamppb.New(*f.PublishedTo)
+		}
+		must = append(must, qdrant.NewDatetimeRange(domain.PayloadPublicationDate, dateRange))
+	}
+
+	if len(must) == 0 {
+		return nil
+	}
+	return &qdrant.Filter{Must: must}
+}
 
+func toFloat32(vector []float64) []float32 {
+	converted := make([]float32, len(vector))
+	for i, v := range vector {
+		converted[i] = float32(v)
+	}
+	return converted
+}
+
+func toDocuments(result []*qdrant.ScoredPoint) []domain.Document {
 	response := make([]domain.Document, len(result))
 	for i, v := range result {
 		response[i] = domain.Document{
 			ID:       v.Id.String(),
+			Score:    v.Score,
 			Metadata: make(map[string]interface{}),
 		}
 		for key, value := range v.Payload {
-			response[i].Metadata[key] = value.GetStringValue()
+			response[i].Metadata[key] = payloadValueToString(value)
 		}
 	}
+	return response
+}
 
-	return response, nil
+// payloadValueToString flattens scalar payload values into strings.
+// The indexer stores some fields (e.g. ordinal) as numbers, which GetStringValue would drop.
+func payloadValueToString(value *qdrant.Value) string {
+	switch kind := value.GetKind().(type) {
+	case *qdrant.Value_StringValue:
+		return kind.StringValue
+	case *qdrant.Value_IntegerValue:
+		return strconv.FormatInt(kind.IntegerValue, 10)
+	case *qdrant.Value_DoubleValue:
+		return strconv.FormatFloat(kind.DoubleValue, 'f', -1, 64)
+	case *qdrant.Value_BoolValue:
+		return strconv.FormatBool(kind.BoolValue)
+	default:
+		return value.GetStringValue()
+	}
 }
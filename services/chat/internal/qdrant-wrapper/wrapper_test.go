@@ -0,0 +1,51 @@
+package qdrantWrapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+func TestScopeFilter(t *testing.T) {
+	if filter := scopeFilter(domain.SearchScope{IncludePrivate: true}); filter != nil {
+		t.Errorf("expected no filter for an unrestricted scope, got %v", filter)
+	}
+
+	filter := scopeFilter(domain.SearchScope{})
+	if filter == nil || len(filter.Must) != 1 || filter.Must[0].GetField().GetKey() != domain.PayloadIsPublic {
+		t.Fatalf("expected only the is_public condition, got %v", filter)
+	}
+
+	from := time.Date(1780, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter = scopeFilter(domain.SearchScope{
+		IncludePrivate: true,
+		Filters: domain.SearchFilters{
+			DocumentIDs:   []string{"d1"},
+			AuthorIDs:     []string{"a1", "a2"},
+			TagIDs:        []int64{3},
+			PublishedFrom: &from,
+		},
+	})
+	if filter == nil {
+		t.Fatal("expected a filter")
+	}
+
+	keys := make(map[string]bool)
+	for _, condition := range filter.Must {
+		keys[condition.GetField().GetKey()] = true
+	}
+	for _, key := range []string{domain.PayloadDocumentID, domain.PayloadAuthorIDs, domain.PayloadTagIDs, domain.PayloadPublicationDate} {
+		if !keys[key] {
+			t.Errorf("expected a condition on %s, got %v", key, filter.Must)
+		}
+	}
+	if keys[domain.PayloadIsPublic] {
+		t.Error("is_public must not be required when private documents are allowed")
+	}
+
+	dateRange := filter.Must[3].GetField().GetDatetimeRange()
+	if dateRange.GetGte().AsTime() != from || dateRange.GetLte() != nil {
+		t.Errorf("unexpected date range %v", dateRange)
+	}
+}
@@ -0,0 +1,205 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+const (
+	keyPrefix = "chat:quota:"
+
+	requestsWindow = time.Minute
+	tokensWindow   = 24 * time.Hour
+)
+
+// refundScript decrements the request counter without letting it go below zero,
+// so a refund after the minute window has expired does not create a negative count.
+var refundScript = redis.NewScript(`
+local count = tonumber(redis.call("GET", KEYS[1]) or "0")
+if count > 0 then
+	return redis.call("DECR", KEYS[1])
+end
+return 0
+`)
+
+// limits of a role. Zero is unlimited.
+type limits struct {
+	requestsPerMinute int
+	tokensPerDay      int
+}
+
+// Limiter enforces per-user quotas with counters in Redis, so that all replicas of the service share them.
+// Requests are counted in fixed one-minute windows and tokens in UTC days.
+type Limiter struct {
+	client   *redis.Client
+	defaults limits
+	roles    map[string]limits
+	now      func() time.Time
+}
+
+// New creates a limiter with the limits of cfg. It fails on malformed role overrides.
+func New(client *redis.Client, cfg *config.Quota) (*Limiter, error) {
+	roles := make(map[string]limits, len(cfg.Roles))
+	for role, value := range cfg.Roles {
+		l, err := parseLimits(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quota of role %q: %w", role, err)
+		}
+		roles[role] = l
+	}
+
+	return &Limiter{
+		client:   client,
+		defaults: limits{requestsPerMinute: cfg.RequestsPerMinute, tokensPerDay: cfg.TokensPerDay},
+		roles:    roles,
+		now:      time.Now,
+	}, nil
+}
+
+// parseLimits parses "requests_per_minute/tokens_per_day".
+func parseLimits(value string) (limits, error) {
+	requests, tokens, ok := strings.Cut(value, "/")
+	if !ok {
+		return limits{}, errors.New("expected requests_per_minute/tokens_per_day")
+	}
+	var (
+		l   limits
+		err error
+	)
+	if l.requestsPerMinute, err = strconv.Atoi(strings.TrimSpace(requests)); err != nil || l.requestsPerMinute < 0 {
+		return limits{}, fmt.Errorf("invalid requests per minute %q", requests)
+	}
+	if l.tokensPerDay, err = strconv.Atoi(strings.TrimSpace(tokens)); err != nil || l.tokensPerDay < 0 {
+		return limits{}, fmt.Errorf("invalid tokens per day %q", tokens)
+	}
+	return l, nil
+}
+
+func (l *Limiter) limits(role string) limits {
+	if roleLimits, ok := l.roles[role]; ok {
+		return roleLimits
+	}
+	return l.defaults
+}
+
+// Acquire counts a generation request of the user. It fails with a *domain.QuotaExceededError
+// when the user made too many requests this minute or spent the tokens of the day.
+// Rejected requests count towards the minute as well.
+func (l *Limiter) Acquire(ctx context.Context, userID, role string) (domain.QuotaUsage, error) {
+	lim := l.limits(role)
+	if lim.requestsPerMinute == 0 && lim.tokensPerDay == 0 {
+		return domain.QuotaUsage{}, nil
+	}
+
+	now := l.now()
+	requestsKey, requestsReset := requestsKey(userID, now)
+	tokensKey, tokensReset := tokensKey(userID, now)
+
+	var (
+		spent    *redis.StringCmd
+		requests *redis.IntCmd
+	)
+	_, err := l.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		spent = pipe.Get(ctx, tokensKey)
+		requests = pipe.Incr(ctx, requestsKey)
+		pipe.Expire(ctx, requestsKey, 2*requestsWindow)
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return domain.QuotaUsage{}, fmt.Errorf("could not count request: %w", err)
+	}
+
+	tokens, _ := spent.Int()
+	if lim.tokensPerDay > 0 && tokens >= lim.tokensPerDay {
+		return domain.QuotaUsage{}, &domain.QuotaExceededError{Quota: domain.QuotaTokensPerDay, RetryAfter: tokensReset.Sub(now)}
+	}
+	if lim.requestsPerMinute > 0 && int(requests.Val()) > lim.requestsPerMinute {
+		return domain.QuotaUsage{}, &domain.QuotaExceededError{Quota: domain.QuotaRequestsPerMinute, RetryAfter: requestsReset.Sub(now)}
+	}
+
+	return usage(lim, int(requests.Val()), requestsReset, tokens, tokensReset), nil
+}
+
+// AddTokens charges tokens spent on a generation to the user and returns the remaining quota.
+func (l *Limiter) AddTokens(ctx context.Context, userID, role string, tokens int) (domain.QuotaUsage, error) {
+	lim := l.limits(role)
+	if lim.requestsPerMinute == 0 && lim.tokensPerDay == 0 {
+		return domain.QuotaUsage{}, nil
+	}
+
+	now := l.now()
+	requestsKey, requestsReset := requestsKey(userID, now)
+	tokensKey, tokensReset := tokensKey(userID, now)
+
+	var (
+		requests *redis.StringCmd
+		spent    *redis.IntCmd
+	)
+	_, err := l.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		requests = pipe.Get(ctx, requestsKey)
+		spent = pipe.IncrBy(ctx, tokensKey, int64(tokens))
+		pipe.Expire(ctx, tokensKey, 2*tokensWindow)
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return domain.QuotaUsage{}, fmt.Errorf("could not charge tokens: %w", err)
+	}
+
+	count, _ := requests.Int()
+	return usage(lim, count, requestsReset, int(spent.Val()), tokensReset), nil
+}
+
+// Refund returns a request counted by Acquire that was not served, e.g. because the session
+// was not found or the generation queue was full. Only the current minute is refunded.
+func (l *Limiter) Refund(ctx context.Context, userID, role string) error {
+	lim := l.limits(role)
+	if lim.requestsPerMinute == 0 {
+		return nil
+	}
+
+	requestsKey, _ := requestsKey(userID, l.now())
+	if err := refundScript.Run(ctx, l.client, []string{requestsKey}).Err(); err != nil {
+		return fmt.Errorf("could not refund request: %w", err)
+	}
+	return nil
+}
+
+// requestsKey returns the counter of the current minute and the end of the minute.
+func requestsKey(userID string, now time.Time) (string, time.Time) {
+	start := now.Truncate(requestsWindow)
+	return fmt.Sprintf("%srequests:%s:%d", keyPrefix, userID, start.Unix()), start.Add(requestsWindow)
+}
+
+// tokensKey returns the counter of the current UTC day and the end of the day.
+func tokensKey(userID string, now time.Time) (string, time.Time) {
+	start := now.UTC().Truncate(tokensWindow)
+	return fmt.Sprintf("%stokens:%s:%s", keyPrefix, userID, start.Format(time.DateOnly)), start.Add(tokensWindow)
+}
+
+func usage(lim limits, requests int, requestsReset time.Time, tokens int, tokensReset time.Time) domain.QuotaUsage {
+	var u domain.QuotaUsage
+	if lim.requestsPerMinute > 0 {
+		u.Requests = &domain.QuotaWindow{
+			Limit:     lim.requestsPerMinute,
+			Remaining: max(lim.requestsPerMinute-requests, 0),
+			ResetAt:   requestsReset,
+		}
+	}
+	if lim.tokensPerDay > 0 {
+		u.Tokens = &domain.QuotaWindow{
+			Limit:     lim.tokensPerDay,
+			Remaining: max(lim.tokensPerDay-tokens, 0),
+			ResetAt:   tokensReset,
+		}
+	}
+	return u
+}
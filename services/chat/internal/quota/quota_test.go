@@ -0,0 +1,135 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+func TestNewParsesRoleLimits(t *testing.T) {
+	l, err := New(nil, &config.Quota{
+		RequestsPerMinute: 10,
+		TokensPerDay:      1000,
+		Roles:             map[string]string{"Admin": "0/0", "Reader": " 2 / 50 "},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if got := l.limits("Reader"); got != (limits{requestsPerMinute: 2, tokensPerDay: 50}) {
+		t.Errorf("Reader limits = %+v", got)
+	}
+	if got := l.limits("User"); got != (limits{requestsPerMinute: 10, tokensPerDay: 1000}) {
+		t.Errorf("default limits = %+v", got)
+	}
+
+	// Unlimited roles never touch Redis, so the nil client is not used
+	usage, err := l.Acquire(context.Background(), "u1", "Admin")
+	if err != nil || usage.Requests != nil || usage.Tokens != nil {
+		t.Errorf("Acquire() for an unlimited role = %+v, %v", usage, err)
+	}
+
+	for _, value := range []string{"10", "a/1", "1/-1", ""} {
+		if _, err := New(nil, &config.Quota{Roles: map[string]string{"User": value}}); err == nil {
+			t.Errorf("New() with role limits %q: expected an error", value)
+		}
+	}
+}
+
+func TestLimiterRedis(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379", DB: 2})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("Skipping Redis tests: %v", err)
+	}
+	defer client.Close() //nolint:errcheck // safe to ignore
+
+	l, err := New(client, &config.Quota{RequestsPerMinute: 2, TokensPerDay: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 16, 12, 30, 15, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	userID := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	requestsKey, _ := requestsKey(userID, now)
+	tokensKey, _ := tokensKey(userID, now)
+	defer client.Del(context.Background(), requestsKey, tokensKey)
+
+	usage, err := l.Acquire(ctx, userID, "")
+	if err != nil {
+		t.Fatalf("first Acquire() error = %v", err)
+	}
+	if usage.Requests.Remaining != 1 || !usage.Requests.ResetAt.Equal(now.Truncate(time.Minute).Add(time.Minute)) {
+		t.Errorf("first Acquire() requests = %+v", usage.Requests)
+	}
+	if _, err := l.Acquire(ctx, userID, ""); err != nil {
+		t.Fatalf("second Acquire() error = %v", err)
+	}
+
+	var quotaErr *domain.QuotaExceededError
+	_, err = l.Acquire(ctx, userID, "")
+	if !errors.As(err, &quotaErr) || quotaErr.Quota != domain.QuotaRequestsPerMinute || quotaErr.RetryAfter != 45*time.Second {
+		t.Fatalf("third Acquire() error = %v, want the requests quota exceeded for 45s", err)
+	}
+
+	// Tokens are counted for the whole day, so the next minute is still rejected once they are spent
+	usage, err = l.AddTokens(ctx, userID, "", 120)
+	if err != nil || usage.Tokens.Remaining != 0 {
+		t.Fatalf("AddTokens() = %+v, %v", usage.Tokens, err)
+	}
+	now = now.Add(time.Minute)
+	defer client.Del(context.Background(), fmt.Sprintf("%srequests:%s:%d", keyPrefix, userID, now.Truncate(time.Minute).Unix()))
+	_, err = l.Acquire(ctx, userID, "")
+	if !errors.As(err, &quotaErr) || quotaErr.Quota != domain.QuotaTokensPerDay {
+		t.Errorf("Acquire() after spending the tokens error = %v, want the tokens quota exceeded", err)
+	}
+}
+
+func TestLimiterRefundRedis(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379", DB: 2})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("Skipping Redis tests: %v", err)
+	}
+	defer client.Close() //nolint:errcheck // safe to ignore
+
+	l, err := New(client, &config.Quota{RequestsPerMinute: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 16, 12, 30, 15, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	userID := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	requestsKey, _ := requestsKey(userID, now)
+	defer client.Del(context.Background(), requestsKey)
+
+	// A refunded request frees its slot in the minute
+	if _, err := l.Acquire(ctx, userID, ""); err != nil {
+		t.Fatalf("first Acquire() error = %v", err)
+	}
+	if err := l.Refund(ctx, userID, ""); err != nil {
+		t.Fatalf("Refund() error = %v", err)
+	}
+	if _, err := l.Acquire(ctx, userID, ""); err != nil {
+		t.Fatalf("Acquire() after a refund error = %v", err)
+	}
+
+	// Extra refunds never push the counter below zero
+	for range 3 {
+		if err := l.Refund(ctx, userID, ""); err != nil {
+			t.Fatalf("Refund() error = %v", err)
+		}
+	}
+	if count, err := client.Get(ctx, requestsKey).Int(); err != nil || count != 0 {
+		t.Errorf("requests counter = %d, %v, want 0", count, err)
+	}
+}
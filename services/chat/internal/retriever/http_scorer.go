@@ -0,0 +1,97 @@
+package retriever
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// HTTPScorer scores documents with a cross-encoder served through the /v1/rerank API
+// introduced by Cohere and Jina and implemented by vLLM, llama.cpp server and LocalAI.
+type HTTPScorer struct {
+	client *http.Client
+	cfg    *config.Rerank
+}
+
+// NewHTTPScorer creates a scorer posting to cfg.URL.
+func NewHTTPScorer(cfg *config.Rerank) *HTTPScorer {
+	return &HTTPScorer{
+		client: &http.Client{Timeout: cfg.Timeout},
+		cfg:    cfg,
+	}
+}
+
+type rerankRequest struct {
+	Model     string   `json:"model,omitempty"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n"`
+}
+
+type rerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+// ScoreDocuments returns the relevance score of every document's chunk text to the query.
+func (s *HTTPScorer) ScoreDocuments(ctx context.Context, query string, docs []domain.Document) ([]float64, error) {
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i], _ = doc.Metadata[domain.PayloadChunkText].(string)
+	}
+
+	body, err := json.Marshal(rerankRequest{
+		Model:     s.cfg.Model,
+		Query:     query,
+		Documents: texts,
+		TopN:      len(texts),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rerank marshal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("rerank request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.APIKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rerank do: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // safe to ignore
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("rerank: status %d, body: %s", resp.StatusCode, b)
+	}
+
+	var result rerankResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("rerank decode: %w", err)
+	}
+	if len(result.Results) != len(docs) {
+		return nil, errScoreCount
+	}
+
+	scores := make([]float64, len(docs))
+	for _, r := range result.Results {
+		if r.Index < 0 || r.Index >= len(docs) {
+			return nil, fmt.Errorf("rerank: document index %d out of range", r.Index)
+		}
+		scores[r.Index] = r.RelevanceScore
+	}
+	return scores, nil
+}
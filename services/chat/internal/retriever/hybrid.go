@@ -0,0 +1,132 @@
+package retriever
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+type vectorSearcher interface {
+	RetrieveVectors(ctx context.Context, query string, vector []float64, scope domain.SearchScope) ([]domain.Document, error)
+	RetrieveDocumentChunks(ctx context.Context, vector []float64, documentIDs []string, scope domain.SearchScope) ([]domain.Document, error)
+}
+
+type keywordSearcher interface {
+	SearchFullText(ctx context.Context, query string, limit int) ([]string, error)
+}
+
+// Hybrid combines vector similarity hits with full-text hits using weighted Reciprocal Rank Fusion.
+// Keyword search is best effort: on failure the vector hits are returned unchanged.
+// The full-text side matches document titles and descriptions only, not their content,
+// so terms found only in the body of a document are left to the vector side.
+type Hybrid struct {
+	vectors  vectorSearcher
+	keywords keywordSearcher
+	cfg      *config.Qdrant
+}
+
+// NewHybrid creates a hybrid retriever on top of the vector store and the full-text searcher.
+func NewHybrid(vectors vectorSearcher, keywords keywordSearcher, cfg *config.Qdrant) *Hybrid {
+	return &Hybrid{
+		vectors:  vectors,
+		keywords: keywords,
+		cfg:      cfg,
+	}
+}
+
+// RetrieveVectors returns up to CountOfResults (or scope.Limit) chunks ranked by the fused score.
+// Document.Score keeps the cosine similarity reported by Qdrant.
+// Keyword hits outside of the scope are dropped, since their chunks are looked up in the scoped collection.
+func (h *Hybrid) RetrieveVectors(ctx context.Context, query string, vector []float64, scope domain.SearchScope) ([]domain.Document, error) {
+	vectorHits, err := h.vectors.RetrieveVectors(ctx, query, vector, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	keywordHits, err := h.keywordHits(ctx, query, vector, scope)
+	if err != nil {
+		slog.WarnContext(ctx, "keyword search failed, using vector hits only", "error", err)
+		return vectorHits, nil
+	}
+	if len(keywordHits) == 0 {
+		return vectorHits, nil
+	}
+
+	fused := fuse(
+		[]rankedList{
+			{docs: vectorHits, weight: h.cfg.Hybrid.VectorWeight},
+			{docs: keywordHits, weight: h.cfg.Hybrid.KeywordWeight},
+		},
+		h.cfg.Hybrid.RRFConstant,
+	)
+	limit := int(h.cfg.CountOfResults)
+	if scope.Limit > 0 {
+		limit = int(scope.Limit)
+	}
+	if limit > 0 && len(fused) > limit {
+		fused = fused[:limit]
+	}
+	return fused, nil
+}
+
+// keywordHits maps full-text document matches to their best chunk, keeping the full-text ranking.
+func (h *Hybrid) keywordHits(ctx context.Context, query string, vector []float64, scope domain.SearchScope) ([]domain.Document, error) {
+	ids, err := h.keywords.SearchFullText(ctx, query, h.cfg.Hybrid.KeywordCandidates)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	chunks, err := h.vectors.RetrieveDocumentChunks(ctx, vector, ids, scope)
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve chunks for keyword hits: %w", err)
+	}
+
+	// Chunks come sorted by similarity, so the first one seen per document is its best chunk.
+	best := make(map[string]domain.Document, len(ids))
+	for _, chunk := range chunks {
+		docID, _ := chunk.Metadata[domain.PayloadDocumentID].(string)
+		if _, ok := best[docID]; !ok {
+			best[docID] = chunk
+		}
+	}
+
+	hits := make([]domain.Document, 0, len(best))
+	for _, id := range ids {
+		if chunk, ok := best[id]; ok {
+			hits = append(hits, chunk)
+		}
+	}
+	return hits, nil
+}
+
+type rankedList struct {
+	docs   []domain.Document
+	weight float64
+}
+
+// fuse merges ranked lists by chunk ID with score = Σ weight / (k + rank), rank starting at 1.
+// Ties keep the order in which chunks were first seen.
+func fuse(lists []rankedList, k int) []domain.Document {
+	scores := make(map[string]float64)
+	var order []domain.Document
+	for _, list := range lists {
+		for i, doc := range list.docs {
+			if _, seen := scores[doc.ID]; !seen {
+				order = append(order, doc)
+			}
+			scores[doc.ID] += list.weight / float64(k+i+1)
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i].ID] > scores[order[j].ID]
+	})
+	return order
+}
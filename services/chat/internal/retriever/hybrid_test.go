@@ -0,0 +1,124 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+type fakeVectors struct {
+	hits   []domain.Document
+	chunks []domain.Document
+	scope  domain.SearchScope // Scope of the last RetrieveVectors call.
+}
+
+func (f *fakeVectors) RetrieveVectors(_ context.Context, _ string, _ []float64, scope domain.SearchScope) ([]domain.Document, error) {
+	f.scope = scope
+	return f.hits, nil
+}
+
+func (f *fakeVectors) RetrieveDocumentChunks(context.Context, []float64, []string, domain.SearchScope) ([]domain.Document, error) {
+	return f.chunks, nil
+}
+
+type fakeKeywords struct {
+	ids []string
+	err error
+}
+
+func (f *fakeKeywords) SearchFullText(context.Context, string, int) ([]string, error) {
+	return f.ids, f.err
+}
+
+func chunk(id, docID string) domain.Document {
+	return domain.Document{ID: id, Metadata: map[string]interface{}{domain.PayloadDocumentID: docID}}
+}
+
+func ids(docs []domain.Document) []string {
+	out := make([]string, len(docs))
+	for i, d := range docs {
+		out[i] = d.ID
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFuse(t *testing.T) {
+	vector := []domain.Document{chunk("a", "d1"), chunk("b", "d2"), chunk("c", "d3")}
+	keyword := []domain.Document{chunk("c", "d3"), chunk("d", "d4")}
+
+	got := ids(fuse([]rankedList{{docs: vector, weight: 1}, {docs: keyword, weight: 1}}, 60))
+
+	// c appears in both lists and must outrank single-list hits.
+	want := []string{"c", "a", "b", "d"}
+	if !equal(got, want) {
+		t.Errorf("fuse order = %v, want %v", got, want)
+	}
+}
+
+func TestFuseWeights(t *testing.T) {
+	vector := []domain.Document{chunk("a", "d1")}
+	keyword := []domain.Document{chunk("b", "d2")}
+
+	got := ids(fuse([]rankedList{{docs: vector, weight: 0.5}, {docs: keyword, weight: 2}}, 60))
+
+	want := []string{"b", "a"}
+	if !equal(got, want) {
+		t.Errorf("fuse order = %v, want %v", got, want)
+	}
+}
+
+func TestHybridRetrieveVectors(t *testing.T) {
+	cfg := &config.Qdrant{CountOfResults: 3}
+	cfg.Hybrid = config.HybridSearch{VectorWeight: 1, KeywordWeight: 1, RRFConstant: 60, KeywordCandidates: 10}
+
+	vectors := &fakeVectors{
+		hits: []domain.Document{chunk("a", "d1"), chunk("b", "d2"), chunk("c", "d3")},
+		// Best chunk of d4 first, then a weaker one that must be ignored.
+		chunks: []domain.Document{chunk("x", "d4"), chunk("b", "d2"), chunk("y", "d4")},
+	}
+	keywords := &fakeKeywords{ids: []string{"d4", "d2"}}
+
+	got := ids(mustRetrieve(t, NewHybrid(vectors, keywords, cfg)))
+
+	want := []string{"b", "a", "x"}
+	if !equal(got, want) {
+		t.Errorf("hybrid order = %v, want %v", got, want)
+	}
+}
+
+func TestHybridFallsBackOnKeywordError(t *testing.T) {
+	cfg := &config.Qdrant{CountOfResults: 2}
+	vectors := &fakeVectors{hits: []domain.Document{chunk("a", "d1"), chunk("b", "d2")}}
+	keywords := &fakeKeywords{err: errors.New("nats: timeout")}
+
+	got := ids(mustRetrieve(t, NewHybrid(vectors, keywords, cfg)))
+
+	want := []string{"a", "b"}
+	if !equal(got, want) {
+		t.Errorf("fallback order = %v, want %v", got, want)
+	}
+}
+
+func mustRetrieve(t *testing.T, h *Hybrid) []domain.Document {
+	t.Helper()
+	docs, err := h.RetrieveVectors(context.Background(), "query", []float64{0.1}, domain.SearchScope{})
+	if err != nil {
+		t.Fatalf("RetrieveVectors() error = %v", err)
+	}
+	return docs
+}
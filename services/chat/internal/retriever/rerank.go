@@ -0,0 +1,107 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+var errScoreCount = errors.New("scorer returned a score count different from the document count")
+
+type vectorRetriever interface {
+	RetrieveVectors(ctx context.Context, query string, vector []float64, scope domain.SearchScope) ([]domain.Document, error)
+}
+
+// scorer rates the relevance of every document to the query. Higher scores are better.
+type scorer interface {
+	ScoreDocuments(ctx context.Context, query string, docs []domain.Document) ([]float64, error)
+}
+
+// Reranker over-fetches candidates from the underlying retriever and re-orders them with a scorer,
+// e.g. the generation model or a cross-encoder. Scoring is best effort: on failure the candidates
+// keep their original order.
+type Reranker struct {
+	retriever vectorRetriever
+	scorer    scorer
+	cfg       *config.Qdrant
+}
+
+// NewReranker creates a re-ranking stage on top of retriever, which may be the vector store or Hybrid.
+func NewReranker(retriever vectorRetriever, scorer scorer, cfg *config.Qdrant) *Reranker {
+	return &Reranker{
+		retriever: retriever,
+		scorer:    scorer,
+		cfg:       cfg,
+	}
+}
+
+// RetrieveVectors fetches Rerank.Candidates chunks and returns up to Rerank.TopK of them ordered by score.
+// Chunks scoring below Rerank.Threshold are dropped, so the result may be empty.
+// Document.Score keeps the similarity reported by the underlying retriever.
+func (r *Reranker) RetrieveVectors(ctx context.Context, query string, vector []float64, scope domain.SearchScope) ([]domain.Document, error) {
+	scope.Limit = r.cfg.Rerank.Candidates
+	candidates, err := r.retriever.RetrieveVectors(ctx, query, vector, scope)
+	if err != nil {
+		return nil, err
+	}
+	topK := r.topK()
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	scoreCtx := ctx
+	if r.cfg.Rerank.Timeout > 0 {
+		var cancel context.CancelFunc
+		scoreCtx, cancel = context.WithTimeout(ctx, r.cfg.Rerank.Timeout)
+		defer cancel()
+	}
+	scores, err := r.scorer.ScoreDocuments(scoreCtx, query, candidates)
+	if err == nil && len(scores) != len(candidates) {
+		err = errScoreCount
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "re-ranking failed, keeping retrieval order", "error", err)
+		return truncate(candidates, topK), nil
+	}
+
+	ranked := make([]scoredDocument, 0, len(candidates))
+	for i, doc := range candidates {
+		if scores[i] >= r.cfg.Rerank.Threshold {
+			ranked = append(ranked, scoredDocument{doc: doc, score: scores[i]})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	docs := make([]domain.Document, len(ranked))
+	for i, d := range ranked {
+		docs[i] = d.doc
+	}
+	slog.DebugContext(ctx, "re-ranked documents", "candidates", len(candidates), "kept", min(len(docs), topK))
+	return truncate(docs, topK), nil
+}
+
+// topK returns the number of chunks to keep, CountOfResults by default.
+func (r *Reranker) topK() int {
+	if r.cfg.Rerank.TopK > 0 {
+		return r.cfg.Rerank.TopK
+	}
+	return int(r.cfg.CountOfResults)
+}
+
+type scoredDocument struct {
+	doc   domain.Document
+	score float64
+}
+
+func truncate(docs []domain.Document, limit int) []domain.Document {
+	if limit > 0 && len(docs) > limit {
+		return docs[:limit]
+	}
+	return docs
+}
@@ -0,0 +1,96 @@
+package retriever
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+type fakeScorer struct {
+	scores []float64
+	err    error
+}
+
+func (f *fakeScorer) ScoreDocuments(context.Context, string, []domain.Document) ([]float64, error) {
+	return f.scores, f.err
+}
+
+func TestRerankerRetrieveVectors(t *testing.T) {
+	candidates := []domain.Document{chunk("a", "d1"), chunk("b", "d1"), chunk("c", "d2"), chunk("d", "d3")}
+
+	tests := []struct {
+		name   string
+		rerank config.Rerank
+		scorer *fakeScorer
+		want   []string
+	}{
+		{name: "ordered by score", rerank: config.Rerank{Candidates: 4, TopK: 3},
+			scorer: &fakeScorer{scores: []float64{0.1, 0.9, 0.5, 0.7}}, want: []string{"b", "d", "c"}},
+		{name: "count of results by default", rerank: config.Rerank{Candidates: 4},
+			scorer: &fakeScorer{scores: []float64{0.1, 0.9, 0.5, 0.7}}, want: []string{"b", "d"}},
+		{name: "threshold", rerank: config.Rerank{Candidates: 4, TopK: 3, Threshold: 0.6},
+			scorer: &fakeScorer{scores: []float64{0.1, 0.9, 0.5, 0.7}}, want: []string{"b", "d"}},
+		{name: "nothing relevant", rerank: config.Rerank{Candidates: 4, TopK: 3, Threshold: 0.6},
+			scorer: &fakeScorer{scores: []float64{0.1, 0.2, 0.3, 0.4}}, want: []string{}},
+		{name: "scorer failure keeps retrieval order", rerank: config.Rerank{Candidates: 4, TopK: 3},
+			scorer: &fakeScorer{err: errors.New("timeout")}, want: []string{"a", "b", "c"}},
+		{name: "score count mismatch keeps retrieval order", rerank: config.Rerank{Candidates: 4, TopK: 3},
+			scorer: &fakeScorer{scores: []float64{1}}, want: []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vectors := &fakeVectors{hits: candidates}
+			r := NewReranker(vectors, tt.scorer, &config.Qdrant{CountOfResults: 2, Rerank: tt.rerank})
+
+			docs, err := r.RetrieveVectors(context.Background(), "q", nil, domain.SearchScope{Collection: "kant"})
+			if err != nil {
+				t.Fatalf("RetrieveVectors() error = %v", err)
+			}
+			if got := ids(docs); !equal(got, tt.want) {
+				t.Errorf("RetrieveVectors() = %v, want %v", got, tt.want)
+			}
+			if vectors.scope.Limit != tt.rerank.Candidates || vectors.scope.Collection != "kant" {
+				t.Errorf("expected %d candidates in the original scope, got %+v", tt.rerank.Candidates, vectors.scope)
+			}
+		})
+	}
+}
+
+func TestHTTPScorerScoreDocuments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		var req rerankRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.Query != "свобода" || req.Model != "bge-reranker" || len(req.Documents) != 2 || req.Documents[1] != "текст b" {
+			t.Errorf("unexpected request %+v", req)
+		}
+		fmt.Fprint(w, `{"results":[{"index":1,"relevance_score":0.8},{"index":0,"relevance_score":0.2}]}`)
+	}))
+	defer server.Close()
+
+	scorer := NewHTTPScorer(&config.Rerank{URL: server.URL, Model: "bge-reranker", APIKey: "secret"})
+	docs := []domain.Document{
+		{ID: "a", Metadata: map[string]interface{}{domain.PayloadChunkText: "текст a"}},
+		{ID: "b", Metadata: map[string]interface{}{domain.PayloadChunkText: "текст b"}},
+	}
+
+	scores, err := scorer.ScoreDocuments(context.Background(), "свобода", docs)
+	if err != nil {
+		t.Fatalf("ScoreDocuments() error = %v", err)
+	}
+	if len(scores) != 2 || scores[0] != 0.2 || scores[1] != 0.8 {
+		t.Errorf("expected scores by document index, got %v", scores)
+	}
+}
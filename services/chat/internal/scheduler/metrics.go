@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics reports the load of the generation queue.
+type Metrics struct {
+	Wait     prometheus.Histogram
+	Running  prometheus.Gauge
+	Queued   prometheus.Gauge
+	Rejected prometheus.Counter
+}
+
+// NewMetrics registers the queue metrics in reg, the default registerer when nil.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	factory := promauto.With(reg)
+
+	return &Metrics{
+		Wait: factory.NewHistogram(prometheus.HistogramOpts{
+			Name:    "chat_generation_queue_wait_seconds",
+			Help:    "Time generation requests waited for a free slot, including requests served at once",
+			Buckets: []float64{0.01, 0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		}),
+		Running: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "chat_generations_running",
+			Help: "The number of answers being generated",
+		}),
+		Queued: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "chat_generation_queue_length",
+			Help: "The number of generation requests waiting for a free slot",
+		}),
+		Rejected: factory.NewCounter(prometheus.CounterOpts{
+			Name: "chat_generation_queue_rejected_total",
+			Help: "The total number of generation requests rejected because the queue was full",
+		}),
+	}
+}
+
+func (m *Metrics) waited(d time.Duration) {
+	if m != nil {
+		m.Wait.Observe(d.Seconds())
+	}
+}
+
+func (m *Metrics) set(running, queued int) {
+	if m != nil {
+		m.Running.Set(float64(running))
+		m.Queued.Set(float64(queued))
+	}
+}
+
+func (m *Metrics) rejected() {
+	if m != nil {
+		m.Rejected.Inc()
+	}
+}
@@ -0,0 +1,199 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// waiter is a request waiting for a generation slot.
+type waiter struct {
+	userID   string
+	ready    chan struct{} // closed when the slot is granted
+	position chan int      // the latest position, replaced rather than queued
+	last     int           // the last reported position
+}
+
+// Scheduler bounds the number of concurrent generations. Requests over the limit wait in per-user queues
+// which are served round-robin, so a user sending many requests delays only themselves.
+type Scheduler struct {
+	maxRunning int
+	maxQueued  int
+	metrics    *Metrics
+	now        func() time.Time
+
+	mu      sync.Mutex
+	running int
+	queued  int
+	waiting map[string][]*waiter // per-user FIFO queues
+	users   []string             // users with waiting requests in serving order, the next one first
+}
+
+// New creates a scheduler with the limits of cfg. cfg.MaxConcurrent must be positive.
+// metrics may be nil.
+func New(cfg *config.Generation, metrics *Metrics) *Scheduler {
+	return &Scheduler{
+		maxRunning: cfg.MaxConcurrent,
+		maxQueued:  cfg.MaxQueued,
+		metrics:    metrics,
+		now:        time.Now,
+		waiting:    make(map[string][]*waiter),
+	}
+}
+
+// Acquire waits for a generation slot of the user. While the request waits, queued is called
+// with its 1-based position every time the position changes. The returned release frees the slot
+// and may be called more than once. Acquire fails with domain.ErrQueueFull when the queue is full
+// and with the context error when ctx is done before a slot is granted.
+func (s *Scheduler) Acquire(ctx context.Context, userID string, queued func(position int)) (func(), error) {
+	start := s.now()
+
+	s.mu.Lock()
+	// Nobody may overtake the waiting requests, even when a slot has just been freed
+	if s.running < s.maxRunning && s.queued == 0 {
+		s.running++
+		s.report()
+		s.mu.Unlock()
+		s.metrics.waited(0)
+		return s.releaseFunc(), nil
+	}
+	if s.maxQueued > 0 && s.queued >= s.maxQueued {
+		s.mu.Unlock()
+		s.metrics.rejected()
+		return nil, domain.ErrQueueFull
+	}
+
+	w := &waiter{userID: userID, ready: make(chan struct{}), position: make(chan int, 1)}
+	if len(s.waiting[userID]) == 0 {
+		s.users = append(s.users, userID)
+	}
+	s.waiting[userID] = append(s.waiting[userID], w)
+	s.queued++
+	s.updatePositions()
+	s.report()
+	s.mu.Unlock()
+
+	for {
+		select {
+		case <-w.ready:
+			s.metrics.waited(s.now().Sub(start))
+			return s.releaseFunc(), nil
+		case position := <-w.position:
+			queued(position)
+		case <-ctx.Done():
+			s.mu.Lock()
+			select {
+			case <-w.ready:
+				// The slot was granted at the same time, give it to the next request
+				s.running--
+				s.dispatch()
+			default:
+				s.remove(w)
+				s.updatePositions()
+			}
+			s.report()
+			s.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *Scheduler) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.running--
+			s.dispatch()
+			s.report()
+		})
+	}
+}
+
+// dispatch grants free slots to the first request of the next user in turn.
+// A user with more waiting requests moves to the end of the line. s.mu must be held.
+func (s *Scheduler) dispatch() {
+	granted := false
+	for s.running < s.maxRunning && len(s.users) > 0 {
+		userID := s.users[0]
+		queue := s.waiting[userID]
+		w := queue[0]
+		if len(queue) == 1 {
+			delete(s.waiting, userID)
+			s.users = s.users[1:]
+		} else {
+			s.waiting[userID] = queue[1:]
+			s.users = append(s.users[1:], userID)
+		}
+		s.queued--
+		s.running++
+		close(w.ready)
+		granted = true
+	}
+	if granted {
+		s.updatePositions()
+	}
+}
+
+// remove drops a waiter whose request was cancelled. s.mu must be held.
+func (s *Scheduler) remove(w *waiter) {
+	queue := s.waiting[w.userID]
+	for i, other := range queue {
+		if other != w {
+			continue
+		}
+		queue = append(queue[:i], queue[i+1:]...)
+		break
+	}
+	s.queued--
+	if len(queue) > 0 {
+		s.waiting[w.userID] = queue
+		return
+	}
+	delete(s.waiting, w.userID)
+	for i, userID := range s.users {
+		if userID == w.userID {
+			s.users = append(s.users[:i], s.users[i+1:]...)
+			break
+		}
+	}
+}
+
+// updatePositions reports the changed positions of the waiting requests. s.mu must be held.
+//
+// Slots are granted in rounds taking one request of every waiting user in turn, so the i-th request
+// of a user is preceded by the first i requests of every user and by the i-th requests of the users
+// ahead of it in the current round.
+func (s *Scheduler) updatePositions() {
+	for u, userID := range s.users {
+		for i, w := range s.waiting[userID] {
+			position := 1
+			for v, other := range s.users {
+				n := len(s.waiting[other])
+				position += min(n, i)
+				if v < u && n > i {
+					position++
+				}
+			}
+			if position == w.last {
+				continue
+			}
+			w.last = position
+			// Only the latest position matters to a waiter that has not read the previous one yet
+			select {
+			case <-w.position:
+			default:
+			}
+			w.position <- position
+		}
+	}
+}
+
+// report updates the gauges. s.mu must be held.
+func (s *Scheduler) report() {
+	s.metrics.set(s.running, s.queued)
+}
@@ -0,0 +1,185 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// request is a call of Acquire running in background.
+type request struct {
+	name      string
+	positions chan int
+	granted   chan func()
+	failed    chan error
+}
+
+// enqueue calls Acquire in background and waits until the request is queued.
+func enqueue(t *testing.T, ctx context.Context, s *Scheduler, name, userID string) *request {
+	t.Helper()
+	r := &request{name: name, positions: make(chan int, 16), granted: make(chan func(), 1), failed: make(chan error, 1)}
+
+	s.mu.Lock()
+	queued := s.queued
+	s.mu.Unlock()
+
+	go func() {
+		release, err := s.Acquire(ctx, userID, func(position int) { r.positions <- position })
+		if err != nil {
+			r.failed <- err
+			return
+		}
+		r.granted <- release
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mu.Lock()
+		n := s.queued
+		s.mu.Unlock()
+		if n > queued {
+			return r
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("request %s was not queued", name)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// lastPosition returns the latest position reported to the request.
+func (r *request) lastPosition(t *testing.T) int {
+	t.Helper()
+	position := 0
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case position = <-r.positions:
+		case <-time.After(20 * time.Millisecond):
+			if position == 0 {
+				t.Fatalf("no position reported to %s", r.name)
+			}
+			return position
+		case <-deadline:
+			t.Fatalf("positions of %s did not settle", r.name)
+		}
+	}
+}
+
+func (r *request) wait(t *testing.T) func() {
+	t.Helper()
+	select {
+	case release := <-r.granted:
+		return release
+	case err := <-r.failed:
+		t.Fatalf("request %s failed: %v", r.name, err)
+	case <-time.After(time.Second):
+		t.Fatalf("request %s was not granted a slot", r.name)
+	}
+	return nil
+}
+
+func TestSchedulerServesUsersInTurn(t *testing.T) {
+	s := New(&config.Generation{MaxConcurrent: 1}, nil)
+	ctx := context.Background()
+
+	release, err := s.Acquire(ctx, "u1", func(int) { t.Error("free slot must not be queued") })
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	// u1 floods the queue before u2 asks once
+	a := enqueue(t, ctx, s, "a", "u1")
+	b := enqueue(t, ctx, s, "b", "u1")
+	c := enqueue(t, ctx, s, "c", "u1")
+	d := enqueue(t, ctx, s, "d", "u2")
+
+	want := map[*request]int{a: 1, d: 2, b: 3, c: 4}
+	for r, position := range want {
+		if got := r.lastPosition(t); got != position {
+			t.Errorf("position of %s = %d, want %d", r.name, got, position)
+		}
+	}
+
+	for _, r := range []*request{a, d, b, c} {
+		release()
+		release = r.wait(t)
+	}
+	release()
+
+	if s.running != 0 || s.queued != 0 {
+		t.Errorf("expected an idle scheduler, got %d running and %d queued", s.running, s.queued)
+	}
+}
+
+func TestSchedulerRejectsWhenQueueIsFull(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	s := New(&config.Generation{MaxConcurrent: 1, MaxQueued: 1}, NewMetrics(reg))
+	ctx := context.Background()
+
+	release, err := s.Acquire(ctx, "u1", func(int) {})
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	defer release()
+	enqueue(t, ctx, s, "waiting", "u1")
+
+	if _, err := s.Acquire(ctx, "u2", func(int) {}); !errors.Is(err, domain.ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+	if got := testutil.ToFloat64(s.metrics.Rejected); got != 1 {
+		t.Errorf("rejected = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(s.metrics.Queued); got != 1 {
+		t.Errorf("queue length = %v, want 1", got)
+	}
+}
+
+func TestSchedulerCancelledRequestLeavesQueue(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	s := New(&config.Generation{MaxConcurrent: 1}, NewMetrics(reg))
+	ctx := context.Background()
+
+	release, err := s.Acquire(ctx, "u1", func(int) {})
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	first := enqueue(t, cancelCtx, s, "first", "u2")
+	second := enqueue(t, ctx, s, "second", "u3")
+	if got := second.lastPosition(t); got != 2 {
+		t.Fatalf("position of second = %d, want 2", got)
+	}
+
+	cancel()
+	select {
+	case err := <-first.failed:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancelled request is still waiting")
+	}
+	if got := second.lastPosition(t); got != 1 {
+		t.Errorf("position of second after cancel = %d, want 1", got)
+	}
+
+	release()
+	release() // releasing twice must not free another slot
+	second.wait(t)()
+
+	if s.running != 0 || s.queued != 0 {
+		t.Errorf("expected an idle scheduler, got %d running and %d queued", s.running, s.queued)
+	}
+	if got := testutil.CollectAndCount(s.metrics.Wait); got != 1 {
+		t.Errorf("expected the wait histogram to be collected, got %d series", got)
+	}
+}
@@ -3,19 +3,20 @@ package service
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
 	"github.com/artmexbet/raibecas/services/chat/internal/domain"
 )
 
 type vectorStore interface {
-	RetrieveVectors(ctx context.Context, vector []float64) ([]domain.Document, error)
+	RetrieveVectors(ctx context.Context, query string, vector []float64, scope domain.SearchScope) ([]domain.Document, error)
 }
 
 type neuroConnector interface {
@@ -26,31 +27,116 @@ type neuroConnector interface {
 		newPrompt string,
 		fn func(domain.ChatResponse) error,
 	) error
+	Summarize(ctx context.Context, previousSummary string, messages []domain.Message) (string, error)
+	GenerateTitle(ctx context.Context, question, answer string) (string, error)
+	// RewriteQuery returns a standalone search query for the question followed by up to variants paraphrases.
+	RewriteQuery(ctx context.Context, history []domain.Message, question string, variants int) ([]string, error)
 }
 
 type chatHistoryStore interface {
+	// RetrieveChatHistory returns the active branch of the session.
 	RetrieveChatHistory(ctx context.Context, userID, sessionID string) ([]domain.Message, error)
-	SaveMessage(ctx context.Context, userID, sessionID string, message domain.Message) error
+	// RetrieveBranch returns the branch ending at messageID without activating it.
+	RetrieveBranch(ctx context.Context, userID, sessionID, messageID string) ([]domain.Message, error)
+	// ResolveSession returns the ID of the session, or of the latest one when sessionID is empty.
+	// The ID is empty when the user has no session yet.
+	ResolveSession(ctx context.Context, userID, sessionID string) (string, error)
+	// SaveMessage stores message as a child of message.ParentID, makes it the leaf of the active branch
+	// and returns its ID.
+	SaveMessage(ctx context.Context, userID, sessionID string, message domain.Message) (string, error)
+	GetMessage(ctx context.Context, userID, sessionID, messageID string) (domain.Message, error)
+	GetLatestLeaf(ctx context.Context, userID, sessionID, messageID string) (string, error)
+	SetActiveMessage(ctx context.Context, userID, sessionID, messageID string) error
 	ClearChatHistory(ctx context.Context, userID string) error
 	GetChatSize(ctx context.Context, userID string) (int, error)
-	GetUserSessions(ctx context.Context, userID string) ([]domain.ChatSession, error)
+	GetUserSessions(ctx context.Context, userID string, archived bool, page domain.Page) (domain.SessionPage, error)
+	GetSessionMessages(ctx context.Context, userID, sessionID string, page domain.Page) (domain.MessagePage, error)
+	UpdateSession(ctx context.Context, userID, sessionID string, update domain.SessionUpdate) (domain.ChatSession, error)
+	// SetGeneratedTitle sets the title unless the session has been renamed and reports whether it was changed.
+	SetGeneratedTitle(ctx context.Context, userID, sessionID, title string) (domain.ChatSession, bool, error)
+	DeleteSession(ctx context.Context, userID, sessionID string) error
+	SearchMessages(ctx context.Context, userID, query string, page domain.Page) ([]domain.MessageSearchHit, error)
 	CreateSession(ctx context.Context, userID, title string) (string, error)
+	GetSessionSummary(ctx context.Context, userID, sessionID string) (domain.SessionSummary, error)
+	SaveSessionSummary(ctx context.Context, userID, sessionID string, summary domain.SessionSummary) error
+	SetMessageFeedback(ctx context.Context, userID, sessionID, messageID string, feedback domain.Feedback) error
+	// CreateSessionShare stores a snapshot of the active branch of the session under token.
+	CreateSessionShare(ctx context.Context, userID, sessionID, token string) (domain.SessionShare, error)
+	GetSessionShare(ctx context.Context, token string) (domain.SessionShare, error)
+	ListSessionShares(ctx context.Context, userID, sessionID string) ([]domain.SessionShare, error)
+	DeleteSessionShare(ctx context.Context, userID, token string) error
+}
+
+// corpusRegistry stores the corpora chat requests can be routed to.
+type corpusRegistry interface {
+	ListCorpora(ctx context.Context) ([]domain.Corpus, error)
+	GetCorpus(ctx context.Context, id string) (domain.Corpus, error)
+	CreateCorpus(ctx context.Context, corpus domain.Corpus) (domain.Corpus, error)
+	UpdateCorpus(ctx context.Context, corpus domain.Corpus) (domain.Corpus, error)
+	DeleteCorpus(ctx context.Context, id string) error
+}
+
+// quotaLimiter enforces per-user generation quotas.
+type quotaLimiter interface {
+	// Acquire counts a generation request and fails with domain.ErrQuotaExceeded when a quota is spent.
+	Acquire(ctx context.Context, userID, role string) (domain.QuotaUsage, error)
+	// AddTokens charges the tokens of a generation and returns the remaining quota.
+	AddTokens(ctx context.Context, userID, role string, tokens int) (domain.QuotaUsage, error)
+	// Refund returns a request counted by Acquire that never reached the model.
+	Refund(ctx context.Context, userID, role string) error
+}
+
+// generationQueue bounds the number of answers generated at the same time.
+type generationQueue interface {
+	// Acquire waits for a generation slot of the user, calling queued with the position of the request
+	// while it waits. It fails with domain.ErrQueueFull when too many requests are waiting.
+	Acquire(ctx context.Context, userID string, queued func(position int)) (release func(), err error)
+}
+
+// sessionEvents delivers session changes made in background to the connected clients of the user.
+type sessionEvents interface {
+	Publish(userID string, event domain.SessionEvent)
 }
 
 type Chat struct {
 	vectorStore  vectorStore
 	neuro        neuroConnector
 	historyStore chatHistoryStore
+	corpora      corpusRegistry
+	events       sessionEvents
+	quotas       quotaLimiter
+	queue        generationQueue
+	contextCfg   *config.ContextGeneration
 	tracer       trace.Tracer
+	summaries    sessionLocks
 }
 
 // New creates a new Chat service with the provided vector store and embedder.
 // vectorStore is used to retrieve vectors, and embedder is used to generate embeddings.
-func New(vectorStore vectorStore, neuro neuroConnector, historyStore chatHistoryStore, tracer trace.Tracer) *Chat {
+// corpora holds the collections and personas requests may select, events receives session updates
+// such as generated titles. quotas limits the generations of every user; nil leaves them unlimited.
+// queue bounds the concurrent calls of the model; with nil every request calls it at once.
+// contextCfg defines the token budget of the generation context.
+func New(
+	vectorStore vectorStore,
+	neuro neuroConnector,
+	historyStore chatHistoryStore,
+	corpora corpusRegistry,
+	events sessionEvents,
+	quotas quotaLimiter,
+	queue generationQueue,
+	contextCfg *config.ContextGeneration,
+	tracer trace.Tracer,
+) *Chat {
 	return &Chat{
 		vectorStore:  vectorStore,
 		neuro:        neuro,
 		historyStore: historyStore,
+		corpora:      corpora,
+		events:       events,
+		quotas:       quotas,
+		queue:        queue,
+		contextCfg:   contextCfg,
 		tracer:       tracer,
 	}
 }
@@ -60,92 +146,556 @@ func New(vectorStore vectorStore, neuro neuroConnector, historyStore chatHistory
 // Parameters:
 //   - ctx: context for cancellation and deadlines.
 //   - input: the input text to process.
+//   - opts: optional settings of the request, such as the corpus to answer from.
 //
 // Returns:
 //   - error: non-nil if embedding generation or vector retrieval fails.
-func (c *Chat) ProcessInput(ctx context.Context, input, userID, sessionID string, fn func(response domain.ChatResponse) error) error {
+func (c *Chat) ProcessInput(
+	ctx context.Context,
+	input, userID, sessionID string,
+	opts domain.ChatOptions,
+	fn func(response domain.ChatResponse) error,
+) error {
 	ctx, span := c.tracer.Start(ctx, "chat.service.process_input",
 		trace.WithAttributes(
 			attribute.String("chat.user_id", userID),
 			attribute.String("chat.session_id", sessionID),
+			attribute.String("chat.corpus_id", opts.CorpusID),
+		),
+	)
+	defer span.End()
+
+	// The session is fixed when the request starts, the latest session may change while it waits for the model.
+	sessionID, err := c.historyStore.ResolveSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	// Retrieve chat history
+	var history []domain.Message
+	if sessionID != "" {
+		history, err = c.historyStore.RetrieveChatHistory(ctx, userID, sessionID)
+		if err != nil {
+			if isSessionError(err) {
+				return err
+			}
+			slog.WarnContext(ctx, "could not retrieve chat history", "error", err)
+			history = []domain.Message{}
+		}
+	}
+
+	return c.generate(ctx, span, generation{
+		userID:    userID,
+		sessionID: sessionID,
+		input:     input,
+		history:   history,
+		saveInput: true,
+		parentID:  lastMessageID(history),
+		opts:      opts,
+	}, fn)
+}
+
+// Regenerate replaces the last assistant answer of the active branch with a new one.
+// The previous answer is kept as a sibling branch that can be activated with SwitchBranch.
+func (c *Chat) Regenerate(
+	ctx context.Context,
+	userID, sessionID string,
+	opts domain.ChatOptions,
+	fn func(response domain.ChatResponse) error,
+) error {
+	ctx, span := c.tracer.Start(ctx, "chat.service.regenerate",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.String("chat.session_id", sessionID),
+			attribute.String("chat.corpus_id", opts.CorpusID),
+		),
+	)
+	defer span.End()
+
+	sessionID, err := c.historyStore.ResolveSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if sessionID == "" {
+		return domain.ErrNothingToRegenerate
+	}
+	history, err := c.historyStore.RetrieveChatHistory(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	// The prompt is the last user message; an answer after it, if any, becomes an alternative.
+	promptIdx := -1
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role == "user" {
+			promptIdx = i
+			break
+		}
+	}
+	if promptIdx < 0 {
+		return domain.ErrNothingToRegenerate
+	}
+	prompt := history[promptIdx]
+
+	return c.generate(ctx, span, generation{
+		userID:    userID,
+		sessionID: sessionID,
+		input:     prompt.Content,
+		history:   history[:promptIdx],
+		parentID:  prompt.ID,
+		branch:    true,
+		active:    history,
+		opts:      opts,
+	}, fn)
+}
+
+// EditMessage answers input as a new version of the user message messageID.
+// The new prompt becomes a sibling of the edited one, so the original conversation stays available as another branch.
+func (c *Chat) EditMessage(
+	ctx context.Context,
+	userID, sessionID, messageID, input string,
+	opts domain.ChatOptions,
+	fn func(response domain.ChatResponse) error,
+) error {
+	ctx, span := c.tracer.Start(ctx, "chat.service.edit_message",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.String("chat.session_id", sessionID),
+			attribute.String("chat.message_id", messageID),
+			attribute.String("chat.corpus_id", opts.CorpusID),
+		),
+	)
+	defer span.End()
+
+	sessionID, err := c.historyStore.ResolveSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if sessionID == "" {
+		return domain.ErrMessageNotFound
+	}
+	message, err := c.historyStore.GetMessage(ctx, userID, sessionID, messageID)
+	if err != nil {
+		return err
+	}
+	if message.Role != "user" {
+		return domain.ErrNotUserMessage
+	}
+
+	active, err := c.historyStore.RetrieveChatHistory(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	// The new prompt continues the branch ending at the parent of the edited message.
+	history, err := c.historyStore.RetrieveBranch(ctx, userID, sessionID, message.ParentID)
+	if err != nil {
+		return err
+	}
+
+	return c.generate(ctx, span, generation{
+		userID:    userID,
+		sessionID: sessionID,
+		input:     input,
+		history:   history,
+		saveInput: true,
+		parentID:  message.ParentID,
+		branch:    true,
+		active:    active,
+		opts:      opts,
+	}, fn)
+}
+
+// SwitchBranch activates the newest branch going through messageID and returns its messages.
+func (c *Chat) SwitchBranch(ctx context.Context, userID, sessionID, messageID string) ([]domain.Message, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.switch_branch",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.String("chat.session_id", sessionID),
+			attribute.String("chat.message_id", messageID),
 		),
 	)
 	defer span.End()
 
-	embedding, err := c.neuro.GenerateEmbeddings(ctx, input)
+	leafID, err := c.historyStore.GetLatestLeaf(ctx, userID, sessionID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := c.historyStore.RetrieveChatHistory(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.moveBranch(ctx, userID, sessionID, history, leafID); err != nil {
+		return nil, err
+	}
+
+	return c.historyStore.RetrieveChatHistory(ctx, userID, sessionID)
+}
+
+// moveBranch points the active branch at messageID.
+// The session summary covers a prefix of the active branch, so it is reset when the new branch does not share it.
+func (c *Chat) moveBranch(ctx context.Context, userID, sessionID string, current []domain.Message, messageID string) error {
+	if err := c.historyStore.SetActiveMessage(ctx, userID, sessionID, messageID); err != nil {
+		return err
+	}
+
+	summary, err := c.historyStore.GetSessionSummary(ctx, userID, sessionID)
+	if err != nil || summary.MessageCount == 0 {
+		return nil
+	}
+
+	if summary.MessageCount > sharedMessages(current, messageID) {
+		if err := c.historyStore.SaveSessionSummary(ctx, userID, sessionID, domain.SessionSummary{}); err != nil {
+			slog.WarnContext(ctx, "could not reset session summary", "error", err)
+		}
+	}
+	return nil
+}
+
+// sharedMessages returns how many messages of the current branch a branch ending at messageID keeps.
+func sharedMessages(current []domain.Message, messageID string) int {
+	if messageID == "" {
+		return 0
+	}
+	for i, m := range current {
+		if m.ID == messageID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// generation describes a single answer to produce.
+type generation struct {
+	userID    string
+	sessionID string
+	input     string
+	history   []domain.Message // Branch preceding the prompt.
+	// saveInput stores input as a new user message, a child of parentID.
+	// Otherwise the prompt is already stored as parentID.
+	// The answer is stored as a child of the prompt, so that concurrent requests do not mix their branches.
+	saveInput bool
+	parentID  string
+	// branch moves the active branch from active to parentID once the request is scheduled,
+	// so that regenerated and edited answers start a sibling of the current one.
+	branch bool
+	active []domain.Message
+	opts   domain.ChatOptions
+}
+
+// generate retrieves documents for the prompt, fits the context and streams the answer,
+// appending it to the active branch.
+func (c *Chat) generate(ctx context.Context, span trace.Span, g generation, fn func(response domain.ChatResponse) error) error {
+	scope, persona, err := c.resolveScope(ctx, g.opts)
 	if err != nil {
+		return err
+	}
+	if err := c.acquireQuota(ctx, g); err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "embedding generation failed")
-		return fmt.Errorf("could not generate embeddings: %w", err)
+		span.SetStatus(codes.Error, "quota exceeded")
+		return err
 	}
+	// The request is charged only once the model answers it: failed lookups, a full queue
+	// or a cancellation while queued give the request back
+	scheduled := false
+	defer func() {
+		if !scheduled {
+			c.refundQuota(context.WithoutCancel(ctx), g)
+		}
+	}()
 
-	docs, err := c.vectorStore.RetrieveVectors(ctx, embedding)
+	queries := c.searchQueries(ctx, g.history, g.input)
+	docs, err := c.retrieve(ctx, queries, scope)
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "vector retrieval failed")
-		return fmt.Errorf("could not retrieve vectors: %w", err)
+		span.SetStatus(codes.Error, "retrieval failed")
+		return err
 	}
-	span.SetAttributes(attribute.Int("chat.docs_count", len(docs)))
+	span.SetAttributes(
+		attribute.Int("chat.queries_count", len(queries)),
+		attribute.Int("chat.docs_count", len(docs)),
+	)
 	slog.DebugContext(ctx, "retrieved documents", "count", len(docs), "docs", docs)
 
-	// Retrieve chat history from Redis
-	history, err := c.historyStore.RetrieveChatHistory(ctx, userID, sessionID)
+	// The summary covers a prefix of the active branch, a new branch may only reuse the part it keeps.
+	covered := len(g.history)
+	if g.branch {
+		covered = min(covered, sharedMessages(g.active, g.parentID))
+	}
+	stored, err := c.historyStore.GetSessionSummary(ctx, g.userID, g.sessionID)
+	summary := stored
+	if err != nil || summary.MessageCount > covered {
+		if err != nil {
+			slog.WarnContext(ctx, "could not retrieve session summary", "error", err)
+		}
+		summary = domain.SessionSummary{}
+	}
+
+	contextCfg := persona.Apply(*c.contextCfg)
+	workingContext, dropped := domain.WorkingContext{
+		Messages: g.history[summary.MessageCount:],
+		Docs:     docs,
+		Summary:  summary.Content,
+		Persona:  persona,
+	}.FitToBudget(g.input, contextCfg)
+	promptTokens := workingContext.EstimateTokens(g.input, contextCfg)
+	span.SetAttributes(
+		attribute.Int("chat.context_messages", len(workingContext.Messages)),
+		attribute.Int("chat.context_docs", len(workingContext.Docs)),
+		attribute.Int("chat.dropped_messages", len(dropped)),
+	)
+	citations := domain.NewCitations(workingContext.Docs)
+
+	// Wait for the model before moving the branch or storing the prompt:
+	// a request that is not scheduled leaves the active branch as it was
+	release, err := c.waitForSlot(ctx, span, g, fn)
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidChatSessionID) || errors.Is(err, domain.ErrChatSessionNotFound) {
-			return err
+		if errors.Is(ctx.Err(), context.Canceled) {
+			// Cancelled while queued, the model has not read the prompt
+			span.SetAttributes(attribute.Bool("chat.truncated", true))
+			promptID := g.parentID
+			if g.saveInput {
+				promptID = ""
+			}
+			c.finishTruncated(ctx, g, promptID, "", citations, 0, fn)
+			return nil
 		}
-		slog.WarnContext(ctx, "could not retrieve chat history", "error", err)
-		history = []domain.Message{}
+		return err
 	}
 
-	// Add user message to history
-	userMessage := domain.Message{
-		Role:    "user",
-		Content: input,
+	if g.branch {
+		if err := c.moveBranch(ctx, g.userID, g.sessionID, g.active, g.parentID); err != nil {
+			release()
+			return err
+		}
 	}
-	err = c.historyStore.SaveMessage(ctx, userID, sessionID, userMessage)
-	if err != nil {
-		if errors.Is(err, domain.ErrInvalidChatSessionID) || errors.Is(err, domain.ErrChatSessionNotFound) {
+
+	if g.sessionID == "" {
+		// The first request of the user starts a session
+		g.sessionID, err = c.historyStore.CreateSession(ctx, g.userID, "")
+		if err != nil {
+			release()
 			return err
 		}
-		slog.WarnContext(ctx, "could not save user message", "error", err)
 	}
 
-	workingContext := domain.WorkingContext{
-		Messages: history,
-		Docs:     docs,
+	// Add user message to history
+	parentID := g.parentID
+	if g.saveInput {
+		userMessage := domain.Message{
+			ParentID: g.parentID,
+			Role:     "user",
+			Content:  g.input,
+		}
+		promptID, err := c.historyStore.SaveMessage(ctx, g.userID, g.sessionID, userMessage)
+		switch {
+		case err == nil:
+			parentID = promptID
+		case isSessionError(err):
+			release()
+			return err
+		default:
+			slog.WarnContext(ctx, "could not save user message", "error", err)
+		}
 	}
 
 	// Process response with message chunking and saving
 	assistantContent := strings.Builder{}
-	err = c.neuro.Chat(ctx, workingContext, input, func(response domain.ChatResponse) error {
+	completed := false
+	scheduled = true
+	err = c.neuro.Chat(ctx, workingContext, g.input, func(response domain.ChatResponse) error {
 		// Accumulate message chunks
 		if response.Message != nil && response.Message.Content != "" {
 			assistantContent.WriteString(response.Message.Content)
 		}
 
-		// If message is complete, save full message to history
+		// If message is complete, save full message with its sources to history
 		if response.Done {
+			completed = true
+			response.Citations = citations
+			response.Quota = c.chargeTokens(ctx, g, promptTokens, assistantContent.String())
 			fullMessage := domain.Message{
-				Role:    "assistant",
-				Content: assistantContent.String(),
+				ParentID:  parentID,
+				Role:      "assistant",
+				Content:   assistantContent.String(),
+				Citations: citations,
 			}
 			slog.DebugContext(ctx, "Sending chat response chunk")
-			err := c.historyStore.SaveMessage(ctx, userID, sessionID, fullMessage)
+			id, err := c.historyStore.SaveMessage(ctx, g.userID, g.sessionID, fullMessage)
 			if err != nil {
-				if errors.Is(err, domain.ErrInvalidChatSessionID) || errors.Is(err, domain.ErrChatSessionNotFound) {
+				if isSessionError(err) {
 					return err
 				}
 
 				slog.WarnContext(ctx, "could not save assistant message", "error", err)
 			}
+			response.Message = withBranchIDs(response.Message, id, parentID)
 		}
 
 		// Pass response to handler (streaming)
 		return fn(response)
 	})
+	release()
+	if !completed && errors.Is(ctx.Err(), context.Canceled) {
+		span.SetAttributes(attribute.Bool("chat.truncated", true))
+		c.finishTruncated(ctx, g, parentID, assistantContent.String(), citations, promptTokens, fn)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(dropped) > 0 && c.contextCfg.SummarizeHistory {
+		// The answer is already delivered, so the summary must neither delay the request nor depend on the client staying connected.
+		go c.rollUpSummary(context.WithoutCancel(ctx), g.userID, g.sessionID, stored, summary, dropped)
+	}
+	if len(g.history) == 0 && c.contextCfg.GenerateTitles {
+		go c.generateTitle(context.WithoutCancel(ctx), g.userID, g.sessionID, g.input, assistantContent.String())
+	}
+	return nil
+}
+
+// generateTitle names the session after its first exchange and notifies the user's clients.
+// The session keeps its default title when the model is unavailable or the user renamed it meanwhile.
+func (c *Chat) generateTitle(ctx context.Context, userID, sessionID, question, answer string) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.generate_title",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.String("chat.session_id", sessionID),
+		),
+	)
+	defer span.End()
+
+	if c.contextCfg.TitleTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.contextCfg.TitleTimeout)
+		defer cancel()
+	}
+
+	title, err := c.neuro.GenerateTitle(ctx, question, answer)
+	if err != nil {
+		span.RecordError(err)
+		slog.WarnContext(ctx, "could not generate session title", "error", err)
+		return
+	}
+
+	session, updated, err := c.historyStore.SetGeneratedTitle(ctx, userID, sessionID, title)
+	if err != nil {
+		span.RecordError(err)
+		slog.WarnContext(ctx, "could not save session title", "error", err)
+		return
+	}
+	span.SetAttributes(attribute.Bool("chat.title_updated", updated))
+	if updated {
+		c.events.Publish(userID, domain.SessionEvent{Type: domain.SessionEventUpdated, Session: session})
+	}
+}
+
+// finishTruncated persists the partially generated answer of a cancelled request flagged as truncated
+// and sends a final chunk so that clients still listening can close the answer.
+func (c *Chat) finishTruncated(
+	ctx context.Context,
+	g generation,
+	parentID, content string,
+	citations []domain.Citation,
+	promptTokens int,
+	fn func(response domain.ChatResponse) error,
+) {
+	slog.InfoContext(ctx, "chat generation cancelled", "user_id", g.userID, "generated_bytes", len(content))
+	var id string
+	if content != "" {
+		var err error
+		// The request context is already cancelled.
+		id, err = c.historyStore.SaveMessage(context.WithoutCancel(ctx), g.userID, g.sessionID, domain.Message{
+			ParentID:  parentID,
+			Role:      "assistant",
+			Content:   content,
+			Citations: citations,
+			Truncated: true,
+		})
+		if err != nil {
+			slog.WarnContext(ctx, "could not save truncated assistant message", "error", err)
+		}
+	}
+
+	err := fn(domain.ChatResponse{
+		Done:      true,
+		Message:   withBranchIDs(nil, id, parentID),
+		CreatedAt: time.Now(),
+		Citations: citations,
+		Truncated: true,
+		// The tokens were spent even though the user stopped the answer.
+		Quota: c.chargeTokens(context.WithoutCancel(ctx), g, promptTokens, content),
+	})
+	if err != nil {
+		slog.DebugContext(ctx, "could not deliver truncated response", "error", err)
+	}
+}
+
+// withBranchIDs returns a copy of the final answer chunk message carrying the stored answer ID
+// and the ID of its prompt, so that clients can regenerate or edit it later.
+func withBranchIDs(message *domain.Message, id, parentID string) *domain.Message {
+	result := domain.Message{Role: "assistant"}
+	if message != nil {
+		result = *message
+	}
+	result.ID = id
+	result.ParentID = parentID
+	return &result
+}
+
+// lastMessageID returns the ID of the leaf of branch, or an empty ID for an empty branch.
+func lastMessageID(branch []domain.Message) string {
+	if len(branch) == 0 {
+		return ""
+	}
+	return branch[len(branch)-1].ID
+}
+
+// isSessionError reports whether err is caused by the requested session rather than by the storage.
+func isSessionError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidChatSessionID) || errors.Is(err, domain.ErrChatSessionNotFound)
+}
+
+// rollUpSummary folds messages that no longer fit into the context into the stored session summary.
+// stored is the summary read by the request and summary the one its context was built on.
+// Roll-ups of a session run one at a time; one whose stored summary has been replaced meanwhile is skipped.
+// Failures are logged. Either way the messages stay in history and are summarized on a later turn.
+func (c *Chat) rollUpSummary(ctx context.Context, userID, sessionID string, stored, summary domain.SessionSummary, dropped []domain.Message) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.roll_up_summary",
+		trace.WithAttributes(attribute.Int("chat.summarized_messages", len(dropped))),
+	)
+	defer span.End()
+
+	unlock := c.summaries.lock(sessionID)
+	defer unlock()
+
+	current, err := c.historyStore.GetSessionSummary(ctx, userID, sessionID)
+	if err != nil {
+		span.RecordError(err)
+		slog.WarnContext(ctx, "could not retrieve session summary", "error", err)
+		return
+	}
+	if current != stored {
+		span.SetAttributes(attribute.Bool("chat.summary_outdated", true))
+		return
+	}
+
+	content, err := c.neuro.Summarize(ctx, summary.Content, dropped)
+	if err != nil {
+		span.RecordError(err)
+		slog.WarnContext(ctx, "could not summarize chat history", "error", err)
+		return
+	}
 
-	return err
+	err = c.historyStore.SaveSessionSummary(ctx, userID, sessionID, domain.SessionSummary{
+		Content:      content,
+		MessageCount: summary.MessageCount + len(dropped),
+	})
+	if err != nil {
+		span.RecordError(err)
+		slog.WarnContext(ctx, "could not save session summary", "error", err)
+	}
 }
 
 // ClearUserChat clears all chat history for a user
@@ -158,14 +708,104 @@ func (c *Chat) ClearUserChat(ctx context.Context, userID string) error {
 	return c.historyStore.ClearChatHistory(ctx, userID)
 }
 
-// GetUserSessions returns all chat sessions with messages for a user
-func (c *Chat) GetUserSessions(ctx context.Context, userID string) ([]domain.ChatSession, error) {
+// GetUserSessions returns a page of the user's active or archived chat sessions
+func (c *Chat) GetUserSessions(ctx context.Context, userID string, archived bool, page domain.Page) (domain.SessionPage, error) {
 	ctx, span := c.tracer.Start(ctx, "chat.service.get_sessions",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.Bool("chat.archived", archived),
+		),
+	)
+	defer span.End()
+
+	return c.historyStore.GetUserSessions(ctx, userID, archived, page.Normalize())
+}
+
+// GetSessionMessages returns a page of the active branch of a session, newest page first
+func (c *Chat) GetSessionMessages(ctx context.Context, userID, sessionID string, page domain.Page) (domain.MessagePage, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.get_session_messages",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.String("chat.session_id", sessionID),
+		),
+	)
+	defer span.End()
+
+	return c.historyStore.GetSessionMessages(ctx, userID, sessionID, page.Normalize())
+}
+
+// UpdateSession renames, archives or restores a chat session
+func (c *Chat) UpdateSession(ctx context.Context, userID, sessionID string, update domain.SessionUpdate) (domain.ChatSession, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.update_session",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.String("chat.session_id", sessionID),
+		),
+	)
+	defer span.End()
+
+	if update.Title != nil {
+		title := strings.TrimSpace(*update.Title)
+		if title == "" {
+			return domain.ChatSession{}, domain.ErrInvalidSessionTitle
+		}
+		update.Title = &title
+	}
+	return c.historyStore.UpdateSession(ctx, userID, sessionID, update)
+}
+
+// SetFeedback rates an assistant answer of the session. FeedbackNone removes the rating.
+func (c *Chat) SetFeedback(ctx context.Context, userID, sessionID, messageID string, feedback domain.Feedback) error {
+	ctx, span := c.tracer.Start(ctx, "chat.service.set_feedback",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.String("chat.session_id", sessionID),
+			attribute.String("chat.message_id", messageID),
+			attribute.Int("chat.feedback_rating", feedback.Rating),
+		),
+	)
+	defer span.End()
+
+	feedback.Comment = strings.TrimSpace(feedback.Comment)
+	if err := feedback.Validate(); err != nil {
+		return err
+	}
+
+	msg, err := c.historyStore.GetMessage(ctx, userID, sessionID, messageID)
+	if err != nil {
+		return err
+	}
+	if msg.Role != "assistant" {
+		return domain.ErrNotAssistantMessage
+	}
+	return c.historyStore.SetMessageFeedback(ctx, userID, sessionID, messageID, feedback)
+}
+
+// DeleteSession deletes a chat session with all of its messages
+func (c *Chat) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	ctx, span := c.tracer.Start(ctx, "chat.service.delete_session",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.String("chat.session_id", sessionID),
+		),
+	)
+	defer span.End()
+
+	return c.historyStore.DeleteSession(ctx, userID, sessionID)
+}
+
+// SearchMessages finds the user's chat messages matching query
+func (c *Chat) SearchMessages(ctx context.Context, userID, query string, page domain.Page) ([]domain.MessageSearchHit, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.search_messages",
 		trace.WithAttributes(attribute.String("chat.user_id", userID)),
 	)
 	defer span.End()
 
-	return c.historyStore.GetUserSessions(ctx, userID)
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, domain.ErrEmptySearchQuery
+	}
+	return c.historyStore.SearchMessages(ctx, userID, query, page.Normalize())
 }
 
 // CreateSession creates a new chat session for a user
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// fakeHistory returns a single message and records saved feedback.
+type fakeHistory struct {
+	chatHistoryStore
+	message  domain.Message
+	feedback *domain.Feedback
+}
+
+func (f *fakeHistory) GetMessage(context.Context, string, string, string) (domain.Message, error) {
+	if f.message.ID == "" {
+		return domain.Message{}, domain.ErrMessageNotFound
+	}
+	return f.message, nil
+}
+
+func (f *fakeHistory) SetMessageFeedback(_ context.Context, _, _, _ string, feedback domain.Feedback) error {
+	f.feedback = &feedback
+	return nil
+}
+
+func TestSetFeedback(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  domain.Message
+		feedback domain.Feedback
+		wantErr  error
+		want     *domain.Feedback
+	}{
+		{name: "answer", message: domain.Message{ID: "m1", Role: "assistant"},
+			feedback: domain.Feedback{Rating: domain.FeedbackNegative, Comment: "  Не по тексту  "},
+			want:     &domain.Feedback{Rating: domain.FeedbackNegative, Comment: "Не по тексту"}},
+		{name: "question", message: domain.Message{ID: "m1", Role: "user"},
+			feedback: domain.Feedback{Rating: domain.FeedbackPositive}, wantErr: domain.ErrNotAssistantMessage},
+		{name: "missing message", feedback: domain.Feedback{Rating: domain.FeedbackPositive}, wantErr: domain.ErrMessageNotFound},
+		{name: "invalid rating", message: domain.Message{ID: "m1", Role: "assistant"},
+			feedback: domain.Feedback{Rating: 2}, wantErr: domain.ErrInvalidFeedback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &fakeHistory{message: tt.message}
+			c := New(nil, nil, history, nil, nil, nil, nil, &config.ContextGeneration{}, noop.NewTracerProvider().Tracer("test"))
+
+			err := c.SetFeedback(context.Background(), "u1", "s1", "m1", tt.feedback)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetFeedback() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				if history.feedback != nil {
+					t.Errorf("expected no feedback saved, got %+v", history.feedback)
+				}
+				return
+			}
+			if history.feedback == nil || *history.feedback != *tt.want {
+				t.Errorf("saved feedback %+v, want %+v", history.feedback, tt.want)
+			}
+		})
+	}
+}
+
+// branchHistory is a session of two exchanges whose active branch ends at the last answer.
+// It records branch moves and saved messages.
+type branchHistory struct {
+	emptyHistory
+	messages []domain.Message
+	active   string
+	saved    []domain.Message
+}
+
+func newBranchHistory() *branchHistory {
+	return &branchHistory{
+		messages: []domain.Message{
+			{ID: "u1", Role: "user", Content: "Что такое категорический императив?"},
+			{ID: "a1", ParentID: "u1", Role: "assistant", Content: "..."},
+			{ID: "u2", ParentID: "a1", Role: "user", Content: "А гипотетический?"},
+			{ID: "a2", ParentID: "u2", Role: "assistant", Content: "..."},
+		},
+		active: "a2",
+	}
+}
+
+func (f *branchHistory) RetrieveChatHistory(context.Context, string, string) ([]domain.Message, error) {
+	return f.branchTo(f.active), nil
+}
+
+func (f *branchHistory) RetrieveBranch(_ context.Context, _, _, messageID string) ([]domain.Message, error) {
+	return f.branchTo(messageID), nil
+}
+
+func (f *branchHistory) GetMessage(_ context.Context, _, _, messageID string) (domain.Message, error) {
+	for _, m := range f.messages {
+		if m.ID == messageID {
+			return m, nil
+		}
+	}
+	return domain.Message{}, domain.ErrMessageNotFound
+}
+
+func (f *branchHistory) SetActiveMessage(_ context.Context, _, _, messageID string) error {
+	f.active = messageID
+	return nil
+}
+
+func (f *branchHistory) SaveMessage(_ context.Context, _, _ string, message domain.Message) (string, error) {
+	f.saved = append(f.saved, message)
+	return "new", nil
+}
+
+func (f *branchHistory) branchTo(messageID string) []domain.Message {
+	for i, m := range f.messages {
+		if m.ID == messageID {
+			return f.messages[:i+1]
+		}
+	}
+	return nil
+}
+
+func TestBranchingRejectedRequestKeepsActiveBranch(t *testing.T) {
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, -1, 0)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		opts    domain.ChatOptions
+		quotas  *fakeQuota
+		queue   *fakeQueue
+		wantErr error
+	}{
+		{name: "invalid filters", ctx: context.Background(),
+			opts:    domain.ChatOptions{Filters: domain.SearchFilters{PublishedFrom: &from, PublishedTo: &to}},
+			wantErr: domain.ErrInvalidFilters},
+		{name: "unknown corpus", ctx: context.Background(), opts: domain.ChatOptions{CorpusID: "hegel"},
+			wantErr: domain.ErrCorpusNotFound},
+		{name: "quota exceeded", ctx: context.Background(),
+			quotas:  &fakeQuota{acquireErr: &domain.QuotaExceededError{Quota: domain.QuotaRequestsPerMinute}},
+			wantErr: domain.ErrQuotaExceeded},
+		{name: "queue full", ctx: context.Background(), queue: &fakeQueue{err: domain.ErrQueueFull},
+			wantErr: domain.ErrQueueFull},
+		{name: "cancelled in queue", ctx: cancelled, queue: &fakeQueue{err: context.Canceled}},
+	}
+
+	requests := map[string]func(c *Chat, ctx context.Context, opts domain.ChatOptions) error{
+		"regenerate": func(c *Chat, ctx context.Context, opts domain.ChatOptions) error {
+			return c.Regenerate(ctx, "u1", "s1", opts, func(domain.ChatResponse) error { return nil })
+		},
+		"edit": func(c *Chat, ctx context.Context, opts domain.ChatOptions) error {
+			return c.EditMessage(ctx, "u1", "s1", "u2", "А ассерторический?", opts, func(domain.ChatResponse) error { return nil })
+		},
+	}
+
+	for request, send := range requests {
+		for _, tt := range tests {
+			t.Run(request+"/"+tt.name, func(t *testing.T) {
+				history := newBranchHistory()
+				neuro := &answeringNeuro{}
+				var quotas quotaLimiter
+				if tt.quotas != nil {
+					quotas = tt.quotas
+				}
+				var queue generationQueue
+				if tt.queue != nil {
+					queue = tt.queue
+				}
+				c := New(&fakeVectorStore{}, neuro, history, fakeCorpora{}, nil, quotas, queue,
+					&config.ContextGeneration{CharsPerToken: 3}, noop.NewTracerProvider().Tracer("test"))
+
+				err := send(c, tt.ctx, tt.opts)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if history.active != "a2" {
+					t.Errorf("active branch moved to %q, want it to stay at a2", history.active)
+				}
+				if len(history.saved) != 0 || neuro.calls != 0 {
+					t.Errorf("a rejected request must not be answered, saved %+v, model called %d times", history.saved, neuro.calls)
+				}
+			})
+		}
+	}
+}
+
+func TestBranchingMovesActiveBranchWhenScheduled(t *testing.T) {
+	tests := []struct {
+		name       string
+		send       func(c *Chat) error
+		wantActive string
+		wantSaved  []string
+	}{
+		{
+			name: "regenerate",
+			send: func(c *Chat) error {
+				return c.Regenerate(context.Background(), "u1", "s1", domain.ChatOptions{}, func(domain.ChatResponse) error { return nil })
+			},
+			wantActive: "u2",
+			wantSaved:  []string{"assistant"},
+		},
+		{
+			name: "edit",
+			send: func(c *Chat) error {
+				return c.EditMessage(context.Background(), "u1", "s1", "u2", "А ассерторический?", domain.ChatOptions{},
+					func(domain.ChatResponse) error { return nil })
+			},
+			wantActive: "a1",
+			wantSaved:  []string{"user", "assistant"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := newBranchHistory()
+			c := New(&fakeVectorStore{}, &answeringNeuro{}, history, nil, nil, nil, &fakeQueue{},
+				&config.ContextGeneration{CharsPerToken: 3}, noop.NewTracerProvider().Tracer("test"))
+
+			if err := tt.send(c); err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if history.active != tt.wantActive {
+				t.Errorf("active branch = %q, want %q before the answer is saved", history.active, tt.wantActive)
+			}
+			if len(history.saved) != len(tt.wantSaved) {
+				t.Fatalf("saved %+v, want roles %v", history.saved, tt.wantSaved)
+			}
+			for i, role := range tt.wantSaved {
+				if history.saved[i].Role != role {
+					t.Errorf("saved message %d has role %q, want %q", i, history.saved[i].Role, role)
+				}
+			}
+		})
+	}
+}
+
+// fakeCorpora knows no corpora.
+type fakeCorpora struct {
+	corpusRegistry
+}
+
+func (fakeCorpora) GetCorpus(context.Context, string) (domain.Corpus, error) {
+	return domain.Corpus{}, domain.ErrCorpusNotFound
+}
+
+// treeHistory stores messages with their parents and records the session each one was saved to.
+type treeHistory struct {
+	emptyHistory
+	mu       sync.Mutex
+	saved    []domain.Message
+	sessions []string
+}
+
+func (f *treeHistory) ResolveSession(_ context.Context, _, sessionID string) (string, error) {
+	if sessionID == "" {
+		return "latest", nil
+	}
+	return sessionID, nil
+}
+
+func (f *treeHistory) SaveMessage(_ context.Context, _, sessionID string, message domain.Message) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	message.ID = fmt.Sprintf("m%d", len(f.saved)+1)
+	f.saved = append(f.saved, message)
+	f.sessions = append(f.sessions, sessionID)
+	return message.ID, nil
+}
+
+// noDocs finds nothing and is safe for concurrent requests.
+type noDocs struct{}
+
+func (noDocs) RetrieveVectors(context.Context, string, []float64, domain.SearchScope) ([]domain.Document, error) {
+	return nil, nil
+}
+
+// echoNeuro answers every prompt with the prompt itself once all expected prompts have been sent to it.
+type echoNeuro struct {
+	fakeNeuro
+	started sync.WaitGroup
+}
+
+func (f *echoNeuro) Chat(_ context.Context, _ domain.WorkingContext, prompt string, fn func(domain.ChatResponse) error) error {
+	f.started.Done()
+	f.started.Wait()
+	return fn(domain.ChatResponse{Done: true, Message: &domain.Message{Role: "assistant", Content: prompt}})
+}
+
+func TestConcurrentRequestsAnswerTheirOwnPrompts(t *testing.T) {
+	history := &treeHistory{}
+	neuro := &echoNeuro{}
+	neuro.started.Add(2)
+	c := New(noDocs{}, neuro, history, nil, nil, nil, nil,
+		&config.ContextGeneration{CharsPerToken: 3}, noop.NewTracerProvider().Tracer("test"))
+
+	// Both prompts are stored before either answer, as with two frames sent at once on a connection.
+	var wg sync.WaitGroup
+	for _, input := range []string{"Вопрос A", "Вопрос B"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := c.ProcessInput(context.Background(), input, "u1", "", domain.ChatOptions{},
+				func(domain.ChatResponse) error { return nil })
+			if err != nil {
+				t.Errorf("ProcessInput(%q): %v", input, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	prompts := map[string]string{}
+	for _, m := range history.saved {
+		if m.Role == "user" {
+			prompts[m.ID] = m.Content
+		}
+	}
+	if len(history.saved) != 4 || len(prompts) != 2 {
+		t.Fatalf("expected two prompts and two answers, got %+v", history.saved)
+	}
+	for _, m := range history.saved {
+		if m.Role == "assistant" && prompts[m.ParentID] != m.Content {
+			t.Errorf("answer %q is a child of %q, want its own prompt", m.Content, prompts[m.ParentID])
+		}
+	}
+	for i, sessionID := range history.sessions {
+		if sessionID != "latest" {
+			t.Errorf("message %d saved to session %q, want the session resolved when the request started", i, sessionID)
+		}
+	}
+}
+
+// summaryHistory stores the summary of a single session.
+type summaryHistory struct {
+	emptyHistory
+	mu      sync.Mutex
+	summary domain.SessionSummary
+	saves   int
+}
+
+func (f *summaryHistory) GetSessionSummary(context.Context, string, string) (domain.SessionSummary, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.summary, nil
+}
+
+func (f *summaryHistory) SaveSessionSummary(_ context.Context, _, _ string, summary domain.SessionSummary) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.summary = summary
+	f.saves++
+	return nil
+}
+
+// summarizingNeuro records how many Summarize calls run at once.
+type summarizingNeuro struct {
+	fakeNeuro
+	mu      sync.Mutex
+	running int
+	overlap bool
+	calls   int
+}
+
+func (f *summarizingNeuro) Summarize(_ context.Context, previous string, messages []domain.Message) (string, error) {
+	f.mu.Lock()
+	f.running++
+	f.calls++
+	f.overlap = f.overlap || f.running > 1
+	f.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	f.mu.Lock()
+	f.running--
+	f.mu.Unlock()
+	return fmt.Sprintf("%s+%d", previous, len(messages)), nil
+}
+
+func TestRollUpSummarySerialisesSession(t *testing.T) {
+	history := &summaryHistory{summary: domain.SessionSummary{Content: "s", MessageCount: 2}}
+	neuro := &summarizingNeuro{}
+	c := New(noDocs{}, neuro, history, nil, nil, nil, nil,
+		&config.ContextGeneration{CharsPerToken: 3}, noop.NewTracerProvider().Tracer("test"))
+
+	// Two turns of the session read the same summary and each dropped messages past it.
+	read := history.summary
+	var wg sync.WaitGroup
+	for _, dropped := range [][]domain.Message{make([]domain.Message, 2), make([]domain.Message, 4)} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.rollUpSummary(context.Background(), "u1", "s1", read, read, dropped)
+		}()
+	}
+	wg.Wait()
+
+	if neuro.overlap {
+		t.Fatal("expected roll-ups of a session not to run concurrently")
+	}
+	if neuro.calls != 1 || history.saves != 1 {
+		t.Fatalf("expected the second roll-up to be skipped, got %d summaries and %d saves", neuro.calls, history.saves)
+	}
+	if got := history.summary.MessageCount; got != 4 && got != 6 {
+		t.Fatalf("expected the summary to cover one roll-up, got %d messages", got)
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// resolveScope returns the retrieval scope of the request and the persona of its corpus.
+// An empty CorpusID selects the configured collection and prompts.
+func (c *Chat) resolveScope(ctx context.Context, opts domain.ChatOptions) (domain.SearchScope, domain.Persona, error) {
+	if err := opts.Filters.Validate(); err != nil {
+		return domain.SearchScope{}, domain.Persona{}, err
+	}
+
+	scope := domain.SearchScope{Filters: opts.Filters, IncludePrivate: opts.IncludePrivate}
+	if opts.CorpusID == "" {
+		return scope, domain.Persona{}, nil
+	}
+
+	corpus, err := c.corpora.GetCorpus(ctx, opts.CorpusID)
+	if err != nil {
+		return domain.SearchScope{}, domain.Persona{}, err
+	}
+	scope.Collection = corpus.CollectionName
+	return scope, corpus.Persona, nil
+}
+
+// ListCorpora returns the corpora chat requests can be routed to
+func (c *Chat) ListCorpora(ctx context.Context) ([]domain.Corpus, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.list_corpora")
+	defer span.End()
+
+	return c.corpora.ListCorpora(ctx)
+}
+
+// GetCorpus returns a single corpus with its persona
+func (c *Chat) GetCorpus(ctx context.Context, id string) (domain.Corpus, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.get_corpus",
+		trace.WithAttributes(attribute.String("chat.corpus_id", id)),
+	)
+	defer span.End()
+
+	return c.corpora.GetCorpus(ctx, id)
+}
+
+// CreateCorpus registers a new corpus
+func (c *Chat) CreateCorpus(ctx context.Context, corpus domain.Corpus) (domain.Corpus, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.create_corpus",
+		trace.WithAttributes(attribute.String("chat.corpus_id", corpus.ID)),
+	)
+	defer span.End()
+
+	corpus = normalizeCorpus(corpus)
+	if err := corpus.Validate(); err != nil {
+		return domain.Corpus{}, err
+	}
+	return c.corpora.CreateCorpus(ctx, corpus)
+}
+
+// UpdateCorpus replaces the settings of a corpus
+func (c *Chat) UpdateCorpus(ctx context.Context, corpus domain.Corpus) (domain.Corpus, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.update_corpus",
+		trace.WithAttributes(attribute.String("chat.corpus_id", corpus.ID)),
+	)
+	defer span.End()
+
+	corpus = normalizeCorpus(corpus)
+	if err := corpus.Validate(); err != nil {
+		return domain.Corpus{}, err
+	}
+	return c.corpora.UpdateCorpus(ctx, corpus)
+}
+
+// DeleteCorpus removes a corpus from the registry
+func (c *Chat) DeleteCorpus(ctx context.Context, id string) error {
+	ctx, span := c.tracer.Start(ctx, "chat.service.delete_corpus",
+		trace.WithAttributes(attribute.String("chat.corpus_id", id)),
+	)
+	defer span.End()
+
+	return c.corpora.DeleteCorpus(ctx, id)
+}
+
+// normalizeCorpus trims the identifying fields of a corpus.
+func normalizeCorpus(corpus domain.Corpus) domain.Corpus {
+	corpus.ID = strings.TrimSpace(corpus.ID)
+	corpus.Name = strings.TrimSpace(corpus.Name)
+	corpus.CollectionName = strings.TrimSpace(corpus.CollectionName)
+	corpus.Persona.GenerationModel = strings.TrimSpace(corpus.Persona.GenerationModel)
+	return corpus
+}
@@ -0,0 +1,41 @@
+package service
+
+import "sync"
+
+// sessionLocks serialises background work on the same session. The zero value is ready to use.
+type sessionLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sessionLock
+}
+
+type sessionLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock blocks until no other holder of the session's lock remains and returns the function releasing it.
+func (l *sessionLocks) lock(sessionID string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*sessionLock)
+	}
+	sl, ok := l.locks[sessionID]
+	if !ok {
+		sl = &sessionLock{}
+		l.locks[sessionID] = sl
+	}
+	sl.refs++
+	l.mu.Unlock()
+
+	sl.mu.Lock()
+	return func() {
+		sl.mu.Unlock()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		sl.refs--
+		if sl.refs == 0 {
+			delete(l.locks, sessionID)
+		}
+	}
+}
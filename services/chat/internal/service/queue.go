@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// waitForSlot waits until the generation may call the model and streams the queue position
+// to the client meanwhile. The returned release must be called once the model has answered.
+func (c *Chat) waitForSlot(
+	ctx context.Context,
+	span trace.Span,
+	g generation,
+	fn func(response domain.ChatResponse) error,
+) (func(), error) {
+	if c.queue == nil {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	queued := false
+	release, err := c.queue.Acquire(ctx, g.userID, func(position int) {
+		queued = true
+		err := fn(domain.ChatResponse{
+			Type:      domain.ResponseTypeQueued,
+			Position:  position,
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			slog.DebugContext(ctx, "could not deliver queue position", "error", err)
+		}
+	})
+	if queued {
+		span.SetAttributes(attribute.Int64("chat.queue_wait_ms", time.Since(start).Milliseconds()))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "not scheduled")
+		return nil, err
+	}
+	return release, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// fakeQueue reports positions before granting a slot, or fails with err.
+type fakeQueue struct {
+	positions []int
+	err       error
+	released  int
+}
+
+func (f *fakeQueue) Acquire(_ context.Context, _ string, queued func(position int)) (func(), error) {
+	for _, position := range f.positions {
+		queued(position)
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return func() { f.released++ }, nil
+}
+
+// recordingHistory is a new session that records the saved messages.
+type recordingHistory struct {
+	emptyHistory
+	saved []domain.Message
+}
+
+func (f *recordingHistory) SaveMessage(_ context.Context, _, _ string, message domain.Message) (string, error) {
+	f.saved = append(f.saved, message)
+	return "m1", nil
+}
+
+func TestProcessInputStreamsQueuePosition(t *testing.T) {
+	queue := &fakeQueue{positions: []int{2, 1}}
+	neuro := &answeringNeuro{}
+	c := New(&fakeVectorStore{}, neuro, emptyHistory{}, nil, nil, nil, queue,
+		&config.ContextGeneration{CharsPerToken: 3}, noop.NewTracerProvider().Tracer("test"))
+
+	var responses []domain.ChatResponse
+	err := c.ProcessInput(context.Background(), "Вопрос", "u1", "s1", domain.ChatOptions{},
+		func(response domain.ChatResponse) error {
+			responses = append(responses, response)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("ProcessInput: %v", err)
+	}
+
+	if len(responses) != 3 {
+		t.Fatalf("expected two queue updates and the answer, got %+v", responses)
+	}
+	for i, position := range []int{2, 1} {
+		if responses[i].Type != domain.ResponseTypeQueued || responses[i].Position != position {
+			t.Errorf("response %d = %+v, want queued at %d", i, responses[i], position)
+		}
+	}
+	if !responses[2].Done || responses[2].Type != "" {
+		t.Errorf("expected the final answer last, got %+v", responses[2])
+	}
+	if neuro.calls != 1 || queue.released != 1 {
+		t.Errorf("expected one model call releasing its slot, got %d calls and %d releases", neuro.calls, queue.released)
+	}
+}
+
+func TestProcessInputFullQueue(t *testing.T) {
+	neuro := &answeringNeuro{}
+	history := &recordingHistory{}
+	c := New(&fakeVectorStore{}, neuro, history, nil, nil, nil, &fakeQueue{err: domain.ErrQueueFull},
+		&config.ContextGeneration{CharsPerToken: 3}, noop.NewTracerProvider().Tracer("test"))
+
+	err := c.ProcessInput(context.Background(), "Вопрос", "u1", "s1", domain.ChatOptions{},
+		func(domain.ChatResponse) error { return nil })
+	if !errors.Is(err, domain.ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+	if neuro.calls != 0 {
+		t.Errorf("the model must not be called, got %d calls", neuro.calls)
+	}
+	if len(history.saved) != 0 {
+		t.Errorf("a rejected prompt must not be saved, got %+v", history.saved)
+	}
+}
+
+func TestProcessInputCancelledInQueueChargesNoPrompt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	quotas := &fakeQuota{}
+	history := &recordingHistory{}
+	c := New(&fakeVectorStore{}, &answeringNeuro{}, history, nil, nil, quotas, &fakeQueue{err: context.Canceled},
+		&config.ContextGeneration{CharsPerToken: 3}, noop.NewTracerProvider().Tracer("test"))
+
+	var final domain.ChatResponse
+	err := c.ProcessInput(ctx, "Вопрос", "u1", "s1", domain.ChatOptions{}, func(response domain.ChatResponse) error {
+		final = response
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ProcessInput: %v", err)
+	}
+	if !final.Done || !final.Truncated {
+		t.Errorf("expected a truncated final chunk, got %+v", final)
+	}
+	if quotas.tokens != 0 || quotas.refunds != 1 {
+		t.Errorf("expected the request refunded and no tokens charged, got %d refunds and %d tokens", quotas.refunds, quotas.tokens)
+	}
+	if len(history.saved) != 0 {
+		t.Errorf("a prompt cancelled in the queue must not be saved, got %+v", history.saved)
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// quotaUserID returns the user the generation is charged to: the caller, or the owner of the chat.
+func (g generation) quotaUserID() string {
+	if g.opts.CallerID != "" {
+		return g.opts.CallerID
+	}
+	return g.userID
+}
+
+// acquireQuota counts the generation against the quotas of the user.
+// Only exceeded quotas fail the request: when the counters are unavailable the generation is allowed.
+func (c *Chat) acquireQuota(ctx context.Context, g generation) error {
+	if c.quotas == nil {
+		return nil
+	}
+
+	_, err := c.quotas.Acquire(ctx, g.quotaUserID(), g.opts.UserRole)
+	if errors.Is(err, domain.ErrQuotaExceeded) {
+		slog.InfoContext(ctx, "chat quota exceeded", "user_id", g.quotaUserID(), "error", err)
+		return err
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "could not check chat quota", "error", err)
+	}
+	return nil
+}
+
+// refundQuota gives back a generation counted by acquireQuota that did not reach the model.
+func (c *Chat) refundQuota(ctx context.Context, g generation) {
+	if c.quotas == nil {
+		return
+	}
+
+	if err := c.quotas.Refund(ctx, g.quotaUserID(), g.opts.UserRole); err != nil {
+		slog.WarnContext(ctx, "could not refund chat quota", "error", err)
+	}
+}
+
+// chargeTokens charges the estimated prompt and answer tokens of a generation to the user.
+// It returns the remaining quota, or nil when the user is unlimited or the counters are unavailable.
+func (c *Chat) chargeTokens(ctx context.Context, g generation, promptTokens int, answer string) *domain.QuotaUsage {
+	if c.quotas == nil {
+		return nil
+	}
+
+	tokens := promptTokens + domain.EstimateTokens(answer, c.contextCfg.CharsPerToken)
+	usage, err := c.quotas.AddTokens(ctx, g.quotaUserID(), g.opts.UserRole, tokens)
+	if err != nil {
+		slog.WarnContext(ctx, "could not charge chat tokens", "error", err, "tokens", tokens)
+		return nil
+	}
+	if usage.Requests == nil && usage.Tokens == nil {
+		return nil
+	}
+	return &usage
+}
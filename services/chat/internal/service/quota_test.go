@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// fakeQuota rejects requests with acquireErr and records charged tokens and refunds.
+type fakeQuota struct {
+	acquireErr error
+	userID     string
+	role       string
+	tokens     int
+	refunds    int
+}
+
+func (f *fakeQuota) Acquire(_ context.Context, userID, role string) (domain.QuotaUsage, error) {
+	f.userID = userID
+	f.role = role
+	return domain.QuotaUsage{}, f.acquireErr
+}
+
+func (f *fakeQuota) AddTokens(_ context.Context, _, _ string, tokens int) (domain.QuotaUsage, error) {
+	f.tokens += tokens
+	return domain.QuotaUsage{Tokens: &domain.QuotaWindow{Limit: 1000, Remaining: 1000 - f.tokens}}, nil
+}
+
+func (f *fakeQuota) Refund(context.Context, string, string) error {
+	f.refunds++
+	return nil
+}
+
+// emptyHistory is a new session that accepts every message.
+type emptyHistory struct {
+	chatHistoryStore
+}
+
+func (emptyHistory) RetrieveChatHistory(context.Context, string, string) ([]domain.Message, error) {
+	return nil, nil
+}
+
+func (emptyHistory) ResolveSession(_ context.Context, _, sessionID string) (string, error) {
+	return sessionID, nil
+}
+
+func (emptyHistory) GetSessionSummary(context.Context, string, string) (domain.SessionSummary, error) {
+	return domain.SessionSummary{}, nil
+}
+
+func (emptyHistory) SaveMessage(context.Context, string, string, domain.Message) (string, error) {
+	return "m1", nil
+}
+
+// answeringNeuro answers every prompt with a fixed text.
+type answeringNeuro struct {
+	fakeNeuro
+	calls int
+}
+
+func (f *answeringNeuro) Chat(_ context.Context, _ domain.WorkingContext, _ string, fn func(domain.ChatResponse) error) error {
+	f.calls++
+	return fn(domain.ChatResponse{Done: true, Message: &domain.Message{Role: "assistant", Content: "Ответ из двенадцати букв"}})
+}
+
+func TestProcessInputQuota(t *testing.T) {
+	exceeded := &domain.QuotaExceededError{Quota: domain.QuotaRequestsPerMinute, RetryAfter: 30 * time.Second}
+
+	tests := []struct {
+		name       string
+		acquireErr error
+		wantErr    error
+		wantCalls  int
+	}{
+		{name: "allowed", wantCalls: 1},
+		{name: "exceeded", acquireErr: exceeded, wantErr: domain.ErrQuotaExceeded},
+		{name: "counters unavailable", acquireErr: errors.New("redis is down"), wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotas := &fakeQuota{acquireErr: tt.acquireErr}
+			neuro := &answeringNeuro{}
+			c := New(&fakeVectorStore{}, neuro, emptyHistory{}, nil, nil, quotas, nil,
+				&config.ContextGeneration{CharsPerToken: 3}, noop.NewTracerProvider().Tracer("test"))
+
+			var final domain.ChatResponse
+			err := c.ProcessInput(context.Background(), "Вопрос", "u1", "s1", domain.ChatOptions{UserRole: "Reader"},
+				func(response domain.ChatResponse) error {
+					final = response
+					return nil
+				})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ProcessInput() error = %v, want %v", err, tt.wantErr)
+			}
+			if neuro.calls != tt.wantCalls {
+				t.Errorf("model called %d times, want %d", neuro.calls, tt.wantCalls)
+			}
+			if quotas.role != "Reader" {
+				t.Errorf("quota role = %q, want Reader", quotas.role)
+			}
+			if quotas.refunds != 0 {
+				t.Errorf("refunded %d requests, want none", quotas.refunds)
+			}
+			if tt.wantCalls == 0 {
+				return
+			}
+			if quotas.tokens == 0 {
+				t.Error("no tokens charged for the answer")
+			}
+			if final.Quota == nil || final.Quota.Tokens.Remaining != 1000-quotas.tokens {
+				t.Errorf("final chunk quota = %+v, want the remaining tokens", final.Quota)
+			}
+		})
+	}
+}
+
+func TestProcessInputChargesCaller(t *testing.T) {
+	tests := []struct {
+		name     string
+		callerID string
+		want     string
+	}{
+		{name: "owner", want: "u1"},
+		{name: "admin in another user's chat", callerID: "admin", want: "admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotas := &fakeQuota{}
+			c := New(&fakeVectorStore{}, &answeringNeuro{}, emptyHistory{}, nil, nil, quotas, nil,
+				&config.ContextGeneration{CharsPerToken: 3}, noop.NewTracerProvider().Tracer("test"))
+
+			err := c.ProcessInput(context.Background(), "Вопрос", "u1", "s1", domain.ChatOptions{CallerID: tt.callerID},
+				func(domain.ChatResponse) error { return nil })
+			if err != nil {
+				t.Fatalf("ProcessInput: %v", err)
+			}
+			if quotas.userID != tt.want {
+				t.Errorf("quota charged to %q, want %q", quotas.userID, tt.want)
+			}
+		})
+	}
+}
+
+// missingSessionHistory is a session deleted before the prompt is stored.
+type missingSessionHistory struct {
+	emptyHistory
+}
+
+func (missingSessionHistory) SaveMessage(context.Context, string, string, domain.Message) (string, error) {
+	return "", domain.ErrChatSessionNotFound
+}
+
+func TestProcessInputRefundsUnservedRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		vectors *fakeVectorStore
+		history chatHistoryStore
+		queue   *fakeQueue
+		wantErr error
+	}{
+		{
+			name:    "retrieval failed",
+			vectors: &fakeVectorStore{err: errors.New("qdrant is down")},
+			history: emptyHistory{},
+			queue:   &fakeQueue{},
+		},
+		{
+			name:    "session not found",
+			vectors: &fakeVectorStore{},
+			history: missingSessionHistory{},
+			queue:   &fakeQueue{},
+			wantErr: domain.ErrChatSessionNotFound,
+		},
+		{
+			name:    "queue full",
+			vectors: &fakeVectorStore{},
+			history: emptyHistory{},
+			queue:   &fakeQueue{err: domain.ErrQueueFull},
+			wantErr: domain.ErrQueueFull,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotas := &fakeQuota{}
+			neuro := &answeringNeuro{}
+			c := New(tt.vectors, neuro, tt.history, nil, nil, quotas, tt.queue,
+				&config.ContextGeneration{CharsPerToken: 3}, noop.NewTracerProvider().Tracer("test"))
+
+			err := c.ProcessInput(context.Background(), "Вопрос", "u1", "s1", domain.ChatOptions{},
+				func(domain.ChatResponse) error { return nil })
+			if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Fatalf("ProcessInput() error = %v, want %v", err, tt.wantErr)
+			}
+			if neuro.calls != 0 {
+				t.Errorf("model called %d times, want none", neuro.calls)
+			}
+			if quotas.refunds != 1 || quotas.tokens != 0 {
+				t.Errorf("refunds = %d, tokens = %d, want the request refunded and no tokens charged", quotas.refunds, quotas.tokens)
+			}
+		})
+	}
+}
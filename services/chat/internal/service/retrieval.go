@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// searchQueries returns the queries to retrieve documents for: a standalone version of a follow-up
+// question and the configured number of paraphrases. The input itself is used when rewriting is
+// disabled, not needed or fails.
+func (c *Chat) searchQueries(ctx context.Context, history []domain.Message, input string) []string {
+	rewrite := c.contextCfg.RewriteQueries && len(history) > 0
+	if !rewrite && c.contextCfg.QueryVariants <= 0 {
+		return []string{input}
+	}
+	if !rewrite {
+		history = nil
+	} else if n := c.contextCfg.RewriteHistoryMessages; n > 0 && len(history) > n {
+		history = history[len(history)-n:]
+	}
+
+	ctx, span := c.tracer.Start(ctx, "chat.service.rewrite_query")
+	defer span.End()
+
+	if c.contextCfg.RewriteTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.contextCfg.RewriteTimeout)
+		defer cancel()
+	}
+
+	queries, err := c.neuro.RewriteQuery(ctx, history, input, max(c.contextCfg.QueryVariants, 0))
+	if err != nil {
+		span.RecordError(err)
+		slog.WarnContext(ctx, "could not rewrite query, retrieving for the input", "error", err)
+		return []string{input}
+	}
+
+	span.SetAttributes(
+		attribute.String("chat.rewritten_query", queries[0]),
+		attribute.StringSlice("chat.query_variants", queries[1:]),
+	)
+	return queries
+}
+
+// retrieve fetches documents for every query and merges the results.
+func (c *Chat) retrieve(ctx context.Context, queries []string, scope domain.SearchScope) ([]domain.Document, error) {
+	lists := make([][]domain.Document, 0, len(queries))
+	for _, query := range queries {
+		embedding, err := c.neuro.GenerateEmbeddings(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("could not generate embeddings: %w", err)
+		}
+
+		docs, err := c.vectorStore.RetrieveVectors(ctx, query, embedding, scope)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve vectors: %w", err)
+		}
+		lists = append(lists, docs)
+	}
+	return mergeDocuments(lists), nil
+}
+
+// mergeDocuments interleaves ranked lists of chunks by rank, dropping chunks already taken,
+// so that every query contributes its best hits first. The result is no longer than the longest list.
+func mergeDocuments(lists [][]domain.Document) []domain.Document {
+	if len(lists) == 1 {
+		return lists[0]
+	}
+
+	longest := 0
+	for _, list := range lists {
+		longest = max(longest, len(list))
+	}
+
+	merged := make([]domain.Document, 0, longest)
+	seen := make(map[string]struct{}, longest)
+	for rank := 0; rank < longest && len(merged) < longest; rank++ {
+		for _, list := range lists {
+			if rank >= len(list) || len(merged) == longest {
+				continue
+			}
+			if _, ok := seen[list[rank].ID]; ok {
+				continue
+			}
+			seen[list[rank].ID] = struct{}{}
+			merged = append(merged, list[rank])
+		}
+	}
+	return merged
+}
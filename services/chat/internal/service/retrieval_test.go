@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// fakeNeuro rewrites queries and embeds every query into a vector holding its length.
+type fakeNeuro struct {
+	neuroConnector
+	queries    []string
+	rewriteErr error
+	history    []domain.Message // History passed to the last RewriteQuery call.
+	variants   int
+	rewrites   int
+}
+
+func (f *fakeNeuro) RewriteQuery(_ context.Context, history []domain.Message, _ string, variants int) ([]string, error) {
+	f.rewrites++
+	f.history = history
+	f.variants = variants
+	return f.queries, f.rewriteErr
+}
+
+func (f *fakeNeuro) GenerateEmbeddings(_ context.Context, input string) ([]float64, error) {
+	return []float64{float64(len(input))}, nil
+}
+
+// fakeVectorStore returns the chunks configured for each query, or err.
+type fakeVectorStore struct {
+	docs    map[string][]domain.Document
+	queries []string
+	err     error
+}
+
+func (f *fakeVectorStore) RetrieveVectors(_ context.Context, query string, _ []float64, _ domain.SearchScope) ([]domain.Document, error) {
+	f.queries = append(f.queries, query)
+	return f.docs[query], f.err
+}
+
+func newRetrievalChat(neuro *fakeNeuro, vectors *fakeVectorStore, cfg config.ContextGeneration) *Chat {
+	return New(vectors, neuro, nil, nil, nil, nil, nil, &cfg, noop.NewTracerProvider().Tracer("test"))
+}
+
+func TestSearchQueries(t *testing.T) {
+	history := []domain.Message{
+		{Role: "user", Content: "Что Кант писал о свободе?"},
+		{Role: "assistant", Content: "..."},
+		{Role: "user", Content: "А Гегель?"},
+		{Role: "assistant", Content: "..."},
+	}
+	rewritten := []string{"Что Кант писал о свободе позже?", "Поздний Кант о свободе"}
+
+	tests := []struct {
+		name         string
+		cfg          config.ContextGeneration
+		history      []domain.Message
+		rewriteErr   error
+		want         []string
+		wantRewrites int
+		wantHistory  int
+	}{
+		{name: "disabled", cfg: config.ContextGeneration{}, history: history, want: []string{"А позже?"}},
+		{name: "first question", cfg: config.ContextGeneration{RewriteQueries: true}, want: []string{"А позже?"}},
+		{name: "follow-up", cfg: config.ContextGeneration{RewriteQueries: true, RewriteHistoryMessages: 2}, history: history,
+			want: rewritten, wantRewrites: 1, wantHistory: 2},
+		{name: "variants without rewriting", cfg: config.ContextGeneration{QueryVariants: 1}, history: history,
+			want: rewritten, wantRewrites: 1},
+		{name: "failure falls back to input", cfg: config.ContextGeneration{RewriteQueries: true}, history: history,
+			rewriteErr: errors.New("model unavailable"), want: []string{"А позже?"}, wantRewrites: 1, wantHistory: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			neuro := &fakeNeuro{queries: rewritten, rewriteErr: tt.rewriteErr}
+			c := newRetrievalChat(neuro, &fakeVectorStore{}, tt.cfg)
+
+			got := c.searchQueries(context.Background(), tt.history, "А позже?")
+
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("searchQueries() = %q, want %q", got, tt.want)
+			}
+			if neuro.rewrites != tt.wantRewrites {
+				t.Errorf("expected %d rewrites, got %d", tt.wantRewrites, neuro.rewrites)
+			}
+			if len(neuro.history) != tt.wantHistory {
+				t.Errorf("expected %d history messages, got %d", tt.wantHistory, len(neuro.history))
+			}
+			if neuro.variants != tt.cfg.QueryVariants {
+				t.Errorf("expected %d variants, got %d", tt.cfg.QueryVariants, neuro.variants)
+			}
+		})
+	}
+}
+
+func TestRetrieveMergesQueries(t *testing.T) {
+	doc := func(id string) domain.Document { return domain.Document{ID: id} }
+	vectors := &fakeVectorStore{docs: map[string][]domain.Document{
+		"first":  {doc("a"), doc("b"), doc("c")},
+		"second": {doc("b"), doc("d")},
+	}}
+	c := newRetrievalChat(&fakeNeuro{}, vectors, config.ContextGeneration{})
+
+	docs, err := c.retrieve(context.Background(), []string{"first", "second"}, domain.SearchScope{})
+	if err != nil {
+		t.Fatalf("retrieve() error = %v", err)
+	}
+
+	var ids []string
+	for _, d := range docs {
+		ids = append(ids, d.ID)
+	}
+	if got := strings.Join(ids, ","); got != "a,b,d" {
+		t.Errorf("expected chunks interleaved by rank without duplicates, got %s", got)
+	}
+	if strings.Join(vectors.queries, ",") != "first,second" {
+		t.Errorf("expected retrieval for every query, got %q", vectors.queries)
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// shareTokenBytes is the entropy of share tokens: anyone knowing a token can read the transcript.
+const shareTokenBytes = 24
+
+// ShareSession publishes a read-only snapshot of the active branch of the session under a new random token.
+// Sharing the same session again creates another snapshot with its own token.
+func (c *Chat) ShareSession(ctx context.Context, userID, sessionID string) (domain.SessionShare, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.share_session",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.String("chat.session_id", sessionID),
+		),
+	)
+	defer span.End()
+
+	token, err := newShareToken()
+	if err != nil {
+		return domain.SessionShare{}, err
+	}
+	return c.historyStore.CreateSessionShare(ctx, userID, sessionID, token)
+}
+
+// ListSessionShares returns the snapshots of the session published by the user, newest first.
+func (c *Chat) ListSessionShares(ctx context.Context, userID, sessionID string) ([]domain.SessionShare, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.list_session_shares",
+		trace.WithAttributes(
+			attribute.String("chat.user_id", userID),
+			attribute.String("chat.session_id", sessionID),
+		),
+	)
+	defer span.End()
+
+	return c.historyStore.ListSessionShares(ctx, userID, sessionID)
+}
+
+// RevokeSessionShare deletes a snapshot published by the user, so that its token stops working.
+func (c *Chat) RevokeSessionShare(ctx context.Context, userID, token string) error {
+	ctx, span := c.tracer.Start(ctx, "chat.service.revoke_session_share",
+		trace.WithAttributes(attribute.String("chat.user_id", userID)),
+	)
+	defer span.End()
+
+	return c.historyStore.DeleteSessionShare(ctx, userID, token)
+}
+
+// GetSharedTranscript returns the snapshot published under token for anonymous readers.
+// The source session is not disclosed.
+func (c *Chat) GetSharedTranscript(ctx context.Context, token string) (domain.SessionShare, error) {
+	ctx, span := c.tracer.Start(ctx, "chat.service.get_shared_transcript")
+	defer span.End()
+
+	share, err := c.historyStore.GetSessionShare(ctx, token)
+	if err != nil {
+		return domain.SessionShare{}, err
+	}
+	share.SessionID = ""
+	// Snapshots taken before citations carried their visibility are filtered on read.
+	share.Messages = domain.ShareTranscript(share.Messages)
+	return share, nil
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
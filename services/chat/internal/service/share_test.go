@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/artmexbet/raibecas/services/chat/internal/config"
+	"github.com/artmexbet/raibecas/services/chat/internal/domain"
+)
+
+// shareHistory keeps the shares created through it.
+type shareHistory struct {
+	chatHistoryStore
+	shares map[string]domain.SessionShare
+}
+
+func (h *shareHistory) CreateSessionShare(_ context.Context, _, sessionID, token string) (domain.SessionShare, error) {
+	share := domain.SessionShare{Token: token, SessionID: sessionID, Title: "Диалектика"}
+	h.shares[token] = share
+	return share, nil
+}
+
+func (h *shareHistory) GetSessionShare(_ context.Context, token string) (domain.SessionShare, error) {
+	share, ok := h.shares[token]
+	if !ok {
+		return domain.SessionShare{}, domain.ErrShareNotFound
+	}
+	return share, nil
+}
+
+func TestShareSession(t *testing.T) {
+	history := &shareHistory{shares: make(map[string]domain.SessionShare)}
+	c := New(nil, nil, history, nil, nil, nil, nil, &config.ContextGeneration{}, noop.NewTracerProvider().Tracer("test"))
+
+	first, err := c.ShareSession(context.Background(), "u1", "s1")
+	if err != nil {
+		t.Fatalf("ShareSession: %v", err)
+	}
+	second, err := c.ShareSession(context.Background(), "u1", "s1")
+	if err != nil {
+		t.Fatalf("ShareSession: %v", err)
+	}
+	if first.Token == second.Token {
+		t.Error("every share must get its own token")
+	}
+	if raw, err := base64.RawURLEncoding.DecodeString(first.Token); err != nil || len(raw) != shareTokenBytes {
+		t.Errorf("expected a URL-safe token of %d bytes, got %q", shareTokenBytes, first.Token)
+	}
+
+	transcript, err := c.GetSharedTranscript(context.Background(), first.Token)
+	if err != nil {
+		t.Fatalf("GetSharedTranscript: %v", err)
+	}
+	if transcript.SessionID != "" || transcript.Title != "Диалектика" {
+		t.Errorf("expected the transcript without its session, got %+v", transcript)
+	}
+}
+
+func TestGetSharedTranscriptHidesPrivateCitations(t *testing.T) {
+	// A snapshot of an admin session, stored before citations were filtered when sharing.
+	history := &shareHistory{shares: map[string]domain.SessionShare{
+		"token": {Token: "token", Title: "Диалектика", Messages: []domain.Message{
+			{Role: "user", Content: "Что такое диалектика?"},
+			{Role: "assistant", Content: "Метод", Citations: []domain.Citation{
+				{DocumentID: "public", Excerpt: "Тезис", Public: true},
+				{DocumentID: "private", Excerpt: "Черновик рукописи"},
+			}},
+		}},
+	}}
+	c := New(nil, nil, history, nil, nil, nil, nil, &config.ContextGeneration{}, noop.NewTracerProvider().Tracer("test"))
+
+	transcript, err := c.GetSharedTranscript(context.Background(), "token")
+	if err != nil {
+		t.Fatalf("GetSharedTranscript: %v", err)
+	}
+	for _, m := range transcript.Messages {
+		for _, citation := range m.Citations {
+			if !citation.Public {
+				t.Errorf("private citation %+v reached the shared transcript", citation)
+			}
+		}
+	}
+	if len(transcript.Messages) != 2 || len(transcript.Messages[1].Citations) != 1 {
+		t.Errorf("expected the public citation to stay, got %+v", transcript.Messages)
+	}
+}
@@ -0,0 +1,3 @@
+ALTER TABLE chat_sessions
+    ADD COLUMN IF NOT EXISTS summary TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS summary_message_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,7 @@
+ALTER TABLE chat_sessions
+    DROP COLUMN IF EXISTS active_message_id;
+
+DROP INDEX IF EXISTS idx_chat_messages_parent_id;
+
+ALTER TABLE chat_messages
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,25 @@
+ALTER TABLE chat_messages
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES chat_messages(id) ON DELETE CASCADE;
+
+-- Leaf message of the branch shown in the session; NULL for an empty session.
+ALTER TABLE chat_sessions
+    ADD COLUMN IF NOT EXISTS active_message_id UUID;
+
+-- Existing sessions become a single branch in creation order.
+UPDATE chat_messages m
+SET parent_id = ordered.prev_id
+FROM (
+    SELECT id, LAG(id) OVER (PARTITION BY session_id ORDER BY created_at, id) AS prev_id
+    FROM chat_messages
+) ordered
+WHERE m.id = ordered.id;
+
+UPDATE chat_sessions s
+SET active_message_id = (
+    SELECT m.id FROM chat_messages m
+    WHERE m.session_id = s.id
+    ORDER BY m.created_at DESC, m.id DESC
+    LIMIT 1
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_parent_id ON chat_messages (parent_id);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_chat_messages_search_vector;
+
+ALTER TABLE chat_messages
+    DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_chat_sessions_user_updated;
+
+ALTER TABLE chat_sessions
+    DROP COLUMN IF EXISTS archived_at;
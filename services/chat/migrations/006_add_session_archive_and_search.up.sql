@@ -0,0 +1,10 @@
+ALTER TABLE chat_sessions
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_chat_sessions_user_updated ON chat_sessions (user_id, updated_at DESC);
+
+ALTER TABLE chat_messages
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+        GENERATED ALWAYS AS (to_tsvector('russian', content)) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_search_vector ON chat_messages USING gin (search_vector);
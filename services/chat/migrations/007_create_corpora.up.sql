@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS corpora (
+    id               TEXT PRIMARY KEY,
+    name             TEXT NOT NULL,
+    description      TEXT NOT NULL DEFAULT '',
+    collection_name  TEXT NOT NULL,
+    generation_model TEXT NOT NULL DEFAULT '',
+    temperature      REAL,
+    base_prompt      TEXT NOT NULL DEFAULT '',
+    context_prompt   TEXT NOT NULL DEFAULT '',
+    query_prompt     TEXT NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_chat_messages_feedback_at;
+
+ALTER TABLE chat_messages
+    DROP COLUMN IF EXISTS feedback_at,
+    DROP COLUMN IF EXISTS feedback_comment,
+    DROP COLUMN IF EXISTS feedback_rating;
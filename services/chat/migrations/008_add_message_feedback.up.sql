@@ -0,0 +1,7 @@
+ALTER TABLE chat_messages
+    ADD COLUMN IF NOT EXISTS feedback_rating SMALLINT CHECK (feedback_rating IN (-1, 1)),
+    ADD COLUMN IF NOT EXISTS feedback_comment TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS feedback_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_feedback_at ON chat_messages (feedback_at)
+    WHERE feedback_rating IS NOT NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS chat_session_shares (
+    token      TEXT PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES chat_sessions(id) ON DELETE CASCADE,
+    user_id    TEXT NOT NULL,
+    title      TEXT NOT NULL,
+    messages   JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_session_shares_session_id ON chat_session_shares (session_id, created_at);
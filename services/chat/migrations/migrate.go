@@ -13,6 +13,14 @@ import (
 )
 
 //go:embed 001_create_chat_tables.up.sql 001_create_chat_tables.down.sql
+//go:embed 002_add_message_citations.up.sql 002_add_message_citations.down.sql
+//go:embed 003_add_session_summary.up.sql 003_add_session_summary.down.sql
+//go:embed 004_add_message_truncated.up.sql 004_add_message_truncated.down.sql
+//go:embed 005_add_message_branches.up.sql 005_add_message_branches.down.sql
+//go:embed 006_add_session_archive_and_search.up.sql 006_add_session_archive_and_search.down.sql
+//go:embed 007_create_corpora.up.sql 007_create_corpora.down.sql
+//go:embed 008_add_message_feedback.up.sql 008_add_message_feedback.down.sql
+//go:embed 009_create_session_shares.up.sql 009_create_session_shares.down.sql
 var files embed.FS
 
 func Up(databaseDSN string) error {
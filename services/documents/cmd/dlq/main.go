@@ -0,0 +1,165 @@
+// Command dlq inspects and replays the dead-letter stream of the documents service:
+// indexing events that failed permanently or exhausted their redeliveries.
+// list prints one JSON object per dead letter with the failure reason and the payload;
+// replay republishes dead letters to their original subject and removes them from the stream.
+//
+// Usage:
+//
+//	dlq [-nats nats://localhost:4222] [-stream INDEXING_DLQ] list [-limit 50]
+//	dlq [-nats nats://localhost:4222] [-stream INDEXING_DLQ] replay SEQ... | all
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/artmexbet/raibecas/libs/natsw"
+)
+
+// deadLetterRecord is the JSON view of a dead letter printed by list
+type deadLetterRecord struct {
+	Sequence   uint64    `json:"seq"`
+	Subject    string    `json:"subject"`
+	Stream     string    `json:"stream"`
+	Consumer   string    `json:"consumer"`
+	Deliveries uint64    `json:"deliveries"`
+	Reason     string    `json:"reason"`
+	Error      string    `json:"error"`
+	FailedAt   time.Time `json:"failed_at"`
+	Data       string    `json:"data"`
+}
+
+func main() {
+	natsURL := flag.String("nats", envOr("NATS_URL", nats.DefaultURL), "NATS server URL")
+	stream := flag.String("stream", envOr("NATS_DEAD_LETTER_STREAM", "INDEXING_DLQ"), "dead-letter stream")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: dlq [flags] list [-limit N] | replay SEQ... | replay all")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*natsURL, *stream, flag.Args()); err != nil {
+		slog.Error("dlq failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(natsURL, stream string, args []string) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return errors.New("missing command")
+	}
+
+	conn, err := nats.Connect(natsURL, nats.Name("documents-dlq"))
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	defer conn.Close()
+
+	jsCtx, err := natsw.NewClient(conn).JetStream()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("list", flag.ExitOnError)
+		limit := fs.Int("limit", 50, "maximum number of dead letters to print, 0 for all")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		letters, err := jsCtx.DeadLetters(ctx, stream, *limit)
+		if err != nil {
+			return err
+		}
+		return writeDeadLetters(os.Stdout, letters)
+	case "replay":
+		seqs, err := replaySequences(ctx, jsCtx, stream, args[1:])
+		if err != nil {
+			return err
+		}
+		for _, seq := range seqs {
+			if err := jsCtx.ReplayDeadLetter(ctx, stream, seq); err != nil {
+				return err
+			}
+			slog.Info("dead letter replayed", "stream", stream, "seq", seq)
+		}
+		slog.Info("replay finished", "replayed", len(seqs))
+		return nil
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// replaySequences resolves the replay arguments: explicit sequences or "all"
+func replaySequences(ctx context.Context, jsCtx *natsw.JetStreamContext, stream string, args []string) ([]uint64, error) {
+	if len(args) == 0 {
+		return nil, errors.New("replay: expected sequences or all")
+	}
+
+	if len(args) == 1 && args[0] == "all" {
+		letters, err := jsCtx.DeadLetters(ctx, stream, 0)
+		if err != nil {
+			return nil, err
+		}
+		seqs := make([]uint64, len(letters))
+		for i, letter := range letters {
+			seqs[i] = letter.Sequence
+		}
+		return seqs, nil
+	}
+
+	seqs := make([]uint64, len(args))
+	for i, arg := range args {
+		seq, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("replay: invalid sequence %q", arg)
+		}
+		seqs[i] = seq
+	}
+	return seqs, nil
+}
+
+// writeDeadLetters writes one JSON object per line.
+func writeDeadLetters(w io.Writer, letters []natsw.DeadLetter) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, letter := range letters {
+		record := deadLetterRecord{
+			Sequence:   letter.Sequence,
+			Subject:    letter.Subject,
+			Stream:     letter.Stream,
+			Consumer:   letter.Consumer,
+			Deliveries: letter.Deliveries,
+			Reason:     letter.Reason,
+			Error:      letter.Error,
+			FailedAt:   letter.FailedAt,
+			Data:       string(letter.Data),
+		}
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("encode dead letter %d: %w", letter.Sequence, err)
+		}
+	}
+	return nil
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,6 +13,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel"
 
 	"github.com/artmexbet/raibecas/libs/natsw"
@@ -27,10 +29,8 @@ import (
 	"github.com/artmexbet/raibecas/services/documents/migrations"
 )
 
-const (
-	// JetStream stream for indexing events
-	indexingStreamName = "INDEXING"
-)
+// JetStream stream for indexing events
+const indexingStreamName = "INDEXING"
 
 // App represents the application
 type App struct {
@@ -43,6 +43,7 @@ type App struct {
 	natsClient *natsw.Client
 	jsCtx      *natsw.JetStreamContext
 	server     *server.Server
+	metricsSrv *http.Server
 	shutdown   func(context.Context) error
 }
 
@@ -135,6 +136,7 @@ func New(ctx context.Context) (*App, error) {
 	natsClient := natsw.NewClient(natsConn,
 		natsw.WithLogger(logger),
 		natsw.WithRecover(),
+		natsw.WithMetrics(natsw.NewMetrics(prometheus.DefaultRegisterer)),
 		natsw.WithTracer(natsTracer),
 		natsw.WithMiddleware(natsw.TraceHandlerMiddleware(natsTracer)),
 	)
@@ -158,6 +160,17 @@ func New(ctx context.Context) (*App, error) {
 		return nil, fmt.Errorf("failed to ensure indexing stream: %w", err)
 	}
 
+	// Dead letters are kept until replayed or expired, see cmd/dlq
+	if _, err := jsCtx.EnsureStream(ctx, natsw.StreamConfig{
+		Name:      cfg.NATS.DeadLetterStream,
+		Subjects:  []string{cfg.NATS.DeadLetterSubject},
+		Storage:   jetstream.FileStorage,
+		Retention: jetstream.LimitsPolicy,
+		MaxAge:    30 * 24 * time.Hour,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to ensure indexing dead-letter stream: %w", err)
+	}
+
 	// Initialize publisher
 	publisher := natsPublisher.NewPublisher(natsClient, logger)
 
@@ -191,7 +204,15 @@ func New(ctx context.Context) (*App, error) {
 	metadataHandler := server.NewMetadataHandler(docService, logger)
 
 	// Initialize server
-	srv := server.New(natsClient, jsCtx, docHandler, metadataHandler)
+	natsService := natsw.NewService(natsClient, natsw.ServiceConfig{
+		Name:          "documents",
+		Version:       cfg.Telemetry.ServiceVersion,
+		Description:   "Documents, bookmarks, notes and metadata",
+		QueueGroup:    cfg.NATS.QueueGroup,
+		MaxConcurrent: cfg.NATS.MaxConcurrent,
+		DrainTimeout:  cfg.NATS.DrainTimeout,
+	})
+	srv := server.New(natsService, jsCtx, docHandler, metadataHandler, cfg.NATS.DeadLetterSubject)
 	app.server = srv
 
 	return app, nil
@@ -204,6 +225,8 @@ func (a *App) Run() error {
 		return fmt.Errorf("failed to start server: %w", err)
 	}
 
+	a.metricsSrv = natsw.ServeMetrics(fmt.Sprintf(":%d", a.cfg.Metrics.Port))
+
 	a.logger.Info("documents service started",
 		"service", a.cfg.Telemetry.ServiceName,
 		"nats_url", a.cfg.NATS.URL,
@@ -216,10 +239,21 @@ func (a *App) Run() error {
 	<-sigChan
 	a.logger.Info("shutting down gracefully...")
 
+	// Finish in-flight requests before closing connections
+	if err := a.server.Stop(context.Background()); err != nil {
+		a.logger.Error("failed to drain nats endpoints", "error", err)
+	} else {
+		a.logger.Info("nats endpoints drained")
+	}
+
 	// Create shutdown context with timeout
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if err := a.metricsSrv.Shutdown(shutdownCtx); err != nil {
+		a.logger.Error("failed to shutdown metrics server", "error", err)
+	}
+
 	// Cleanup with timeout
 	if a.shutdown != nil {
 		if err := a.shutdown(shutdownCtx); err != nil {
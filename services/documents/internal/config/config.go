@@ -11,6 +11,7 @@ type Config struct {
 	Database  DatabaseConfig  `yaml:"database" env-prefix:"DB_"`
 	NATS      NATSConfig      `yaml:"nats" env-prefix:"NATS_"`
 	MinIO     MinIOConfig     `yaml:"minio" env-prefix:"MINIO_"`
+	Metrics   MetricsConfig   `yaml:"metrics" env-prefix:"METRICS_"`
 	Telemetry TelemetryConfig `yaml:"telemetry" env-prefix:"TELEMETRY_"`
 }
 
@@ -35,9 +36,15 @@ func (c DatabaseConfig) DSN() string {
 
 // NATSConfig holds NATS configuration
 type NATSConfig struct {
-	URL            string `env:"URL" env-default:"nats://localhost:4222"`
-	ConnectionName string `env:"CONNECTION_NAME" env-default:"documents-service"`
-	MaxReconnects  int    `env:"MAX_RECONNECTS" env-default:"-1"`
+	URL            string        `env:"URL" env-default:"nats://localhost:4222"`
+	ConnectionName string        `env:"CONNECTION_NAME" env-default:"documents-service"`
+	MaxReconnects  int           `env:"MAX_RECONNECTS" env-default:"-1"`
+	QueueGroup     string        `env:"QUEUE_GROUP" env-default:"documents"`
+	MaxConcurrent  int           `env:"MAX_CONCURRENT" env-default:"32"`
+	DrainTimeout   time.Duration `env:"DRAIN_TIMEOUT" env-default:"20s"`
+	// Indexing events that failed permanently or exhausted retries, see cmd/dlq
+	DeadLetterStream  string `env:"DEAD_LETTER_STREAM" env-default:"INDEXING_DLQ"`
+	DeadLetterSubject string `env:"DEAD_LETTER_SUBJECT" env-default:"dlq.indexing"`
 }
 
 // MinIOConfig holds MinIO configuration
@@ -49,6 +56,11 @@ type MinIOConfig struct {
 	UseSSL    bool   `env:"USE_SSL" env-default:"false"`
 }
 
+// MetricsConfig holds Prometheus metrics server configuration
+type MetricsConfig struct {
+	Port int `env:"PORT" env-default:"9092"`
+}
+
 // TelemetryConfig holds telemetry configuration
 type TelemetryConfig struct {
 	Enabled        bool          `env:"ENABLED" env-default:"true"`
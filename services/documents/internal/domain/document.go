@@ -155,3 +155,16 @@ type ListDocumentsParams struct {
 	IsPublic       *bool
 	Search         string
 }
+
+// FullTextSearchParams represents parameters for keyword search over document metadata
+type FullTextSearchParams struct {
+	Query    string
+	Limit    int
+	IsPublic *bool
+}
+
+// FullTextMatch represents a document matched by keyword search with its relevance rank
+type FullTextMatch struct {
+	DocumentID uuid.UUID
+	Rank       float32
+}
@@ -698,7 +698,184 @@ func (v *ListDocumentsParams) UnmarshalJSON(data []byte) error {
 func (v *ListDocumentsParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain2(l, v)
 }
-func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(in *jlexer.Lexer, out *DocumentVersion) {
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(in *jlexer.Lexer, out *FullTextSearchParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "Query":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Query = string(in.String())
+			}
+		case "Limit":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Limit = int(in.Int())
+			}
+		case "IsPublic":
+			if in.IsNull() {
+				in.Skip()
+				out.IsPublic = nil
+			} else {
+				if out.IsPublic == nil {
+					out.IsPublic = new(bool)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					*out.IsPublic = bool(in.Bool())
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(out *jwriter.Writer, in FullTextSearchParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Query\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Query))
+	}
+	{
+		const prefix string = ",\"Limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
+	}
+	{
+		const prefix string = ",\"IsPublic\":"
+		out.RawString(prefix)
+		if in.IsPublic == nil {
+			out.RawString("null")
+		} else {
+			out.Bool(bool(*in.IsPublic))
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FullTextSearchParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FullTextSearchParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FullTextSearchParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FullTextSearchParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(l, v)
+}
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(in *jlexer.Lexer, out *FullTextMatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "DocumentID":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.DocumentID).UnmarshalText(data))
+				}
+			}
+		case "Rank":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Rank = float32(in.Float32())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(out *jwriter.Writer, in FullTextMatch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"DocumentID\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.DocumentID).MarshalText())
+	}
+	{
+		const prefix string = ",\"Rank\":"
+		out.RawString(prefix)
+		out.Float32(float32(in.Rank))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FullTextMatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FullTextMatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FullTextMatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FullTextMatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(l, v)
+}
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(in *jlexer.Lexer, out *DocumentVersion) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -788,7 +965,7 @@ func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 		in.Consumed()
 	}
 }
-func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(out *jwriter.Writer, in DocumentVersion) {
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(out *jwriter.Writer, in DocumentVersion) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -833,27 +1010,27 @@ func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 // MarshalJSON supports json.Marshaler interface
 func (v DocumentVersion) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(&w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v DocumentVersion) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *DocumentVersion) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(&r, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *DocumentVersion) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain3(l, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(l, v)
 }
-func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(in *jlexer.Lexer, out *DocumentType) {
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(in *jlexer.Lexer, out *DocumentType) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -897,7 +1074,7 @@ func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 		in.Consumed()
 	}
 }
-func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(out *jwriter.Writer, in DocumentType) {
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(out *jwriter.Writer, in DocumentType) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -922,27 +1099,27 @@ func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 // MarshalJSON supports json.Marshaler interface
 func (v DocumentType) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(&w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v DocumentType) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *DocumentType) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(&r, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *DocumentType) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain4(l, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(l, v)
 }
-func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(in *jlexer.Lexer, out *DocumentParticipantRef) {
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(in *jlexer.Lexer, out *DocumentParticipantRef) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -980,7 +1157,7 @@ func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 		in.Consumed()
 	}
 }
-func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(out *jwriter.Writer, in DocumentParticipantRef) {
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(out *jwriter.Writer, in DocumentParticipantRef) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1000,27 +1177,27 @@ func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 // MarshalJSON supports json.Marshaler interface
 func (v DocumentParticipantRef) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(&w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v DocumentParticipantRef) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *DocumentParticipantRef) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(&r, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *DocumentParticipantRef) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain5(l, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(l, v)
 }
-func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(in *jlexer.Lexer, out *DocumentParticipant) {
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(in *jlexer.Lexer, out *DocumentParticipant) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1056,7 +1233,7 @@ func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 		in.Consumed()
 	}
 }
-func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(out *jwriter.Writer, in DocumentParticipant) {
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(out *jwriter.Writer, in DocumentParticipant) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1076,27 +1253,27 @@ func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 // MarshalJSON supports json.Marshaler interface
 func (v DocumentParticipant) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(&w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v DocumentParticipant) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *DocumentParticipant) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(&r, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *DocumentParticipant) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain6(l, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(l, v)
 }
-func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(in *jlexer.Lexer, out *Document) {
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(in *jlexer.Lexer, out *Document) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1340,7 +1517,7 @@ func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 		in.Consumed()
 	}
 }
-func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(out *jwriter.Writer, in Document) {
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(out *jwriter.Writer, in Document) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1463,27 +1640,27 @@ func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 // MarshalJSON supports json.Marshaler interface
 func (v Document) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(&w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Document) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Document) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(&r, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Document) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain7(l, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(l, v)
 }
-func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(in *jlexer.Lexer, out *CreateDocumentRequest) {
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(in *jlexer.Lexer, out *CreateDocumentRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1637,7 +1814,7 @@ func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 		in.Consumed()
 	}
 }
-func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(out *jwriter.Writer, in CreateDocumentRequest) {
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(out *jwriter.Writer, in CreateDocumentRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1715,27 +1892,27 @@ func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 // MarshalJSON supports json.Marshaler interface
 func (v CreateDocumentRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(&w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CreateDocumentRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CreateDocumentRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(&r, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CreateDocumentRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain8(l, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(l, v)
 }
-func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(in *jlexer.Lexer, out *Category) {
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(in *jlexer.Lexer, out *Category) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1793,7 +1970,7 @@ func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 		in.Consumed()
 	}
 }
-func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(out *jwriter.Writer, in Category) {
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(out *jwriter.Writer, in Category) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1823,27 +2000,27 @@ func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 // MarshalJSON supports json.Marshaler interface
 func (v Category) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(&w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Category) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Category) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(&r, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Category) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain9(l, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(l, v)
 }
-func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(in *jlexer.Lexer, out *AuthorshipType) {
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain12(in *jlexer.Lexer, out *AuthorshipType) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1887,7 +2064,7 @@ func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 		in.Consumed()
 	}
 }
-func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(out *jwriter.Writer, in AuthorshipType) {
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain12(out *jwriter.Writer, in AuthorshipType) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1912,27 +2089,27 @@ func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 // MarshalJSON supports json.Marshaler interface
 func (v AuthorshipType) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(&w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain12(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AuthorshipType) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain12(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AuthorshipType) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(&r, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain12(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AuthorshipType) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain10(l, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain12(l, v)
 }
-func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(in *jlexer.Lexer, out *Author) {
+func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain13(in *jlexer.Lexer, out *Author) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2000,7 +2177,7 @@ func easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 		in.Consumed()
 	}
 }
-func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(out *jwriter.Writer, in Author) {
+func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain13(out *jwriter.Writer, in Author) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2035,23 +2212,23 @@ func easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 // MarshalJSON supports json.Marshaler interface
 func (v Author) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(&w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain13(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Author) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(w, v)
+	easyjson18605acbEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain13(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Author) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(&r, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain13(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Author) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain11(l, v)
+	easyjson18605acbDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDomain13(l, v)
 }
@@ -23,6 +23,7 @@ type DocumentCreatedEvent struct {
 	Version         int                        `json:"version"`
 	Participants    []DocumentEventParticipant `json:"participants,omitempty"`
 	Tags            []DocumentEventTag         `json:"tags,omitempty"`
+	IsPublic        bool                       `json:"is_public"`
 	Timestamp       time.Time                  `json:"timestamp"`
 }
 
@@ -43,6 +44,7 @@ type DocumentUpdatedEvent struct {
 	Changes         *string                    `json:"changes,omitempty"`
 	Participants    []DocumentEventParticipant `json:"participants,omitempty"`
 	Tags            []DocumentEventTag         `json:"tags,omitempty"`
+	IsPublic        bool                       `json:"is_public"`
 	Timestamp       time.Time                  `json:"timestamp"`
 }
 
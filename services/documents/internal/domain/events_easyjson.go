@@ -179,6 +179,12 @@ func easyjson692db02bDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 				}
 				in.Delim(']')
 			}
+		case "is_public":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.IsPublic = bool(in.Bool())
+			}
 		case "timestamp":
 			if in.IsNull() {
 				in.Skip()
@@ -284,6 +290,11 @@ func easyjson692db02bEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"is_public\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsPublic))
+	}
 	{
 		const prefix string = ",\"timestamp\":"
 		out.RawString(prefix)
@@ -815,6 +826,12 @@ func easyjson692db02bDecodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 				}
 				in.Delim(']')
 			}
+		case "is_public":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.IsPublic = bool(in.Bool())
+			}
 		case "timestamp":
 			if in.IsNull() {
 				in.Skip()
@@ -910,6 +927,11 @@ func easyjson692db02bEncodeGithubComArtmexbetRaibecasServicesDocumentsInternalDo
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"is_public\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsPublic))
+	}
 	{
 		const prefix string = ",\"timestamp\":"
 		out.RawString(prefix)
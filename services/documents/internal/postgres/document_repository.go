@@ -109,6 +109,28 @@ func (r *DocumentRepository) Count(ctx context.Context, params domain.ListDocume
 	return int(count), nil
 }
 
+// SearchFullText finds documents whose title or description match the query, ordered by rank.
+func (r *DocumentRepository) SearchFullText(ctx context.Context, params domain.FullTextSearchParams) ([]domain.FullTextMatch, error) {
+	rows, err := r.queries.SearchDocumentsFullText(ctx, queries.SearchDocumentsFullTextParams{
+		Query:    params.Query,
+		IsPublic: params.IsPublic,
+		Limit:    int32(params.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search documents full text: %w", err)
+	}
+
+	result := make([]domain.FullTextMatch, len(rows))
+	for i, row := range rows {
+		result[i] = domain.FullTextMatch{
+			DocumentID: row.ID,
+			Rank:       row.Rank,
+		}
+	}
+
+	return result, nil
+}
+
 // Update updates a document.
 func (r *DocumentRepository) Update(ctx context.Context, doc *domain.Document) error {
 	currentVersion := int32(doc.CurrentVersion)
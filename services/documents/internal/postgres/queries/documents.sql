@@ -314,3 +314,16 @@ ORDER BY t.title;
 -- name: ClearDocumentTags :exec
 DELETE FROM document_tags
 WHERE document_id = $1;
+
+-- name: SearchDocumentsFullText :many
+SELECT
+    d.id,
+    ts_rank(
+        to_tsvector('russian', d.title || ' ' || COALESCE(d.description, '')),
+        plainto_tsquery('russian', sqlc.arg('query')::text)
+    )::real AS rank
+FROM documents d
+WHERE to_tsvector('russian', d.title || ' ' || COALESCE(d.description, '')) @@ plainto_tsquery('russian', sqlc.arg('query')::text)
+  AND (sqlc.narg('is_public')::boolean IS NULL OR d.is_public = sqlc.narg('is_public')::boolean)
+ORDER BY rank DESC
+LIMIT sqlc.arg('limit');
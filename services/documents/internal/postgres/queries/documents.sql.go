@@ -1210,6 +1210,64 @@ func (q *Queries) RemoveDocumentTag(ctx context.Context, arg RemoveDocumentTagPa
 	return err
 }
 
+const searchDocumentsFullText = `-- name: SearchDocumentsFullText :many
+SELECT
+    d.id,
+    ts_rank(
+        to_tsvector('russian', d.title || ' ' || COALESCE(d.description, '')),
+        plainto_tsquery('russian', $1::text)
+    )::real AS rank
+FROM documents d
+WHERE to_tsvector('russian', d.title || ' ' || COALESCE(d.description, '')) @@ plainto_tsquery('russian', $1::text)
+  AND ($2::boolean IS NULL OR d.is_public = $2::boolean)
+ORDER BY rank DESC
+LIMIT $3
+`
+
+type SearchDocumentsFullTextParams struct {
+	Query    string
+	IsPublic *bool
+	Limit    int32
+}
+
+type SearchDocumentsFullTextRow struct {
+	ID   uuid.UUID
+	Rank float32
+}
+
+// SearchDocumentsFullText
+//
+//	SELECT
+//	    d.id,
+//	    ts_rank(
+//	        to_tsvector('russian', d.title || ' ' || COALESCE(d.description, '')),
+//	        plainto_tsquery('russian', $1::text)
+//	    )::real AS rank
+//	FROM documents d
+//	WHERE to_tsvector('russian', d.title || ' ' || COALESCE(d.description, '')) @@ plainto_tsquery('russian', $1::text)
+//	  AND ($2::boolean IS NULL OR d.is_public = $2::boolean)
+//	ORDER BY rank DESC
+//	LIMIT $3
+func (q *Queries) SearchDocumentsFullText(ctx context.Context, arg SearchDocumentsFullTextParams) ([]SearchDocumentsFullTextRow, error) {
+	rows, err := q.db.Query(ctx, searchDocumentsFullText, arg.Query, arg.IsPublic, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchDocumentsFullTextRow{}
+	for rows.Next() {
+		var i SearchDocumentsFullTextRow
+		if err := rows.Scan(&i.ID, &i.Rank); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateDocument = `-- name: UpdateDocument :one
 UPDATE documents
 SET
@@ -30,17 +30,11 @@ func NewDocumentHandler(service *service.DocumentService, logger *slog.Logger) *
 }
 
 // HandleCreateDocument handles document creation requests
-func (h *DocumentHandler) HandleCreateDocument(msg *natsw.Message) error {
-	var req documents.CreateDocumentRequest
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid create document request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleCreateDocument(msg *natsw.Message, req *documents.CreateDocumentRequest) (*documents.CreateDocumentResponse, error) {
 	// Check authorization (admin only)
 	if !h.isAdmin(msg) {
 		h.logger.WarnContext(msg.Ctx, "unauthorized create document attempt")
-		return h.respondError(msg, dto.ErrCodeUnauthorized)
+		return nil, natsw.NewRPCError(dto.ErrCodeUnauthorized, "")
 	}
 
 	// Convert to domain type
@@ -59,11 +53,10 @@ func (h *DocumentHandler) HandleCreateDocument(msg *natsw.Message) error {
 
 	doc, err := h.service.CreateDocument(msg.Ctx, domainReq)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to create document", "error", err)
 		if errors.Is(err, service.ErrInvalidInput) {
-			return h.respondError(msg, dto.ErrCodeInvalidRequest)
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 		}
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to create document: %w", err)
 	}
 
 	// Convert domain document to dto
@@ -72,29 +65,22 @@ func (h *DocumentHandler) HandleCreateDocument(msg *natsw.Message) error {
 		Document: dtoDoc,
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleGetDocument handles document retrieval requests
-func (h *DocumentHandler) HandleGetDocument(msg *natsw.Message) error {
-	var req documents.GetDocumentRequest
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid get document request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleGetDocument(msg *natsw.Message, req *documents.GetDocumentRequest) (*documents.GetDocumentResponse, error) {
 	doc, err := h.service.GetDocument(msg.Ctx, req.ID)
 	if err != nil {
 		if errors.Is(err, service.ErrNotFound) {
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		}
-		h.logger.ErrorContext(msg.Ctx, "failed to get document", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to get document: %w", err)
 	}
 
 	// Non-admin users cannot access non-public documents
 	if !h.isAdmin(msg) && !doc.IsPublic {
-		return h.respondError(msg, dto.ErrCodeNotFound)
+		return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 	}
 
 	// Convert domain document to dto
@@ -103,41 +89,28 @@ func (h *DocumentHandler) HandleGetDocument(msg *natsw.Message) error {
 		Document: dtoDoc,
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleGetDocumentContent handles document content retrieval requests
-func (h *DocumentHandler) HandleGetDocumentContent(msg *natsw.Message) error {
-	var req documents.GetDocumentContentRequest
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid get document content request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleGetDocumentContent(msg *natsw.Message, req *documents.GetDocumentContentRequest) (*documents.GetDocumentContentResponse, error) {
 	content, err := h.service.GetDocumentContent(msg.Ctx, req.ID)
 	if err != nil {
 		if errors.Is(err, service.ErrNotFound) {
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		}
-		h.logger.ErrorContext(msg.Ctx, "failed to get document content", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to get document content: %w", err)
 	}
 
 	response := documents.GetDocumentContentResponse{
 		Content: string(content),
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleListDocuments handles document listing requests
-func (h *DocumentHandler) HandleListDocuments(msg *natsw.Message) error {
-	var req documents.ListDocumentsQuery
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid list documents request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleListDocuments(msg *natsw.Message, req *documents.ListDocumentsQuery) (*documents.ListDocumentsResponse, error) {
 	// Set default limit
 	limit := req.Limit
 	if limit <= 0 || limit > 100 {
@@ -179,8 +152,7 @@ func (h *DocumentHandler) HandleListDocuments(msg *natsw.Message) error {
 		Search:         req.Search,
 	})
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to list documents", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to list documents: %w", err)
 	}
 
 	// Convert domain documents to dto
@@ -194,17 +166,47 @@ func (h *DocumentHandler) HandleListDocuments(msg *natsw.Message) error {
 		Total:     total,
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
-// HandleListBookmarks handles bookmark listing requests.
-func (h *DocumentHandler) HandleListBookmarks(msg *natsw.Message) error {
-	var req documents.ListBookmarksQuery
-	if err := msg.UnmarshalEasyJSON(&req); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid list bookmarks request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
+// HandleSearchFullText handles keyword search requests used by hybrid retrieval
+func (h *DocumentHandler) HandleSearchFullText(msg *natsw.Message, req *documents.FullTextSearchRequest) (*documents.FullTextSearchResponse, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
 	}
 
+	// For non-admin users, only match public documents
+	var isPublic *bool
+	if !h.isAdmin(msg) {
+		truthy := true
+		isPublic = &truthy
+	}
+
+	matches, err := h.service.SearchFullText(msg.Ctx, domain.FullTextSearchParams{
+		Query:    req.Query,
+		Limit:    limit,
+		IsPublic: isPublic,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search documents: %w", err)
+	}
+
+	response := documents.FullTextSearchResponse{
+		Matches: make([]documents.FullTextMatch, len(matches)),
+	}
+	for i, match := range matches {
+		response.Matches[i] = documents.FullTextMatch{
+			DocumentID: match.DocumentID,
+			Rank:       match.Rank,
+		}
+	}
+
+	return &response, nil
+}
+
+// HandleListBookmarks handles bookmark listing requests.
+func (h *DocumentHandler) HandleListBookmarks(msg *natsw.Message, req *documents.ListBookmarksQuery) (*documents.ListBookmarksResponse, error) {
 	page := max(req.Page, 1)
 	limit := req.Limit
 	if limit <= 0 || limit > 100 {
@@ -216,7 +218,7 @@ func (h *DocumentHandler) HandleListBookmarks(msg *natsw.Message) error {
 			userID, err := uuid.Parse(userIDValue)
 			if err != nil {
 				h.logger.ErrorContext(msg.Ctx, "invalid X-User-ID header", "error", err)
-				return h.respondError(msg, dto.ErrCodeInvalidRequest)
+				return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 			}
 			req.UserID = userID
 		}
@@ -230,11 +232,10 @@ func (h *DocumentHandler) HandleListBookmarks(msg *natsw.Message) error {
 		UserID: req.UserID,
 	})
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to list bookmarks", "error", err)
 		if errors.Is(err, service.ErrInvalidInput) {
-			return h.respondError(msg, dto.ErrCodeInvalidRequest)
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 		}
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
 	}
 
 	totalPages := 0
@@ -255,23 +256,17 @@ func (h *DocumentHandler) HandleListBookmarks(msg *natsw.Message) error {
 		TotalPages: totalPages,
 	}
 
-	return msg.RespondEasyJSON(response)
+	return &response, nil
 }
 
 // HandleCreateBookmark handles bookmark creation requests.
-func (h *DocumentHandler) HandleCreateBookmark(msg *natsw.Message) error {
-	var req documents.CreateBookmarkRequest
-	if err := msg.UnmarshalEasyJSON(&req); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid create bookmark request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleCreateBookmark(msg *natsw.Message, req *documents.CreateBookmarkRequest) (*documents.CreateBookmarkResponse, error) {
 	if req.UserID == uuid.Nil {
 		if userIDValue := msg.Header.Get("X-User-ID"); userIDValue != "" {
 			userID, err := uuid.Parse(userIDValue)
 			if err != nil {
 				h.logger.ErrorContext(msg.Ctx, "invalid X-User-ID header", "error", err)
-				return h.respondError(msg, dto.ErrCodeInvalidRequest)
+				return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 			}
 			req.UserID = userID
 		}
@@ -286,68 +281,54 @@ func (h *DocumentHandler) HandleCreateBookmark(msg *natsw.Message) error {
 		PageLabel:  req.PageLabel,
 	})
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to create bookmark", "error", err)
 		switch {
 		case errors.Is(err, service.ErrInvalidInput):
-			return h.respondError(msg, dto.ErrCodeInvalidRequest)
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 		case errors.Is(err, service.ErrNotFound):
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		default:
-			return h.respondError(msg, dto.ErrCodeInternal)
+			return nil, fmt.Errorf("failed to create bookmark: %w", err)
 		}
 	}
 
 	response := documents.CreateBookmarkResponse{Item: convertBookmarkDomainToDTOItem(*item)}
-	return msg.RespondEasyJSON(response)
+	return &response, nil
 }
 
 // HandleDeleteBookmark handles bookmark deletion requests.
-func (h *DocumentHandler) HandleDeleteBookmark(msg *natsw.Message) error {
-	var req documents.DeleteBookmarkRequest
-	if err := msg.UnmarshalEasyJSON(&req); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid delete bookmark request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleDeleteBookmark(msg *natsw.Message, req *documents.DeleteBookmarkRequest) (*documents.DeleteBookmarkResponse, error) {
 	if req.UserID == uuid.Nil {
 		if userIDValue := msg.Header.Get("X-User-ID"); userIDValue != "" {
 			userID, err := uuid.Parse(userIDValue)
 			if err != nil {
 				h.logger.ErrorContext(msg.Ctx, "invalid X-User-ID header", "error", err)
-				return h.respondError(msg, dto.ErrCodeInvalidRequest)
+				return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 			}
 			req.UserID = userID
 		}
 	}
 
 	if err := h.service.DeleteBookmark(msg.Ctx, req.UserID, req.ID); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to delete bookmark", "error", err)
 		switch {
 		case errors.Is(err, service.ErrInvalidInput):
-			return h.respondError(msg, dto.ErrCodeInvalidRequest)
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 		case errors.Is(err, service.ErrNotFound):
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		default:
-			return h.respondError(msg, dto.ErrCodeInternal)
+			return nil, fmt.Errorf("failed to delete bookmark: %w", err)
 		}
 	}
 
 	response := documents.DeleteBookmarkResponse{Success: true}
-	return msg.RespondEasyJSON(response)
+	return &response, nil
 }
 
 // HandleUpdateDocument handles document update requests
-func (h *DocumentHandler) HandleUpdateDocument(msg *natsw.Message) error {
-	var req documents.UpdateDocumentRequest
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid update document request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleUpdateDocument(msg *natsw.Message, req *documents.UpdateDocumentRequest) (*documents.UpdateDocumentResponse, error) {
 	// Check authorization (admin only)
 	if !h.isAdmin(msg) {
 		h.logger.WarnContext(msg.Ctx, "unauthorized update document attempt")
-		return h.respondError(msg, dto.ErrCodeUnauthorized)
+		return nil, natsw.NewRPCError(dto.ErrCodeUnauthorized, "")
 	}
 
 	// Convert to domain type
@@ -368,10 +349,9 @@ func (h *DocumentHandler) HandleUpdateDocument(msg *natsw.Message) error {
 	doc, err := h.service.UpdateDocument(msg.Ctx, req.ID, domainReq)
 	if err != nil {
 		if errors.Is(err, service.ErrNotFound) {
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		}
-		h.logger.ErrorContext(msg.Ctx, "failed to update document", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to update document: %w", err)
 	}
 
 	// Convert domain document to dto
@@ -380,50 +360,36 @@ func (h *DocumentHandler) HandleUpdateDocument(msg *natsw.Message) error {
 		Document: dtoDoc,
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleDeleteDocument handles document deletion requests
-func (h *DocumentHandler) HandleDeleteDocument(msg *natsw.Message) error {
-	var req documents.DeleteDocumentRequest
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid delete document request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleDeleteDocument(msg *natsw.Message, req *documents.DeleteDocumentRequest) (*documents.DeleteDocumentResponse, error) {
 	// Check authorization (admin only)
 	if !h.isAdmin(msg) {
 		h.logger.WarnContext(msg.Ctx, "unauthorized delete document attempt")
-		return h.respondError(msg, dto.ErrCodeUnauthorized)
+		return nil, natsw.NewRPCError(dto.ErrCodeUnauthorized, "")
 	}
 
 	if err := h.service.DeleteDocument(msg.Ctx, req.ID); err != nil {
 		if errors.Is(err, service.ErrNotFound) {
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		}
-		h.logger.ErrorContext(msg.Ctx, "failed to delete document", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to delete document: %w", err)
 	}
 
 	response := documents.DeleteDocumentResponse{
 		Success: true,
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleListDocumentVersions handles document versions listing requests
-func (h *DocumentHandler) HandleListDocumentVersions(msg *natsw.Message) error {
-	var req documents.ListDocumentVersionsRequest
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid list versions request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleListDocumentVersions(msg *natsw.Message, req *documents.ListDocumentVersionsRequest) (*documents.ListDocumentVersionsResponse, error) {
 	versions, err := h.service.ListDocumentVersions(msg.Ctx, req.ID)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to list document versions", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to list document versions: %w", err)
 	}
 
 	// Convert domain versions to dto
@@ -444,11 +410,12 @@ func (h *DocumentHandler) HandleListDocumentVersions(msg *natsw.Message) error {
 		Versions: dtoVersions,
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleDocumentIndexed handles document indexed events from index-python via JetStream.
-// Returns an error to trigger NAK and redelivery on transient failures.
+// Returns an error to trigger NAK and redelivery on transient failures and a permanent
+// error to move events that can never succeed to the dead-letter stream.
 func (h *DocumentHandler) HandleDocumentIndexed(msg *natsw.Message) error {
 	h.logger.InfoContext(msg.Ctx, "received document indexed event",
 		"subject", msg.Subject,
@@ -457,12 +424,12 @@ func (h *DocumentHandler) HandleDocumentIndexed(msg *natsw.Message) error {
 
 	var event domain.DocumentIndexedEvent
 	if err := event.UnmarshalJSON(msg.Data); err != nil {
-		// Deserialization error is permanent — retrying won't help, so ACK (return nil).
-		h.logger.ErrorContext(msg.Ctx, "invalid document indexed event payload, skipping",
+		// Deserialization error is permanent — retrying won't help, so quarantine the event.
+		h.logger.ErrorContext(msg.Ctx, "invalid document indexed event payload, moving to dead-letter stream",
 			"error", err,
 			"raw_data", string(msg.Data),
 		)
-		return nil
+		return natsw.Permanent(fmt.Errorf("unmarshal document indexed event: %w", err))
 	}
 
 	h.logger.InfoContext(msg.Ctx, "parsed document indexed event",
@@ -493,59 +460,45 @@ func (h *DocumentHandler) HandleDocumentIndexed(msg *natsw.Message) error {
 }
 
 // HandleUploadCover handles cover image upload requests
-func (h *DocumentHandler) HandleUploadCover(msg *natsw.Message) error {
-	var req documents.UploadCoverRequest
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid upload cover request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleUploadCover(msg *natsw.Message, req *documents.UploadCoverRequest) (*documents.UploadCoverResponse, error) {
 	// Check authorization (admin only)
 	if !h.isAdmin(msg) {
 		h.logger.WarnContext(msg.Ctx, "unauthorized upload cover attempt")
-		return h.respondError(msg, dto.ErrCodeUnauthorized)
+		return nil, natsw.NewRPCError(dto.ErrCodeUnauthorized, "")
 	}
 
 	coverURL, err := h.service.UploadCover(msg.Ctx, req.ID, req.Data, req.ContentType)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to upload cover", "error", err)
 		if errors.Is(err, service.ErrNotFound) {
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		}
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to upload cover: %w", err)
 	}
 
 	response := documents.UploadCoverResponse{
 		CoverURL: coverURL,
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleReindexDocument handles document reindex requests
-func (h *DocumentHandler) HandleReindexDocument(msg *natsw.Message) error {
-	var req documents.ReindexDocumentRequest
-	if err := msg.UnmarshalEasyJSON(&req); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid reindex document request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleReindexDocument(msg *natsw.Message, req *documents.ReindexDocumentRequest) (*documents.ReindexDocumentResponse, error) {
 	// Check authorization (admin only)
 	if !h.isAdmin(msg) {
 		h.logger.WarnContext(msg.Ctx, "unauthorized reindex document attempt")
-		return h.respondError(msg, dto.ErrCodeUnauthorized)
+		return nil, natsw.NewRPCError(dto.ErrCodeUnauthorized, "")
 	}
 
 	if err := h.service.ReindexDocument(msg.Ctx, req.ID); err != nil {
 		if errors.Is(err, service.ErrNotFound) {
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		}
-		h.logger.ErrorContext(msg.Ctx, "failed to reindex document", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to reindex document: %w", err)
 	}
 
 	response := documents.ReindexDocumentResponse{Success: true}
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // isAdmin checks if the user has admin role from message context
@@ -555,15 +508,6 @@ func (h *DocumentHandler) isAdmin(msg *natsw.Message) bool {
 	return role == "Admin" || role == "SuperAdmin"
 }
 
-// respondError sends an error response using easyjson
-func (h *DocumentHandler) respondError(msg *natsw.Message, errCode dto.ErrorCode) error {
-	resp := &dto.ErrorResponse{
-		Success: false,
-		Error:   string(errCode),
-	}
-	return msg.RespondEasyJSON(resp)
-}
-
 // convertDomainToDTO converts domain.Document to documents.Document DTO
 func convertDomainToDTO(doc domain.Document) documents.Document {
 	dtoDoc := documents.Document{
@@ -695,13 +639,7 @@ func intPtrValue(value *int) int {
 // --- Notes handlers ---
 
 // HandleListNotes handles note listing requests.
-func (h *DocumentHandler) HandleListNotes(msg *natsw.Message) error {
-	var req documents.ListNotesQuery
-	if err := msg.UnmarshalEasyJSON(&req); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid list notes request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleListNotes(msg *natsw.Message, req *documents.ListNotesQuery) (*documents.ListNotesResponse, error) {
 	page := max(req.Page, 1)
 	limit := req.Limit
 	if limit <= 0 || limit > 100 {
@@ -713,7 +651,7 @@ func (h *DocumentHandler) HandleListNotes(msg *natsw.Message) error {
 			userID, err := uuid.Parse(userIDValue)
 			if err != nil {
 				h.logger.ErrorContext(msg.Ctx, "invalid X-User-ID header", "error", err)
-				return h.respondError(msg, dto.ErrCodeInvalidRequest)
+				return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 			}
 			req.UserID = userID
 		}
@@ -728,11 +666,10 @@ func (h *DocumentHandler) HandleListNotes(msg *natsw.Message) error {
 		UserID:     req.UserID,
 	})
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to list notes", "error", err)
 		if errors.Is(err, service.ErrInvalidInput) {
-			return h.respondError(msg, dto.ErrCodeInvalidRequest)
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 		}
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to list notes: %w", err)
 	}
 
 	totalPages := 0
@@ -753,44 +690,31 @@ func (h *DocumentHandler) HandleListNotes(msg *natsw.Message) error {
 		TotalPages: totalPages,
 	}
 
-	return msg.RespondEasyJSON(response)
+	return &response, nil
 }
 
 // HandleGetNote handles note retrieval requests.
-func (h *DocumentHandler) HandleGetNote(msg *natsw.Message) error {
-	var req documents.GetNoteRequest
-	if err := msg.UnmarshalEasyJSON(&req); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid get note request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleGetNote(msg *natsw.Message, req *documents.GetNoteRequest) (*documents.GetNoteResponse, error) {
 	note, err := h.service.GetNote(msg.Ctx, req.UserID, req.ID)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to get note", "error", err)
 		if errors.Is(err, service.ErrNotFound) {
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		}
 		if errors.Is(err, service.ErrInvalidInput) {
-			return h.respondError(msg, dto.ErrCodeInvalidRequest)
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 		}
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to get note: %w", err)
 	}
 
 	response := documents.GetNoteResponse{
 		Item: convertNoteDomainToDTO(*note),
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleCreateNote handles note creation requests.
-func (h *DocumentHandler) HandleCreateNote(msg *natsw.Message) error {
-	var req documents.CreateNoteRequest
-	if err := msg.UnmarshalEasyJSON(&req); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid create note request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleCreateNote(msg *natsw.Message, req *documents.CreateNoteRequest) (*documents.CreateNoteResponse, error) {
 	note, err := h.service.CreateNote(msg.Ctx, domain.CreateNoteRequest{
 		UserID:             req.UserID,
 		Title:              req.Title,
@@ -800,31 +724,24 @@ func (h *DocumentHandler) HandleCreateNote(msg *natsw.Message) error {
 		PositionInDocument: req.PositionInDocument,
 	})
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to create note", "error", err)
 		if errors.Is(err, service.ErrInvalidInput) {
-			return h.respondError(msg, dto.ErrCodeInvalidRequest)
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 		}
 		if errors.Is(err, service.ErrNotFound) {
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		}
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to create note: %w", err)
 	}
 
 	response := documents.CreateNoteResponse{
 		Item: convertNoteDomainToDTO(*note),
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleUpdateNote handles note update requests.
-func (h *DocumentHandler) HandleUpdateNote(msg *natsw.Message) error {
-	var req documents.UpdateNoteRequest
-	if err := msg.UnmarshalEasyJSON(&req); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid update note request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleUpdateNote(msg *natsw.Message, req *documents.UpdateNoteRequest) (*documents.UpdateNoteResponse, error) {
 	note, err := h.service.UpdateNote(msg.Ctx, domain.UpdateNoteRequest{
 		ID:                 req.ID,
 		UserID:             req.UserID,
@@ -838,44 +755,36 @@ func (h *DocumentHandler) HandleUpdateNote(msg *natsw.Message) error {
 		ClearPosition:      req.ClearPosition,
 	})
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to update note", "error", err)
 		if errors.Is(err, service.ErrNotFound) {
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		}
 		if errors.Is(err, service.ErrInvalidInput) {
-			return h.respondError(msg, dto.ErrCodeInvalidRequest)
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 		}
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to update note: %w", err)
 	}
 
 	response := documents.UpdateNoteResponse{
 		Item: convertNoteDomainToDTO(*note),
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleDeleteNote handles note deletion requests.
-func (h *DocumentHandler) HandleDeleteNote(msg *natsw.Message) error {
-	var req documents.DeleteNoteRequest
-	if err := msg.UnmarshalEasyJSON(&req); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid delete note request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *DocumentHandler) HandleDeleteNote(msg *natsw.Message, req *documents.DeleteNoteRequest) (*documents.DeleteNoteResponse, error) {
 	if err := h.service.DeleteNote(msg.Ctx, req.UserID, req.ID); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to delete note", "error", err)
 		if errors.Is(err, service.ErrNotFound) {
-			return h.respondError(msg, dto.ErrCodeNotFound)
+			return nil, natsw.NewRPCError(dto.ErrCodeNotFound, "")
 		}
 		if errors.Is(err, service.ErrInvalidInput) {
-			return h.respondError(msg, dto.ErrCodeInvalidRequest)
+			return nil, natsw.NewRPCError(dto.ErrCodeInvalidRequest, "")
 		}
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to delete note: %w", err)
 	}
 
 	response := documents.DeleteNoteResponse{Success: true}
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 func convertNoteDomainToDTO(note domain.Note) documents.NoteItem {
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"fmt"
 	"log/slog"
 
 	"github.com/artmexbet/raibecas/libs/dto"
@@ -25,11 +26,10 @@ func NewMetadataHandler(service *service.DocumentService, logger *slog.Logger) *
 }
 
 // HandleListAuthors handles list authors requests
-func (h *MetadataHandler) HandleListAuthors(msg *natsw.Message) error {
+func (h *MetadataHandler) HandleListAuthors(msg *natsw.Message, _ *struct{}) (*documents.ListAuthorsResponse, error) {
 	authors, err := h.service.ListAuthors(msg.Ctx)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to list authors", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to list authors: %w", err)
 	}
 
 	// Convert to dto
@@ -48,27 +48,20 @@ func (h *MetadataHandler) HandleListAuthors(msg *natsw.Message) error {
 		Authors: dtoAuthors,
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleCreateAuthor handles create author requests
-func (h *MetadataHandler) HandleCreateAuthor(msg *natsw.Message) error {
-	var req documents.CreateAuthorRequest
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid create author request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *MetadataHandler) HandleCreateAuthor(msg *natsw.Message, req *documents.CreateAuthorRequest) (*documents.CreateAuthorResponse, error) {
 	// Check authorization (admin only)
 	if !h.isAdmin(msg) {
 		h.logger.WarnContext(msg.Ctx, "unauthorized create author attempt")
-		return h.respondError(msg, dto.ErrCodeUnauthorized)
+		return nil, natsw.NewRPCError(dto.ErrCodeUnauthorized, "")
 	}
 
 	author, err := h.service.CreateAuthor(msg.Ctx, req.Name)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to create author", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to create author: %w", err)
 	}
 
 	response := documents.CreateAuthorResponse{
@@ -81,15 +74,14 @@ func (h *MetadataHandler) HandleCreateAuthor(msg *natsw.Message) error {
 		},
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleListCategories handles list categories requests
-func (h *MetadataHandler) HandleListCategories(msg *natsw.Message) error {
+func (h *MetadataHandler) HandleListCategories(msg *natsw.Message, _ *struct{}) (*documents.ListCategoriesResponse, error) {
 	categories, err := h.service.ListCategories(msg.Ctx)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to list categories", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to list categories: %w", err)
 	}
 
 	// Convert to dto
@@ -107,27 +99,20 @@ func (h *MetadataHandler) HandleListCategories(msg *natsw.Message) error {
 		Categories: dtoCategories,
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleCreateCategory handles create category requests
-func (h *MetadataHandler) HandleCreateCategory(msg *natsw.Message) error {
-	var req documents.CreateCategoryRequest
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid create category request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *MetadataHandler) HandleCreateCategory(msg *natsw.Message, req *documents.CreateCategoryRequest) (*documents.CreateCategoryResponse, error) {
 	// Check authorization (admin only)
 	if !h.isAdmin(msg) {
 		h.logger.WarnContext(msg.Ctx, "unauthorized create category attempt")
-		return h.respondError(msg, dto.ErrCodeUnauthorized)
+		return nil, natsw.NewRPCError(dto.ErrCodeUnauthorized, "")
 	}
 
 	category, err := h.service.CreateCategory(msg.Ctx, req.Title)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to create category", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to create category: %w", err)
 	}
 
 	response := documents.CreateCategoryResponse{
@@ -139,15 +124,14 @@ func (h *MetadataHandler) HandleCreateCategory(msg *natsw.Message) error {
 		},
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleListDocumentTypes handles list document types requests.
-func (h *MetadataHandler) HandleListDocumentTypes(msg *natsw.Message) error {
+func (h *MetadataHandler) HandleListDocumentTypes(msg *natsw.Message, _ *struct{}) (*documents.ListDocumentTypesResponse, error) {
 	documentTypes, err := h.service.ListDocumentTypes(msg.Ctx)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to list document types", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to list document types: %w", err)
 	}
 
 	dtoTypes := make([]documents.DocumentType, len(documentTypes))
@@ -160,15 +144,14 @@ func (h *MetadataHandler) HandleListDocumentTypes(msg *natsw.Message) error {
 	}
 
 	response := documents.ListDocumentTypesResponse{DocumentTypes: dtoTypes}
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleListAuthorshipTypes handles list authorship types requests.
-func (h *MetadataHandler) HandleListAuthorshipTypes(msg *natsw.Message) error {
+func (h *MetadataHandler) HandleListAuthorshipTypes(msg *natsw.Message, _ *struct{}) (*documents.ListAuthorshipTypesResponse, error) {
 	authorshipTypes, err := h.service.ListAuthorshipTypes(msg.Ctx)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to list authorship types", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to list authorship types: %w", err)
 	}
 
 	dtoTypes := make([]documents.AuthorshipType, len(authorshipTypes))
@@ -181,15 +164,14 @@ func (h *MetadataHandler) HandleListAuthorshipTypes(msg *natsw.Message) error {
 	}
 
 	response := documents.ListAuthorshipTypesResponse{AuthorshipTypes: dtoTypes}
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleListTags handles list tags requests
-func (h *MetadataHandler) HandleListTags(msg *natsw.Message) error {
+func (h *MetadataHandler) HandleListTags(msg *natsw.Message, _ *struct{}) (*documents.ListTagsResponse, error) {
 	tags, err := h.service.ListTags(msg.Ctx)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to list tags", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to list tags: %w", err)
 	}
 
 	// Convert to dto
@@ -206,27 +188,20 @@ func (h *MetadataHandler) HandleListTags(msg *natsw.Message) error {
 		Tags: dtoTags,
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // HandleCreateTag handles create tag requests
-func (h *MetadataHandler) HandleCreateTag(msg *natsw.Message) error {
-	var req documents.CreateTagRequest
-	if err := req.UnmarshalJSON(msg.Data); err != nil {
-		h.logger.ErrorContext(msg.Ctx, "invalid create tag request", "error", err)
-		return h.respondError(msg, dto.ErrCodeInvalidRequest)
-	}
-
+func (h *MetadataHandler) HandleCreateTag(msg *natsw.Message, req *documents.CreateTagRequest) (*documents.CreateTagResponse, error) {
 	// Check authorization (admin only)
 	if !h.isAdmin(msg) {
 		h.logger.WarnContext(msg.Ctx, "unauthorized create tag attempt")
-		return h.respondError(msg, dto.ErrCodeUnauthorized)
+		return nil, natsw.NewRPCError(dto.ErrCodeUnauthorized, "")
 	}
 
 	tag, err := h.service.CreateTag(msg.Ctx, req.Title)
 	if err != nil {
-		h.logger.ErrorContext(msg.Ctx, "failed to create tag", "error", err)
-		return h.respondError(msg, dto.ErrCodeInternal)
+		return nil, fmt.Errorf("failed to create tag: %w", err)
 	}
 
 	response := documents.CreateTagResponse{
@@ -237,18 +212,11 @@ func (h *MetadataHandler) HandleCreateTag(msg *natsw.Message) error {
 		},
 	}
 
-	return msg.RespondEasyJSON(&response)
+	return &response, nil
 }
 
 // Helper methods
 
-func (h *MetadataHandler) respondError(msg *natsw.Message, code dto.ErrorCode) error {
-	response := dto.ErrorResponse{
-		Error: string(code),
-	}
-	return msg.RespondEasyJSON(&response)
-}
-
 func (h *MetadataHandler) isAdmin(msg *natsw.Message) bool {
 	// Extract role from message headers (set by gateway)
 	role := msg.Header.Get("X-User-Role")
@@ -2,11 +2,13 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/nats-io/nats.go/jetstream"
 
+	"github.com/artmexbet/raibecas/libs/dto/documents"
 	"github.com/artmexbet/raibecas/libs/natsw"
 )
 
@@ -23,6 +25,7 @@ const (
 	subjectDocumentsGet         = "documents.get"
 	subjectDocumentsGetContent  = "documents.get.content"
 	subjectDocumentsList        = "documents.list"
+	subjectDocumentsSearchText  = "documents.search.fulltext"
 	subjectDocumentsUpdate      = "documents.update"
 	subjectDocumentsDelete      = "documents.delete"
 	subjectDocumentsVersions    = "documents.versions"
@@ -45,80 +48,141 @@ const (
 	indexedConsumerStream  = "INDEXING"
 )
 
+// indexedConsumerBackoff spaces out redeliveries of indexing events, e.g. while the database is down
+var indexedConsumerBackoff = []time.Duration{time.Second, 5 * time.Second, 30 * time.Second, 2 * time.Minute}
+
+// Document endpoints (typed request-reply)
+var (
+	documentsCreateEndpoint      = natsw.NewEndpoint[documents.CreateDocumentRequest, documents.CreateDocumentResponse](subjectDocumentsCreate)
+	documentsGetEndpoint         = natsw.NewEndpoint[documents.GetDocumentRequest, documents.GetDocumentResponse](subjectDocumentsGet)
+	documentsGetContentEndpoint  = natsw.NewEndpoint[documents.GetDocumentContentRequest, documents.GetDocumentContentResponse](subjectDocumentsGetContent)
+	documentsListEndpoint        = natsw.NewEndpoint[documents.ListDocumentsQuery, documents.ListDocumentsResponse](subjectDocumentsList)
+	documentsSearchTextEndpoint  = natsw.NewEndpoint[documents.FullTextSearchRequest, documents.FullTextSearchResponse](subjectDocumentsSearchText)
+	documentsUpdateEndpoint      = natsw.NewEndpoint[documents.UpdateDocumentRequest, documents.UpdateDocumentResponse](subjectDocumentsUpdate)
+	documentsDeleteEndpoint      = natsw.NewEndpoint[documents.DeleteDocumentRequest, documents.DeleteDocumentResponse](subjectDocumentsDelete)
+	documentsVersionsEndpoint    = natsw.NewEndpoint[documents.ListDocumentVersionsRequest, documents.ListDocumentVersionsResponse](subjectDocumentsVersions)
+	documentsCoverUploadEndpoint = natsw.NewEndpoint[documents.UploadCoverRequest, documents.UploadCoverResponse](subjectDocumentsCoverUpload)
+	documentsReindexEndpoint     = natsw.NewEndpoint[documents.ReindexDocumentRequest, documents.ReindexDocumentResponse](subjectDocumentsReindex)
+)
+
+// Bookmark and note endpoints (typed request-reply)
+var (
+	bookmarksListEndpoint   = natsw.NewEndpoint[documents.ListBookmarksQuery, documents.ListBookmarksResponse](subjectBookmarksList)
+	bookmarksCreateEndpoint = natsw.NewEndpoint[documents.CreateBookmarkRequest, documents.CreateBookmarkResponse](subjectBookmarksCreate)
+	bookmarksDeleteEndpoint = natsw.NewEndpoint[documents.DeleteBookmarkRequest, documents.DeleteBookmarkResponse](subjectBookmarksDelete)
+	notesListEndpoint       = natsw.NewEndpoint[documents.ListNotesQuery, documents.ListNotesResponse](subjectNotesList)
+	notesGetEndpoint        = natsw.NewEndpoint[documents.GetNoteRequest, documents.GetNoteResponse](subjectNotesGet)
+	notesCreateEndpoint     = natsw.NewEndpoint[documents.CreateNoteRequest, documents.CreateNoteResponse](subjectNotesCreate)
+	notesUpdateEndpoint     = natsw.NewEndpoint[documents.UpdateNoteRequest, documents.UpdateNoteResponse](subjectNotesUpdate)
+	notesDeleteEndpoint     = natsw.NewEndpoint[documents.DeleteNoteRequest, documents.DeleteNoteResponse](subjectNotesDelete)
+)
+
+// Metadata endpoints (typed request-reply)
+var (
+	authorsListEndpoint         = natsw.NewEndpoint[struct{}, documents.ListAuthorsResponse](subjectAuthorsList)
+	authorsCreateEndpoint       = natsw.NewEndpoint[documents.CreateAuthorRequest, documents.CreateAuthorResponse](subjectAuthorsCreate)
+	categoriesListEndpoint      = natsw.NewEndpoint[struct{}, documents.ListCategoriesResponse](subjectCategoriesList)
+	categoriesCreateEndpoint    = natsw.NewEndpoint[documents.CreateCategoryRequest, documents.CreateCategoryResponse](subjectCategoriesCreate)
+	documentTypesListEndpoint   = natsw.NewEndpoint[struct{}, documents.ListDocumentTypesResponse](subjectDocumentTypesList)
+	authorshipTypesListEndpoint = natsw.NewEndpoint[struct{}, documents.ListAuthorshipTypesResponse](subjectAuthorshipTypesList)
+	tagsListEndpoint            = natsw.NewEndpoint[struct{}, documents.ListTagsResponse](subjectTagsList)
+	tagsCreateEndpoint          = natsw.NewEndpoint[documents.CreateTagRequest, documents.CreateTagResponse](subjectTagsCreate)
+)
+
 // Server represents the NATS server with subscriptions
 type Server struct {
-	client          *natsw.Client
+	service         *natsw.Service
 	jsCtx           *natsw.JetStreamContext
 	handler         *DocumentHandler
 	metadataHandler *MetadataHandler
-	consumeCtx      jetstream.ConsumeContext // JetStream consumer context for graceful stop
+	// deadLetterSubject receives indexing events that cannot be processed
+	deadLetterSubject string
+	consumeCtx        jetstream.ConsumeContext // JetStream consumer context for graceful stop
 }
 
-// New creates a new server instance
-func New(client *natsw.Client, jsCtx *natsw.JetStreamContext, handler *DocumentHandler, metadataHandler *MetadataHandler) *Server {
+// New creates a new server instance. Indexing events that failed permanently or exhausted
+// their retries are moved to deadLetterSubject.
+func New(
+	service *natsw.Service,
+	jsCtx *natsw.JetStreamContext,
+	handler *DocumentHandler,
+	metadataHandler *MetadataHandler,
+	deadLetterSubject string,
+) *Server {
 	return &Server{
-		client:          client,
-		jsCtx:           jsCtx,
-		handler:         handler,
-		metadataHandler: metadataHandler,
+		service:           service,
+		jsCtx:             jsCtx,
+		handler:           handler,
+		metadataHandler:   metadataHandler,
+		deadLetterSubject: deadLetterSubject,
 	}
 }
 
 // Start registers all NATS subscriptions
-//
-//nolint:errcheck // Subscriptions are fire-and-forget, errors are logged in the client
 func (s *Server) Start() error {
 	// Document operations (request-reply)
-	s.client.Subscribe(subjectDocumentsCreate, s.handler.HandleCreateDocument)
-	s.client.Subscribe(subjectBookmarksList, s.handler.HandleListBookmarks)
-	s.client.Subscribe(subjectBookmarksCreate, s.handler.HandleCreateBookmark)
-	s.client.Subscribe(subjectBookmarksDelete, s.handler.HandleDeleteBookmark)
-	s.client.Subscribe(subjectNotesList, s.handler.HandleListNotes)
-	s.client.Subscribe(subjectNotesGet, s.handler.HandleGetNote)
-	s.client.Subscribe(subjectNotesCreate, s.handler.HandleCreateNote)
-	s.client.Subscribe(subjectNotesUpdate, s.handler.HandleUpdateNote)
-	s.client.Subscribe(subjectNotesDelete, s.handler.HandleDeleteNote)
-	s.client.Subscribe(subjectDocumentsGet, s.handler.HandleGetDocument)
-	s.client.Subscribe(subjectDocumentsGetContent, s.handler.HandleGetDocumentContent)
-	s.client.Subscribe(subjectDocumentsList, s.handler.HandleListDocuments)
-	s.client.Subscribe(subjectDocumentsUpdate, s.handler.HandleUpdateDocument)
-	s.client.Subscribe(subjectDocumentsDelete, s.handler.HandleDeleteDocument)
-	s.client.Subscribe(subjectDocumentsVersions, s.handler.HandleListDocumentVersions)
-	s.client.Subscribe(subjectDocumentsCoverUpload, s.handler.HandleUploadCover)
-	s.client.Subscribe(subjectDocumentsReindex, s.handler.HandleReindexDocument)
+	err := errors.Join(
+		natsw.AddRPC(s.service, documentsCreateEndpoint, s.handler.HandleCreateDocument),
+		natsw.AddRPC(s.service, documentsGetEndpoint, s.handler.HandleGetDocument),
+		natsw.AddRPC(s.service, documentsGetContentEndpoint, s.handler.HandleGetDocumentContent),
+		natsw.AddRPC(s.service, documentsListEndpoint, s.handler.HandleListDocuments),
+		natsw.AddRPC(s.service, documentsSearchTextEndpoint, s.handler.HandleSearchFullText),
+		natsw.AddRPC(s.service, documentsUpdateEndpoint, s.handler.HandleUpdateDocument),
+		natsw.AddRPC(s.service, documentsDeleteEndpoint, s.handler.HandleDeleteDocument),
+		natsw.AddRPC(s.service, documentsVersionsEndpoint, s.handler.HandleListDocumentVersions),
+		natsw.AddRPC(s.service, documentsCoverUploadEndpoint, s.handler.HandleUploadCover),
+		natsw.AddRPC(s.service, documentsReindexEndpoint, s.handler.HandleReindexDocument),
+
+		// Bookmark and note operations (request-reply)
+		natsw.AddRPC(s.service, bookmarksListEndpoint, s.handler.HandleListBookmarks),
+		natsw.AddRPC(s.service, bookmarksCreateEndpoint, s.handler.HandleCreateBookmark),
+		natsw.AddRPC(s.service, bookmarksDeleteEndpoint, s.handler.HandleDeleteBookmark),
+		natsw.AddRPC(s.service, notesListEndpoint, s.handler.HandleListNotes),
+		natsw.AddRPC(s.service, notesGetEndpoint, s.handler.HandleGetNote),
+		natsw.AddRPC(s.service, notesCreateEndpoint, s.handler.HandleCreateNote),
+		natsw.AddRPC(s.service, notesUpdateEndpoint, s.handler.HandleUpdateNote),
+		natsw.AddRPC(s.service, notesDeleteEndpoint, s.handler.HandleDeleteNote),
+
+		// Metadata operations (request-reply)
+		natsw.AddRPC(s.service, authorsListEndpoint, s.metadataHandler.HandleListAuthors),
+		natsw.AddRPC(s.service, authorsCreateEndpoint, s.metadataHandler.HandleCreateAuthor),
+		natsw.AddRPC(s.service, categoriesListEndpoint, s.metadataHandler.HandleListCategories),
+		natsw.AddRPC(s.service, categoriesCreateEndpoint, s.metadataHandler.HandleCreateCategory),
+		natsw.AddRPC(s.service, documentTypesListEndpoint, s.metadataHandler.HandleListDocumentTypes),
+		natsw.AddRPC(s.service, authorshipTypesListEndpoint, s.metadataHandler.HandleListAuthorshipTypes),
+		natsw.AddRPC(s.service, tagsListEndpoint, s.metadataHandler.HandleListTags),
+		natsw.AddRPC(s.service, tagsCreateEndpoint, s.metadataHandler.HandleCreateTag),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register endpoints: %w", err)
+	}
 
 	// Event subscriptions via JetStream (guaranteed delivery with ACK/NAK)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	consumeCtx, err := s.jsCtx.ConsumeStream(ctx, natsw.ConsumerConfig{
-		Stream:        indexedConsumerStream,
-		Durable:       indexedConsumerDurable,
-		FilterSubject: subjectDocumentIndexed,
-		AckWait:       30 * time.Second,
-		MaxDeliver:    5,
+		Stream:            indexedConsumerStream,
+		Durable:           indexedConsumerDurable,
+		FilterSubject:     subjectDocumentIndexed,
+		AckWait:           30 * time.Second,
+		MaxDeliver:        5,
+		Backoff:           indexedConsumerBackoff,
+		DeadLetterSubject: s.deadLetterSubject,
 	}, s.handler.HandleDocumentIndexed)
 	if err != nil {
 		return fmt.Errorf("failed to start JetStream consumer for %s: %w", subjectDocumentIndexed, err)
 	}
 	s.consumeCtx = consumeCtx
 
-	// Metadata operations (request-reply)
-	s.client.Subscribe(subjectAuthorsList, s.metadataHandler.HandleListAuthors)
-	s.client.Subscribe(subjectAuthorsCreate, s.metadataHandler.HandleCreateAuthor)
-	s.client.Subscribe(subjectCategoriesList, s.metadataHandler.HandleListCategories)
-	s.client.Subscribe(subjectCategoriesCreate, s.metadataHandler.HandleCreateCategory)
-	s.client.Subscribe(subjectDocumentTypesList, s.metadataHandler.HandleListDocumentTypes)
-	s.client.Subscribe(subjectAuthorshipTypesList, s.metadataHandler.HandleListAuthorshipTypes)
-	s.client.Subscribe(subjectTagsList, s.metadataHandler.HandleListTags)
-	s.client.Subscribe(subjectTagsCreate, s.metadataHandler.HandleCreateTag)
-
 	return nil
 }
 
-// Stop gracefully stops the JetStream consumer.
-func (s *Server) Stop() {
+// Stop stops the JetStream consumer and drains request-reply endpoints,
+// waiting for in-flight handlers until ctx is done.
+func (s *Server) Stop(ctx context.Context) error {
 	if s.consumeCtx != nil {
 		s.consumeCtx.Stop()
 	}
+	return s.service.Drain(ctx)
 }
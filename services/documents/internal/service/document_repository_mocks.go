@@ -321,6 +321,65 @@ func (_c *MockDocumentRepository_List_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// SearchFullText provides a mock function with given fields: ctx, params
+func (_m *MockDocumentRepository) SearchFullText(ctx context.Context, params domain.FullTextSearchParams) ([]domain.FullTextMatch, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchFullText")
+	}
+
+	var r0 []domain.FullTextMatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.FullTextSearchParams) ([]domain.FullTextMatch, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.FullTextSearchParams) []domain.FullTextMatch); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.FullTextMatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.FullTextSearchParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDocumentRepository_SearchFullText_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchFullText'
+type MockDocumentRepository_SearchFullText_Call struct {
+	*mock.Call
+}
+
+// SearchFullText is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params domain.FullTextSearchParams
+func (_e *MockDocumentRepository_Expecter) SearchFullText(ctx interface{}, params interface{}) *MockDocumentRepository_SearchFullText_Call {
+	return &MockDocumentRepository_SearchFullText_Call{Call: _e.mock.On("SearchFullText", ctx, params)}
+}
+
+func (_c *MockDocumentRepository_SearchFullText_Call) Run(run func(ctx context.Context, params domain.FullTextSearchParams)) *MockDocumentRepository_SearchFullText_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.FullTextSearchParams))
+	})
+	return _c
+}
+
+func (_c *MockDocumentRepository_SearchFullText_Call) Return(_a0 []domain.FullTextMatch, _a1 error) *MockDocumentRepository_SearchFullText_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDocumentRepository_SearchFullText_Call) RunAndReturn(run func(context.Context, domain.FullTextSearchParams) ([]domain.FullTextMatch, error)) *MockDocumentRepository_SearchFullText_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, doc
 func (_m *MockDocumentRepository) Update(ctx context.Context, doc *domain.Document) error {
 	ret := _m.Called(ctx, doc)
@@ -416,6 +475,54 @@ func (_c *MockDocumentRepository_UpdateIndexedStatus_Call) RunAndReturn(run func
 	return _c
 }
 
+// UpdatePublicStatus provides a mock function with given fields: ctx, id, isPublic
+func (_m *MockDocumentRepository) UpdatePublicStatus(ctx context.Context, id uuid.UUID, isPublic bool) error {
+	ret := _m.Called(ctx, id, isPublic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePublicStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) error); ok {
+		r0 = rf(ctx, id, isPublic)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDocumentRepository_UpdatePublicStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePublicStatus'
+type MockDocumentRepository_UpdatePublicStatus_Call struct {
+	*mock.Call
+}
+
+// UpdatePublicStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - isPublic bool
+func (_e *MockDocumentRepository_Expecter) UpdatePublicStatus(ctx interface{}, id interface{}, isPublic interface{}) *MockDocumentRepository_UpdatePublicStatus_Call {
+	return &MockDocumentRepository_UpdatePublicStatus_Call{Call: _e.mock.On("UpdatePublicStatus", ctx, id, isPublic)}
+}
+
+func (_c *MockDocumentRepository_UpdatePublicStatus_Call) Run(run func(ctx context.Context, id uuid.UUID, isPublic bool)) *MockDocumentRepository_UpdatePublicStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockDocumentRepository_UpdatePublicStatus_Call) Return(_a0 error) *MockDocumentRepository_UpdatePublicStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDocumentRepository_UpdatePublicStatus_Call) RunAndReturn(run func(context.Context, uuid.UUID, bool) error) *MockDocumentRepository_UpdatePublicStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockDocumentRepository creates a new instance of MockDocumentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDocumentRepository(t interface {
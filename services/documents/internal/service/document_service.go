@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -184,6 +185,23 @@ func (s *DocumentService) ListDocuments(ctx context.Context, params domain.ListD
 	return docs, total, nil
 }
 
+// SearchFullText performs keyword search over document metadata.
+func (s *DocumentService) SearchFullText(ctx context.Context, params domain.FullTextSearchParams) ([]domain.FullTextMatch, error) {
+	ctx, span := s.tracer.Start(ctx, "documents.service.search_full_text")
+	defer span.End()
+
+	if strings.TrimSpace(params.Query) == "" {
+		return []domain.FullTextMatch{}, nil
+	}
+
+	matches, err := s.docRepo.SearchFullText(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search documents: %w", err)
+	}
+
+	return matches, nil
+}
+
 // UpdateDocument updates a document.
 func (s *DocumentService) UpdateDocument(ctx context.Context, id uuid.UUID, req domain.UpdateDocumentRequest) (*domain.Document, error) {
 	ctx, span := s.tracer.Start(ctx, "documents.service.update",
@@ -504,6 +522,7 @@ func (s *DocumentService) publishDocumentCreatedEvent(doc domain.Document) {
 		Version:         doc.CurrentVersion,
 		Participants:    toEventParticipants(doc.Participants),
 		Tags:            toEventTags(doc.Tags),
+		IsPublic:        doc.IsPublic,
 		Timestamp:       time.Now(),
 	})
 }
@@ -526,6 +545,7 @@ func (s *DocumentService) publishDocumentUpdatedEvent(doc domain.Document, oldVe
 		Changes:         changes,
 		Participants:    toEventParticipants(doc.Participants),
 		Tags:            toEventTags(doc.Tags),
+		IsPublic:        doc.IsPublic,
 		Timestamp:       time.Now(),
 	})
 }
@@ -32,6 +32,7 @@ type DocumentRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Document, error)
 	List(ctx context.Context, params domain.ListDocumentsParams) ([]domain.Document, error)
 	Count(ctx context.Context, params domain.ListDocumentsParams) (int, error)
+	SearchFullText(ctx context.Context, params domain.FullTextSearchParams) ([]domain.FullTextMatch, error)
 	Update(ctx context.Context, doc *domain.Document) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	UpdateIndexedStatus(ctx context.Context, id uuid.UUID, indexed bool) error
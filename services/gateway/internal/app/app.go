@@ -9,6 +9,7 @@ import (
 	"syscall"
 
 	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/artmexbet/raibecas/libs/natsw"
@@ -82,12 +83,15 @@ func New() (*App, error) {
 		natsw.WithLogger(slog.Default()),
 		natsw.WithTracer(natsTracer),
 		natsw.WithMiddleware(natsw.TraceHandlerMiddleware(natsTracer)),
+		// Served on /metrics next to the HTTP metrics
+		natsw.WithMetrics(natsw.NewMetrics(prometheus.DefaultRegisterer)),
 	)
 
 	// Create connectors with shared NATS client
 	documentConnector := connector.NewNATSDocumentConnector(natsClient, cfg.NATS.RequestTimeout)
 	authConnector := connector.NewNATSAuthConnector(natsClient)
 	userConnector := connector.NewNATSUserConnector(natsClient)
+	healthConnector := connector.NewNATSHealthConnector(natsClient)
 
 	// Create chat WebSocket connector
 	chatConnector := connector.NewChatWSConnector(cfg.ChatService.WebSocketURL)
@@ -96,7 +100,17 @@ func New() (*App, error) {
 	chatHTTPConnector := connector.NewChatHTTPConnector(cfg.ChatService.HTTPURL)
 
 	// Create server
-	srv := server.New(&cfg.HTTP, cfg.CORS, documentConnector, authConnector, userConnector, chatConnector, chatHTTPConnector)
+	srv := server.New(
+		&cfg.HTTP,
+		cfg.CORS,
+		documentConnector,
+		authConnector,
+		userConnector,
+		chatConnector,
+		chatHTTPConnector,
+		healthConnector,
+		cfg.Readiness,
+	)
 
 	return &App{
 		cfg:      cfg,
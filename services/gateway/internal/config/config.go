@@ -22,6 +22,12 @@ type NATSConfig struct {
 	ReconnectWait  time.Duration `env:"NATS_RECONNECT_WAIT" env-default:"2s"`
 }
 
+// ReadinessConfig lists the NATS services that must answer $SRV.PING for /readyz to succeed
+type ReadinessConfig struct {
+	Services []string      `env:"READINESS_SERVICES" env-default:"auth,chat,documents,users" env-separator:","`
+	Timeout  time.Duration `env:"READINESS_TIMEOUT" env-default:"2s"`
+}
+
 type TelemetryConfig struct {
 	Enabled        bool          `env:"TELEMETRY_ENABLED" env-default:"true"`
 	ServiceName    string        `env:"TELEMETRY_SERVICE_NAME" env-default:"gateway"`
@@ -45,6 +51,7 @@ type ChatServiceConfig struct {
 type Config struct {
 	HTTP        HTTPConfig        `env-prefix:"GATEWAY_"`
 	NATS        NATSConfig        `env-prefix:"NATS_"`
+	Readiness   ReadinessConfig   `env-prefix:"READINESS_"`
 	Telemetry   TelemetryConfig   `env-prefix:"TELEMETRY_"`
 	CORS        CORSConfig        `env-prefix:"CORS_"`
 	ChatService ChatServiceConfig `env-prefix:"CHAT_"`
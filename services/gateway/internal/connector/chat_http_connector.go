@@ -1,11 +1,15 @@
 package connector
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -14,6 +18,49 @@ import (
 type ChatHTTPConnector struct {
 	baseURL    string
 	httpClient *http.Client
+	// streamClient has no overall timeout: answers are streamed for as long as the model generates,
+	// the request context bounds the call instead.
+	streamClient *http.Client
+}
+
+// ChatChunk is a single NDJSON line of a streamed chat answer.
+type ChatChunk struct {
+	Type      string         `json:"type,omitempty"`     // "queued" while the request waits for the model
+	Position  int            `json:"position,omitempty"` // Position in the generation queue of a "queued" chunk
+	Done      bool           `json:"done"`
+	Message   *ChatMessage   `json:"message"`
+	CreatedAt time.Time      `json:"created_at"`
+	Citations []ChatCitation `json:"citations,omitempty"`
+	Truncated bool           `json:"truncated,omitempty"` // Generation was stopped before completion
+	Quota     *ChatQuota     `json:"quota,omitempty"`     // Remaining quota of the user, set on the final chunk
+	Error     string         `json:"error,omitempty"`
+}
+
+// ChatQuota is the remaining generation quota of the user. Unlimited quotas are omitted.
+type ChatQuota struct {
+	Requests *ChatQuotaWindow `json:"requests_per_minute,omitempty"`
+	Tokens   *ChatQuotaWindow `json:"tokens_per_day,omitempty"`
+}
+
+// ChatQuotaWindow is the state of a quota in its current window.
+type ChatQuotaWindow struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// QuotaExceededError is returned when the chat service rejects a generation because a quota of the user is spent.
+// It matches ErrQuotaExceeded.
+type QuotaExceededError struct {
+	RetryAfter time.Duration // Zero when the chat service did not tell.
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrQuotaExceeded, e.RetryAfter)
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
 }
 
 // ChatSession represents a chat session returned by the chat service.
@@ -23,13 +70,126 @@ type ChatSession struct {
 	Title     string        `json:"title"`
 	CreatedAt string        `json:"created_at"`
 	UpdatedAt string        `json:"updated_at"`
-	Messages  []ChatMessage `json:"messages"`
+	Archived  bool          `json:"archived"`
+	Messages  []ChatMessage `json:"messages,omitempty"`
+}
+
+// ChatShare is a read-only snapshot of a chat session published under a random token.
+// The public transcript has no session ID; share lists have no messages.
+type ChatShare struct {
+	Token     string        `json:"token"`
+	SessionID string        `json:"session_id,omitempty"`
+	Title     string        `json:"title"`
+	Messages  []ChatMessage `json:"messages,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// ChatSessionPage is a page of a user's chat sessions, most recently active first.
+type ChatSessionPage struct {
+	Sessions []ChatSession `json:"sessions"`
+	HasMore  bool          `json:"has_more"`
+}
+
+// ChatMessagePage is a page of the active branch of a session in chronological order.
+// Offset 0 holds the newest messages; HasMore reports that older messages exist.
+type ChatMessagePage struct {
+	Messages []ChatMessage `json:"messages"`
+	HasMore  bool          `json:"has_more"`
+}
+
+// ChatSessionUpdate holds the session fields to change. Nil fields are left as is.
+type ChatSessionUpdate struct {
+	Title    *string `json:"title,omitempty"`
+	Archived *bool   `json:"archived,omitempty"`
+}
+
+// ChatSearchHit is a chat message matching a full-text search query.
+type ChatSearchHit struct {
+	MessageID    string    `json:"message_id"`
+	SessionID    string    `json:"session_id"`
+	SessionTitle string    `json:"session_title"`
+	Role         string    `json:"role"`
+	Snippet      string    `json:"snippet"`
+	Rank         float32   `json:"rank"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
 // ChatMessage represents a single message in a chat session.
+// Messages form a tree: regenerating an answer or editing a prompt adds a sibling listed in SiblingIDs.
 type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	ID         string         `json:"id,omitempty"`
+	ParentID   string         `json:"parent_id,omitempty"`
+	Role       string         `json:"role"`
+	Content    string         `json:"content"`
+	Citations  []ChatCitation `json:"citations,omitempty"`
+	Truncated  bool           `json:"truncated,omitempty"`
+	SiblingIDs []string       `json:"sibling_ids,omitempty"`
+	Feedback   *ChatFeedback  `json:"feedback,omitempty"` // Set when the user rated the answer
+}
+
+// ChatFeedback is the user's rating of an assistant message: 1 (helpful) or -1 (not helpful).
+// Rating 0 removes the feedback.
+type ChatFeedback struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ChatCitation references a document chunk used to generate an assistant message.
+// DocumentID can be resolved via /api/v1/documents/:id.
+type ChatCitation struct {
+	ChunkID      string  `json:"chunk_id,omitempty"`
+	DocumentID   string  `json:"document_id"`
+	ChunkOrdinal int     `json:"chunk_ordinal"`
+	Score        float32 `json:"score"`
+	Excerpt      string  `json:"excerpt"`
+	Public       bool    `json:"public"` // Private documents are cited only in the owner's sessions.
+}
+
+// ChatOptions holds optional settings of a chat generation request.
+type ChatOptions struct {
+	CorpusID string         `json:"corpus_id,omitempty"` // Corpus to answer from, see ListCorpora. The default corpus is used when empty.
+	Filters  *SearchFilters `json:"filters,omitempty"`   // Restricts retrieval to matching documents.
+	// UserRole is sent as the X-User-Role header: only admins retrieve private documents.
+	// It is set by the gateway from the token and never read from client requests.
+	UserRole string `json:"-"`
+}
+
+// SearchFilters restricts chat retrieval to documents matching all of the set fields.
+type SearchFilters struct {
+	DocumentIDs   []string   `json:"document_ids,omitempty"`
+	AuthorIDs     []string   `json:"author_ids,omitempty"`
+	TagIDs        []int64    `json:"tag_ids,omitempty"`
+	PublishedFrom *time.Time `json:"published_from,omitempty"` // Inclusive.
+	PublishedTo   *time.Time `json:"published_to,omitempty"`   // Inclusive.
+}
+
+// Corpus is a document collection with the persona answering questions about it.
+type Corpus struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	Description    string        `json:"description"`
+	CollectionName string        `json:"collection_name"`
+	Persona        CorpusPersona `json:"persona"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// CorpusPersona overrides the generation settings for a corpus. Empty fields fall back to the chat service defaults.
+type CorpusPersona struct {
+	GenerationModel string   `json:"generation_model,omitempty"`
+	Temperature     *float32 `json:"temperature,omitempty"`
+	BasePrompt      string   `json:"base_prompt,omitempty"`
+	ContextPrompt   string   `json:"context_prompt,omitempty"`
+	QueryPrompt     string   `json:"query_prompt,omitempty"`
+}
+
+// chatStreamRequest is the body of the streaming chat endpoints.
+type chatStreamRequest struct {
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id,omitempty"`
+	MessageID string `json:"message_id,omitempty"`
+	Input     string `json:"input,omitempty"`
+	ChatOptions
 }
 
 // NewChatHTTPConnector creates a new ChatHTTPConnector.
@@ -39,34 +199,320 @@ func NewChatHTTPConnector(baseURL string) *ChatHTTPConnector {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		streamClient: &http.Client{},
 	}
 }
 
-// GetUserSessions fetches all chat sessions for a user.
-func (c *ChatHTTPConnector) GetUserSessions(ctx context.Context, userID string) ([]ChatSession, error) {
-	url := fmt.Sprintf("%s/api/v1/chat/%s/sessions", c.baseURL, userID)
+// StreamChat sends a chat message and calls fn for every chunk as soon as the chat service flushes it.
+// Cancelling ctx closes the connection, which makes the chat service stop generating.
+// Invalid or unknown sessions are reported as ErrInvalidRequest and ErrNotFound,
+// generations rejected by a quota as *QuotaExceededError.
+func (c *ChatHTTPConnector) StreamChat(
+	ctx context.Context,
+	userID, sessionID, input string,
+	opts ChatOptions,
+	fn func(ChatChunk) error,
+) error {
+	return c.stream(ctx, "StreamChat", "/api/v1/chat", chatStreamRequest{
+		UserID:      userID,
+		SessionID:   sessionID,
+		Input:       input,
+		ChatOptions: opts,
+	}, fn)
+}
+
+// RegenerateChat streams a new version of the last answer of the session like StreamChat.
+func (c *ChatHTTPConnector) RegenerateChat(ctx context.Context, userID, sessionID string, opts ChatOptions, fn func(ChatChunk) error) error {
+	return c.stream(ctx, "RegenerateChat", "/api/v1/chat/regenerate", chatStreamRequest{
+		UserID:      userID,
+		SessionID:   sessionID,
+		ChatOptions: opts,
+	}, fn)
+}
+
+// EditChatMessage streams the answer to a new version of the user message messageID like StreamChat.
+func (c *ChatHTTPConnector) EditChatMessage(
+	ctx context.Context,
+	userID, sessionID, messageID, input string,
+	opts ChatOptions,
+	fn func(ChatChunk) error,
+) error {
+	return c.stream(ctx, "EditChatMessage", "/api/v1/chat/edit", chatStreamRequest{
+		UserID:      userID,
+		SessionID:   sessionID,
+		MessageID:   messageID,
+		Input:       input,
+		ChatOptions: opts,
+	}, fn)
+}
+
+// SwitchChatBranch activates the newest branch going through messageID and returns its messages.
+func (c *ChatHTTPConnector) SwitchChatBranch(ctx context.Context, userID, sessionID, messageID string) ([]ChatMessage, error) {
+	var result struct {
+		Messages []ChatMessage `json:"messages"`
+	}
+	err := c.doJSON(ctx, "SwitchChatBranch", http.MethodPost, "/api/v1/chat/branch", map[string]string{
+		"user_id":    userID,
+		"session_id": sessionID,
+		"message_id": messageID,
+	}, http.StatusOK, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Messages, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// stream posts payload to an NDJSON endpoint of the chat service and calls fn for every chunk.
+// op prefixes returned errors.
+func (c *ChatHTTPConnector) stream(ctx context.Context, op, path string, payload chatStreamRequest, fn func(ChatChunk) error) error {
+	body, err := json.Marshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("GetUserSessions request: %w", err)
+		return fmt.Errorf("%s marshal: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s request: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson")
+	if payload.UserRole != "" {
+		req.Header.Set("X-User-Role", payload.UserRole)
+	}
+
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s do: %w", op, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // safe to ignore
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		return nil
+	case http.StatusBadRequest:
+		return ErrInvalidRequest
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		seconds, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return &QuotaExceededError{RetryAfter: time.Duration(seconds) * time.Second}
+	case http.StatusServiceUnavailable:
+		return ErrUnavailable
+	default:
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: status %d, body: %s", op, resp.StatusCode, b)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var chunk ChatChunk
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			return fmt.Errorf("%s decode: %w", op, err)
+		}
+		if chunk.Error != "" {
+			return fmt.Errorf("%s: %s", op, chunk.Error)
+		}
+		if err := fn(chunk); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%s read: %w", op, err)
+	}
+	return nil
+}
+
+// GetUserSessions fetches a page of a user's active or archived chat sessions without messages.
+func (c *ChatHTTPConnector) GetUserSessions(ctx context.Context, userID string, archived bool, limit, offset int) (ChatSessionPage, error) {
+	query := url.Values{}
+	query.Set("archived", strconv.FormatBool(archived))
+	setPage(query, limit, offset)
+
+	var page ChatSessionPage
+	path := fmt.Sprintf("/api/v1/chat/%s/sessions?%s", url.PathEscape(userID), query.Encode())
+	err := c.doJSON(ctx, "GetUserSessions", http.MethodGet, path, nil, http.StatusOK, &page)
+	return page, err
+}
+
+// GetSessionMessages fetches a page of the active branch of a session, newest page first.
+func (c *ChatHTTPConnector) GetSessionMessages(ctx context.Context, userID, sessionID string, limit, offset int) (ChatMessagePage, error) {
+	query := url.Values{}
+	setPage(query, limit, offset)
+
+	var page ChatMessagePage
+	path := fmt.Sprintf("/api/v1/chat/%s/sessions/%s/messages?%s", url.PathEscape(userID), url.PathEscape(sessionID), query.Encode())
+	err := c.doJSON(ctx, "GetSessionMessages", http.MethodGet, path, nil, http.StatusOK, &page)
+	return page, err
+}
+
+// UpdateSession renames, archives or restores a chat session and returns it.
+func (c *ChatHTTPConnector) UpdateSession(ctx context.Context, userID, sessionID string, update ChatSessionUpdate) (ChatSession, error) {
+	var session ChatSession
+	path := fmt.Sprintf("/api/v1/chat/%s/sessions/%s", url.PathEscape(userID), url.PathEscape(sessionID))
+	err := c.doJSON(ctx, "UpdateSession", http.MethodPatch, path, update, http.StatusOK, &session)
+	return session, err
+}
+
+// DeleteSession deletes a chat session with its messages.
+func (c *ChatHTTPConnector) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	path := fmt.Sprintf("/api/v1/chat/%s/sessions/%s", url.PathEscape(userID), url.PathEscape(sessionID))
+	return c.doJSON(ctx, "DeleteSession", http.MethodDelete, path, nil, http.StatusNoContent, nil)
+}
+
+// SetMessageFeedback rates an assistant message of a chat session.
+func (c *ChatHTTPConnector) SetMessageFeedback(ctx context.Context, userID, sessionID, messageID string, feedback ChatFeedback) error {
+	path := fmt.Sprintf("/api/v1/chat/%s/sessions/%s/messages/%s/feedback",
+		url.PathEscape(userID), url.PathEscape(sessionID), url.PathEscape(messageID))
+	return c.doJSON(ctx, "SetMessageFeedback", http.MethodPut, path, feedback, http.StatusNoContent, nil)
+}
+
+// ShareSession publishes a snapshot of the active branch of a chat session. An empty session is ErrInvalidRequest.
+func (c *ChatHTTPConnector) ShareSession(ctx context.Context, userID, sessionID string) (ChatShare, error) {
+	var share ChatShare
+	path := fmt.Sprintf("/api/v1/chat/%s/sessions/%s/shares", url.PathEscape(userID), url.PathEscape(sessionID))
+	err := c.doJSON(ctx, "ShareSession", http.MethodPost, path, nil, http.StatusCreated, &share)
+	return share, err
+}
+
+// ListSessionShares fetches the snapshots of a chat session published by the user, newest first.
+func (c *ChatHTTPConnector) ListSessionShares(ctx context.Context, userID, sessionID string) ([]ChatShare, error) {
+	var result struct {
+		Shares []ChatShare `json:"shares"`
+	}
+	path := fmt.Sprintf("/api/v1/chat/%s/sessions/%s/shares", url.PathEscape(userID), url.PathEscape(sessionID))
+	if err := c.doJSON(ctx, "ListSessionShares", http.MethodGet, path, nil, http.StatusOK, &result); err != nil {
+		return nil, err
+	}
+	return result.Shares, nil
+}
+
+// RevokeSessionShare deletes a snapshot published by the user.
+func (c *ChatHTTPConnector) RevokeSessionShare(ctx context.Context, userID, token string) error {
+	path := fmt.Sprintf("/api/v1/chat/%s/shares/%s", url.PathEscape(userID), url.PathEscape(token))
+	return c.doJSON(ctx, "RevokeSessionShare", http.MethodDelete, path, nil, http.StatusNoContent, nil)
+}
+
+// GetSharedTranscript fetches the snapshot published under token. Unknown and revoked tokens are ErrNotFound.
+func (c *ChatHTTPConnector) GetSharedTranscript(ctx context.Context, token string) (ChatShare, error) {
+	var share ChatShare
+	err := c.doJSON(ctx, "GetSharedTranscript", http.MethodGet, "/api/v1/shares/"+url.PathEscape(token), nil, http.StatusOK, &share)
+	return share, err
+}
+
+// SearchMessages runs a full-text search over a user's chat messages.
+func (c *ChatHTTPConnector) SearchMessages(ctx context.Context, userID, query string, limit, offset int) ([]ChatSearchHit, error) {
+	values := url.Values{}
+	values.Set("q", query)
+	setPage(values, limit, offset)
+
+	var result struct {
+		Results []ChatSearchHit `json:"results"`
+	}
+	path := fmt.Sprintf("/api/v1/chat/%s/search?%s", url.PathEscape(userID), values.Encode())
+	if err := c.doJSON(ctx, "SearchMessages", http.MethodGet, path, nil, http.StatusOK, &result); err != nil {
+		return nil, err
+	}
+	return result.Results, nil
+}
+
+// ListCorpora fetches all corpora chat requests can be routed to.
+func (c *ChatHTTPConnector) ListCorpora(ctx context.Context) ([]Corpus, error) {
+	var result struct {
+		Corpora []Corpus `json:"corpora"`
+	}
+	if err := c.doJSON(ctx, "ListCorpora", http.MethodGet, "/api/v1/corpora", nil, http.StatusOK, &result); err != nil {
+		return nil, err
+	}
+	return result.Corpora, nil
+}
+
+// GetCorpus fetches a single corpus with its persona.
+func (c *ChatHTTPConnector) GetCorpus(ctx context.Context, id string) (Corpus, error) {
+	var corpus Corpus
+	err := c.doJSON(ctx, "GetCorpus", http.MethodGet, "/api/v1/corpora/"+url.PathEscape(id), nil, http.StatusOK, &corpus)
+	return corpus, err
+}
+
+// CreateCorpus registers a new corpus. A taken ID is reported as ErrConflict.
+func (c *ChatHTTPConnector) CreateCorpus(ctx context.Context, corpus Corpus) (Corpus, error) {
+	var created Corpus
+	err := c.doJSON(ctx, "CreateCorpus", http.MethodPost, "/api/v1/corpora", corpus, http.StatusCreated, &created)
+	return created, err
+}
+
+// UpdateCorpus replaces the settings of the corpus corpus.ID.
+func (c *ChatHTTPConnector) UpdateCorpus(ctx context.Context, corpus Corpus) (Corpus, error) {
+	var updated Corpus
+	err := c.doJSON(ctx, "UpdateCorpus", http.MethodPut, "/api/v1/corpora/"+url.PathEscape(corpus.ID), corpus, http.StatusOK, &updated)
+	return updated, err
+}
+
+// DeleteCorpus removes a corpus from the registry.
+func (c *ChatHTTPConnector) DeleteCorpus(ctx context.Context, id string) error {
+	return c.doJSON(ctx, "DeleteCorpus", http.MethodDelete, "/api/v1/corpora/"+url.PathEscape(id), nil, http.StatusNoContent, nil)
+}
+
+// setPage adds non-zero pagination parameters to query; the chat service applies defaults otherwise.
+func setPage(query url.Values, limit, offset int) {
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+}
+
+// doJSON sends a request with an optional JSON body to the chat service and decodes the response into out.
+// 400, 404 and 409 responses are reported as ErrInvalidRequest, ErrNotFound and ErrConflict. op prefixes other errors.
+func (c *ChatHTTPConnector) doJSON(ctx context.Context, op, method, path string, body any, wantStatus int, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("%s marshal: %w", op, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("%s request: %w", op, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("GetUserSessions do: %w", err)
+		return fmt.Errorf("%s do: %w", op, err)
 	}
 	defer resp.Body.Close() //nolint:errcheck // safe to ignore
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GetUserSessions: status %d, body: %s", resp.StatusCode, body)
+	switch resp.StatusCode {
+	case wantStatus:
+	case http.StatusBadRequest:
+		return ErrInvalidRequest
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	default:
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: status %d, body: %s", op, resp.StatusCode, b)
 	}
 
-	var sessions []ChatSession
-	if err := json.NewDecoder(resp.Body).Decode(&sessions); err != nil {
-		return nil, fmt.Errorf("GetUserSessions decode: %w", err)
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s decode: %w", op, err)
 	}
-	return sessions, nil
+	return nil
 }
 
 // CreateSession creates a new chat session for a user.
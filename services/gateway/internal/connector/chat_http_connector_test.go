@@ -0,0 +1,340 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatHTTPConnector_StreamChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/chat", r.URL.Path)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "user-1", body["user_id"])
+		assert.Equal(t, "session-1", body["session_id"])
+		assert.Equal(t, "Привет", body["input"])
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprintln(w, `{"type":"queued","position":2,"done":false,"message":null}`)
+		fmt.Fprintln(w, `{"done":false,"message":{"role":"assistant","content":"Здрав"}}`)
+		w.(http.Flusher).Flush()
+		fmt.Fprintln(w, `{"done":true,"message":{"role":"assistant","content":"ствуйте"},"citations":[{"document_id":"doc-1","chunk_ordinal":2}],"quota":{"tokens_per_day":{"limit":1000,"remaining":900,"reset_at":"2026-10-17T00:00:00Z"}}}`)
+	}))
+	defer server.Close()
+
+	connector := NewChatHTTPConnector(server.URL)
+
+	var chunks []ChatChunk
+	err := connector.StreamChat(context.Background(), "user-1", "session-1", "Привет", ChatOptions{}, func(chunk ChatChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, chunks, 3)
+	assert.Equal(t, "queued", chunks[0].Type)
+	assert.Equal(t, 2, chunks[0].Position)
+	assert.Equal(t, "Здрав", chunks[1].Message.Content)
+	assert.True(t, chunks[2].Done)
+	require.Len(t, chunks[2].Citations, 1)
+	assert.Equal(t, "doc-1", chunks[2].Citations[0].DocumentID)
+	require.NotNil(t, chunks[2].Quota)
+	assert.Nil(t, chunks[2].Quota.Requests)
+	assert.Equal(t, 900, chunks[2].Quota.Tokens.Remaining)
+}
+
+func TestChatHTTPConnector_StreamChat_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "invalid session", status: http.StatusBadRequest, body: `{"error":"invalid chat session id"}`, wantErr: ErrInvalidRequest},
+		{name: "unknown session", status: http.StatusNotFound, body: `{"error":"chat session not found"}`, wantErr: ErrNotFound},
+		{name: "full queue", status: http.StatusServiceUnavailable, body: `{"error":"generation queue is full"}`, wantErr: ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			err := NewChatHTTPConnector(server.URL).StreamChat(context.Background(), "u", "s", "q", ChatOptions{}, func(ChatChunk) error {
+				t.Fatal("callback must not be called")
+				return nil
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestChatHTTPConnector_StreamChat_QuotaExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "42")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":"quota exceeded: requests_per_minute, retry in 42s"}`)
+	}))
+	defer server.Close()
+
+	err := NewChatHTTPConnector(server.URL).StreamChat(context.Background(), "u", "", "q", ChatOptions{}, func(ChatChunk) error {
+		t.Fatal("callback must not be called")
+		return nil
+	})
+
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	var quotaErr *QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, 42*time.Second, quotaErr.RetryAfter)
+}
+
+func TestChatHTTPConnector_StreamChat_TrailingError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `{"done":false,"message":{"role":"assistant","content":"частичный"}}`)
+		fmt.Fprintln(w, `{"error":"processing failed"}`)
+	}))
+	defer server.Close()
+
+	var received int
+	err := NewChatHTTPConnector(server.URL).StreamChat(context.Background(), "u", "", "q", ChatOptions{}, func(ChatChunk) error {
+		received++
+		return nil
+	})
+
+	assert.Equal(t, 1, received)
+	assert.ErrorContains(t, err, "processing failed")
+}
+
+func TestChatHTTPConnector_EditChatMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/chat/edit", r.URL.Path)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "msg-1", body["message_id"])
+		assert.Equal(t, "Новый вопрос", body["input"])
+
+		fmt.Fprintln(w, `{"done":true,"message":{"id":"msg-3","parent_id":"msg-2","role":"assistant","content":"Ответ"}}`)
+	}))
+	defer server.Close()
+
+	var chunks []ChatChunk
+	err := NewChatHTTPConnector(server.URL).EditChatMessage(context.Background(), "u", "s", "msg-1", "Новый вопрос", ChatOptions{}, func(chunk ChatChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	assert.Equal(t, "msg-3", chunks[0].Message.ID)
+	assert.Equal(t, "msg-2", chunks[0].Message.ParentID)
+}
+
+func TestChatHTTPConnector_SwitchChatBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/chat/branch", r.URL.Path)
+		fmt.Fprint(w, `{"messages":[{"id":"m1","role":"user","content":"Вопрос","sibling_ids":["m1","m4"]}]}`)
+	}))
+	defer server.Close()
+
+	messages, err := NewChatHTTPConnector(server.URL).SwitchChatBranch(context.Background(), "u", "s", "m1")
+
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"m1", "m4"}, messages[0].SiblingIDs)
+}
+
+func TestChatHTTPConnector_GetUserSessions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/chat/user-1/sessions", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("archived"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Equal(t, "20", r.URL.Query().Get("offset"))
+
+		fmt.Fprint(w, `{"sessions":[{"id":"s1","title":"Диалектика","archived":true}],"has_more":true}`)
+	}))
+	defer server.Close()
+
+	page, err := NewChatHTTPConnector(server.URL).GetUserSessions(context.Background(), "user-1", true, 10, 20)
+
+	require.NoError(t, err)
+	require.Len(t, page.Sessions, 1)
+	assert.True(t, page.Sessions[0].Archived)
+	assert.True(t, page.HasMore)
+}
+
+func TestChatHTTPConnector_DeleteSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "deleted", status: http.StatusNoContent},
+		{name: "unknown session", status: http.StatusNotFound, wantErr: ErrNotFound},
+		{name: "invalid session", status: http.StatusBadRequest, wantErr: ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method)
+				assert.Equal(t, "/api/v1/chat/u/sessions/s", r.URL.Path)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewChatHTTPConnector(server.URL).DeleteSession(context.Background(), "u", "s")
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChatHTTPConnector_SearchMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/chat/u/search", r.URL.Path)
+		assert.Equal(t, "бытие и время", r.URL.Query().Get("q"))
+
+		fmt.Fprint(w, `{"results":[{"message_id":"m1","session_id":"s1","snippet":"<b>бытие</b>"}]}`)
+	}))
+	defer server.Close()
+
+	hits, err := NewChatHTTPConnector(server.URL).SearchMessages(context.Background(), "u", "бытие и время", 0, 0)
+
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "m1", hits[0].MessageID)
+}
+
+func TestChatHTTPConnector_StreamChatSendsOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Admin", r.Header.Get("X-User-Role"))
+
+		var body struct {
+			CorpusID string         `json:"corpus_id"`
+			Filters  map[string]any `json:"filters"`
+			UserRole string         `json:"UserRole"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "kant", body.CorpusID)
+		assert.Equal(t, []any{float64(3)}, body.Filters["tag_ids"])
+		assert.Empty(t, body.UserRole)
+
+		fmt.Fprintln(w, `{"done":true,"message":{"role":"assistant","content":"ok"}}`)
+	}))
+	defer server.Close()
+
+	err := NewChatHTTPConnector(server.URL).StreamChat(context.Background(), "u", "s", "q", ChatOptions{
+		CorpusID: "kant",
+		Filters:  &SearchFilters{TagIDs: []int64{3}},
+		UserRole: "Admin",
+	}, func(ChatChunk) error {
+		return nil
+	})
+
+	require.NoError(t, err)
+}
+
+func TestChatHTTPConnector_CreateCorpus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "created", status: http.StatusCreated, body: `{"id":"kant","name":"Кант","collection_name":"kant"}`},
+		{name: "duplicate", status: http.StatusConflict, body: `{"error":"corpus already exists"}`, wantErr: ErrConflict},
+		{name: "invalid", status: http.StatusBadRequest, body: `{"error":"invalid corpus"}`, wantErr: ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/v1/corpora", r.URL.Path)
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			corpus, err := NewChatHTTPConnector(server.URL).CreateCorpus(context.Background(), Corpus{ID: "kant", Name: "Кант", CollectionName: "kant"})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "kant", corpus.ID)
+		})
+	}
+}
+
+func TestChatHTTPConnector_SetMessageFeedback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/chat/u1/sessions/s1/messages/a1/feedback", r.URL.Path)
+		var body ChatFeedback
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, ChatFeedback{Rating: -1, Comment: "Не по тексту"}, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewChatHTTPConnector(server.URL).SetMessageFeedback(context.Background(), "u1", "s1", "a1",
+		ChatFeedback{Rating: -1, Comment: "Не по тексту"})
+	require.NoError(t, err)
+}
+
+func TestChatHTTPConnector_ShareSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/chat/u1/sessions/s1/shares", r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"token":"t1","session_id":"s1","title":"Диалектика","created_at":"2026-10-16T10:00:00Z"}`)
+	}))
+	defer server.Close()
+
+	share, err := NewChatHTTPConnector(server.URL).ShareSession(context.Background(), "u1", "s1")
+	require.NoError(t, err)
+	assert.Equal(t, "t1", share.Token)
+	assert.Equal(t, "Диалектика", share.Title)
+}
+
+func TestChatHTTPConnector_GetSharedTranscript(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/shares/t1" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"shared transcript not found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"token":"t1","title":"Диалектика","messages":[{"role":"user","content":"Что такое диалектика?"}],"created_at":"2026-10-16T10:00:00Z"}`)
+	}))
+	defer server.Close()
+
+	connector := NewChatHTTPConnector(server.URL)
+
+	share, err := connector.GetSharedTranscript(context.Background(), "t1")
+	require.NoError(t, err)
+	require.Len(t, share.Messages, 1)
+	assert.Equal(t, "user", share.Messages[0].Role)
+
+	_, err = connector.GetSharedTranscript(context.Background(), "revoked")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
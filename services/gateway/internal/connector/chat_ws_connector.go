@@ -35,8 +35,10 @@ func NewChatWSConnector(chatServiceURL string) *ChatWSConnector {
 	}
 }
 
-// Connect establishes WebSocket connection to chat service and bridges it with client
-func (c *ChatWSConnector) Connect(ctx context.Context, clientConn *fiberWs.Conn, userID string) error {
+// Connect establishes WebSocket connection to chat service and bridges it with client.
+// callerID and userRole identify the authenticated user and are passed to the chat service on upgrade:
+// the role decides access to private documents and the caller is charged for the generations of the whole connection.
+func (c *ChatWSConnector) Connect(ctx context.Context, clientConn *fiberWs.Conn, userID, callerID, userRole string) error {
 	// Connect to chat service via WebSocket using fasthttp/websocket
 	url := fmt.Sprintf("%s?userID=%s", c.chatServiceURL, userID)
 
@@ -48,6 +50,10 @@ func (c *ChatWSConnector) Connect(ctx context.Context, clientConn *fiberWs.Conn,
 	// Add userID to headers
 	headers := http.Header{}
 	headers.Set("X-User-ID", userID)
+	headers.Set("X-Caller-ID", callerID)
+	if userRole != "" {
+		headers.Set("X-User-Role", userRole)
+	}
 
 	chatConn, _, err := dialer.Dial(url, headers)
 	if err != nil {
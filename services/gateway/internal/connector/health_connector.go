@@ -0,0 +1,34 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/artmexbet/raibecas/libs/natsw"
+
+	"github.com/artmexbet/raibecas/services/gateway/internal/domain"
+)
+
+// NATSHealthConnector implements server.HealthConnector using the NATS micro $SRV protocol
+type NATSHealthConnector struct {
+	client *natsw.Client
+}
+
+// NewNATSHealthConnector creates a new NATS-based health connector
+func NewNATSHealthConnector(client *natsw.Client) *NATSHealthConnector {
+	return &NATSHealthConnector{
+		client: client,
+	}
+}
+
+// PingService returns the first instance of the named service that answers $SRV.PING
+func (c *NATSHealthConnector) PingService(ctx context.Context, name string) (domain.ServiceInstance, error) {
+	ping, err := natsw.PingService(ctx, c.client, name)
+	if err != nil {
+		return domain.ServiceInstance{}, err
+	}
+	return domain.ServiceInstance{
+		Name:    ping.Name,
+		ID:      ping.ID,
+		Version: ping.Version,
+	}, nil
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/mailru/easyjson"
 	"github.com/nats-io/nats.go"
 
 	"github.com/artmexbet/raibecas/libs/dto"
@@ -23,6 +22,9 @@ var (
 	ErrNotFound       = errors.New("not_found")
 	ErrUnauthorized   = errors.New("unauthorized")
 	ErrForbidden      = errors.New("forbidden")
+	ErrConflict       = errors.New("conflict")
+	ErrQuotaExceeded  = errors.New("quota_exceeded")
+	ErrUnavailable    = errors.New("unavailable")
 	ErrInternal       = errors.New("internal_error")
 )
 
@@ -60,6 +62,42 @@ const (
 	defaultTimeout = 5 * time.Second
 )
 
+// Document endpoints (typed request-reply)
+var (
+	documentsListEndpoint        = natsw.NewEndpoint[documents.ListDocumentsQuery, documents.ListDocumentsResponse](SubjectDocumentsList)
+	documentsGetEndpoint         = natsw.NewEndpoint[documents.GetDocumentRequest, documents.GetDocumentResponse](SubjectDocumentsGet)
+	documentsGetContentEndpoint  = natsw.NewEndpoint[documents.GetDocumentContentRequest, documents.GetDocumentContentResponse](SubjectDocumentsGetContent)
+	documentsCreateEndpoint      = natsw.NewEndpoint[documents.CreateDocumentRequest, documents.CreateDocumentResponse](SubjectDocumentsCreate)
+	documentsUpdateEndpoint      = natsw.NewEndpoint[documents.UpdateDocumentRequest, documents.UpdateDocumentResponse](SubjectDocumentsUpdate)
+	documentsDeleteEndpoint      = natsw.NewEndpoint[documents.DeleteDocumentRequest, documents.DeleteDocumentResponse](SubjectDocumentsDelete)
+	documentsCoverUploadEndpoint = natsw.NewEndpoint[documents.UploadCoverRequest, documents.UploadCoverResponse](SubjectDocumentsCoverUpload)
+	documentsReindexEndpoint     = natsw.NewEndpoint[documents.ReindexDocumentRequest, documents.ReindexDocumentResponse](SubjectDocumentsReindex)
+)
+
+// Bookmark and note endpoints (typed request-reply)
+var (
+	bookmarksListEndpoint   = natsw.NewEndpoint[documents.ListBookmarksQuery, documents.ListBookmarksResponse](SubjectBookmarksList)
+	bookmarksCreateEndpoint = natsw.NewEndpoint[documents.CreateBookmarkRequest, documents.CreateBookmarkResponse](SubjectBookmarksCreate)
+	bookmarksDeleteEndpoint = natsw.NewEndpoint[documents.DeleteBookmarkRequest, documents.DeleteBookmarkResponse](SubjectBookmarksDelete)
+	notesListEndpoint       = natsw.NewEndpoint[documents.ListNotesQuery, documents.ListNotesResponse](SubjectNotesList)
+	notesGetEndpoint        = natsw.NewEndpoint[documents.GetNoteRequest, documents.GetNoteResponse](SubjectNotesGet)
+	notesCreateEndpoint     = natsw.NewEndpoint[documents.CreateNoteRequest, documents.CreateNoteResponse](SubjectNotesCreate)
+	notesUpdateEndpoint     = natsw.NewEndpoint[documents.UpdateNoteRequest, documents.UpdateNoteResponse](SubjectNotesUpdate)
+	notesDeleteEndpoint     = natsw.NewEndpoint[documents.DeleteNoteRequest, documents.DeleteNoteResponse](SubjectNotesDelete)
+)
+
+// Metadata endpoints (typed request-reply)
+var (
+	authorsListEndpoint         = natsw.NewEndpoint[struct{}, documents.ListAuthorsResponse](SubjectAuthorsList)
+	authorsCreateEndpoint       = natsw.NewEndpoint[documents.CreateAuthorRequest, documents.CreateAuthorResponse](SubjectAuthorsCreate)
+	categoriesListEndpoint      = natsw.NewEndpoint[struct{}, documents.ListCategoriesResponse](SubjectCategoriesList)
+	categoriesCreateEndpoint    = natsw.NewEndpoint[documents.CreateCategoryRequest, documents.CreateCategoryResponse](SubjectCategoriesCreate)
+	documentTypesListEndpoint   = natsw.NewEndpoint[struct{}, documents.ListDocumentTypesResponse](SubjectDocumentTypesList)
+	authorshipTypesListEndpoint = natsw.NewEndpoint[struct{}, documents.ListAuthorshipTypesResponse](SubjectAuthorshipTypesList)
+	tagsListEndpoint            = natsw.NewEndpoint[struct{}, documents.ListTagsResponse](SubjectTagsList)
+	tagsCreateEndpoint          = natsw.NewEndpoint[documents.CreateTagRequest, documents.CreateTagResponse](SubjectTagsCreate)
+)
+
 // NATSDocumentConnector implements server.DocumentServiceConnector using NATS for communication
 type NATSDocumentConnector struct {
 	client  *natsw.Client
@@ -91,26 +129,9 @@ func (c *NATSDocumentConnector) ListDocuments(ctx context.Context, query domain.
 		Search:         query.Search,
 	}
 
-	reqData, err := dtoQuery.MarshalJSON()
+	dtoResponse, err := natsw.Call(ctx, c.client, documentsListEndpoint, &dtoQuery, natsw.WithHeader("X-User-Role", userRole))
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal list request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectDocumentsList)
-	msg.Data = reqData
-	if userRole != "" {
-		msg.Header.Set("X-User-Role", userRole)
-	}
-
-	// RequestMsg автоматически пропагирует trace context
-	respMsg, err := c.client.RequestMsg(ctx, msg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send list request: %w", err)
-	}
-
-	var dtoResponse documents.ListDocumentsResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal list response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	// Convert back to domain type
@@ -135,29 +156,9 @@ func (c *NATSDocumentConnector) ListBookmarks(ctx context.Context, query domain.
 		UserID: query.UserID,
 	}
 
-	reqData, err := easyjson.Marshal(dtoQuery)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal bookmarks list request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectBookmarksList)
-	msg.Data = reqData
-	if query.UserID != uuid.Nil {
-		msg.Header.Set("X-User-ID", query.UserID.String())
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, bookmarksListEndpoint, &dtoQuery, withUserID(query.UserID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to send bookmarks list request: %w", err)
-	}
-
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return nil, errResp
-	}
-
-	var dtoResponse documents.ListBookmarksResponse
-	if err := easyjson.Unmarshal(respMsg.Data, &dtoResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal bookmarks list response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	return &domain.ListBookmarksResponse{
@@ -180,29 +181,9 @@ func (c *NATSDocumentConnector) CreateBookmark(ctx context.Context, req domain.C
 		PageLabel:  req.PageLabel,
 	}
 
-	reqData, err := easyjson.Marshal(dtoReq)
+	dtoResponse, err := natsw.Call(ctx, c.client, bookmarksCreateEndpoint, &dtoReq, withUserID(req.UserID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal create bookmark request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectBookmarksCreate)
-	msg.Data = reqData
-	if req.UserID != uuid.Nil {
-		msg.Header.Set("X-User-ID", req.UserID.String())
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send create bookmark request: %w", err)
-	}
-
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return nil, errResp
-	}
-
-	var dtoResponse documents.CreateBookmarkResponse
-	if err := easyjson.Unmarshal(respMsg.Data, &dtoResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal create bookmark response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	return &domain.CreateBookmarkResponse{Item: convertBookmark(dtoResponse.Item)}, nil
@@ -215,29 +196,9 @@ func (c *NATSDocumentConnector) DeleteBookmark(ctx context.Context, userID, book
 		UserID: userID,
 	}
 
-	reqData, err := easyjson.Marshal(dtoReq)
+	dtoResponse, err := natsw.Call(ctx, c.client, bookmarksDeleteEndpoint, &dtoReq, withUserID(userID))
 	if err != nil {
-		return fmt.Errorf("failed to marshal delete bookmark request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectBookmarksDelete)
-	msg.Data = reqData
-	if userID != uuid.Nil {
-		msg.Header.Set("X-User-ID", userID.String())
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
-	if err != nil {
-		return fmt.Errorf("failed to send delete bookmark request: %w", err)
-	}
-
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return errResp
-	}
-
-	var dtoResponse documents.DeleteBookmarkResponse
-	if err := easyjson.Unmarshal(respMsg.Data, &dtoResponse); err != nil {
-		return fmt.Errorf("failed to unmarshal delete bookmark response: %w", err)
+		return rpcError(err)
 	}
 	if !dtoResponse.Success {
 		return fmt.Errorf("delete bookmark failed")
@@ -249,50 +210,17 @@ func (c *NATSDocumentConnector) DeleteBookmark(ctx context.Context, userID, book
 // GetDocument retrieves a single document by ID
 func (c *NATSDocumentConnector) GetDocument(ctx context.Context, id uuid.UUID, userRole string) (*domain.GetDocumentResponse, error) {
 	req := documents.GetDocumentRequest{ID: id}
-	reqData, err := req.MarshalJSON()
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal get request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectDocumentsGet)
-	msg.Data = reqData
-	if userRole != "" {
-		msg.Header.Set("X-User-Role", userRole)
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, documentsGetEndpoint, &req, natsw.WithHeader("X-User-Role", userRole))
 	if err != nil {
-		return nil, fmt.Errorf("failed to send get request: %w", err)
-	}
-
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return nil, errResp
-	}
-
-	var dtoResponse documents.GetDocumentResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal get response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	// Получаем контент документа отдельным запросом (временно)
 	// todo: не возвращать контент целиком
 	contentReq := documents.GetDocumentContentRequest{ID: id}
-	contentReqData, err := contentReq.MarshalJSON()
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal content request: %w", err)
-	}
-
-	contentMsg := nats.NewMsg(SubjectDocumentsGetContent)
-	contentMsg.Data = contentReqData
-
-	contentRespMsg, err := c.client.RequestMsg(ctx, contentMsg)
+	contentResponse, err := natsw.Call(ctx, c.client, documentsGetContentEndpoint, &contentReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send content request: %w", err)
-	}
-
-	var contentResponse documents.GetDocumentContentResponse
-	if err := contentResponse.UnmarshalJSON(contentRespMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal content response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	// Конвертируем и добавляем контент
@@ -321,27 +249,10 @@ func (c *NATSDocumentConnector) CreateDocument(ctx context.Context, req domain.C
 		IsPublic:        req.IsPublic,
 	}
 
-	reqData, err := dtoReq.MarshalJSON()
+	// User role in NATS header is used for authorization
+	dtoResponse, err := natsw.Call(ctx, c.client, documentsCreateEndpoint, &dtoReq, natsw.WithHeader("X-User-Role", userRole))
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal create request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectDocumentsCreate)
-	msg.Data = reqData
-
-	// Set user role in NATS header for authorization
-	if userRole != "" {
-		msg.Header.Set("X-User-Role", userRole)
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send create request: %w", err)
-	}
-
-	var dtoResponse documents.CreateDocumentResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal create response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	// Convert dto response back to domain
@@ -367,27 +278,9 @@ func (c *NATSDocumentConnector) UpdateDocument(ctx context.Context, req domain.U
 		IsPublic:        req.IsPublic,
 	}
 
-	reqData, err := dtoReq.MarshalJSON()
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal update request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectDocumentsUpdate)
-	msg.Data = reqData
-
-	// Set user role in NATS header
-	if userRole != "" {
-		msg.Header.Set("X-User-Role", userRole)
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, documentsUpdateEndpoint, &dtoReq, natsw.WithHeader("X-User-Role", userRole))
 	if err != nil {
-		return nil, fmt.Errorf("failed to send update request: %w", err)
-	}
-
-	var dtoResponse documents.UpdateDocumentResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal update response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	// Convert dto response back to domain
@@ -401,28 +294,8 @@ func (c *NATSDocumentConnector) UpdateDocument(ctx context.Context, req domain.U
 // DeleteDocument deletes a document by ID
 func (c *NATSDocumentConnector) DeleteDocument(ctx context.Context, id uuid.UUID, userRole string) error {
 	req := documents.DeleteDocumentRequest{ID: id}
-	reqData, err := req.MarshalJSON()
-	if err != nil {
-		return fmt.Errorf("failed to marshal delete request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectDocumentsDelete)
-	msg.Data = reqData
-
-	// Set user role in NATS header
-	if userRole != "" {
-		msg.Header.Set("X-User-Role", userRole)
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
-	if err != nil {
-		return fmt.Errorf("failed to send delete request: %w", err)
-	}
-
-	// Check for error response
-	var errorResp domain.ErrorResponse
-	if err := errorResp.UnmarshalJSON(respMsg.Data); err == nil && errorResp.Error != "" {
-		return fmt.Errorf("delete failed: %s - %s", errorResp.Error, errorResp.Message)
+	if _, err := natsw.Call(ctx, c.client, documentsDeleteEndpoint, &req, natsw.WithHeader("X-User-Role", userRole)); err != nil {
+		return rpcError(err)
 	}
 
 	return nil
@@ -536,29 +409,9 @@ func (c *NATSDocumentConnector) UploadCover(ctx context.Context, id uuid.UUID, d
 		ContentType: contentType,
 	}
 
-	reqData, err := req.MarshalJSON()
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal upload cover request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectDocumentsCoverUpload)
-	msg.Data = reqData
-	if userRole != "" {
-		msg.Header.Set("X-User-Role", userRole)
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, documentsCoverUploadEndpoint, &req, natsw.WithHeader("X-User-Role", userRole))
 	if err != nil {
-		return "", fmt.Errorf("failed to send upload cover request: %w", err)
-	}
-
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return "", errResp
-	}
-
-	var dtoResponse documents.UploadCoverResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return "", fmt.Errorf("failed to unmarshal upload cover response: %w", err)
+		return "", rpcError(err)
 	}
 
 	return dtoResponse.CoverURL, nil
@@ -567,66 +420,53 @@ func (c *NATSDocumentConnector) UploadCover(ctx context.Context, id uuid.UUID, d
 // ReindexDocument triggers reindexing of a document
 func (c *NATSDocumentConnector) ReindexDocument(ctx context.Context, id uuid.UUID, userRole string) error {
 	req := documents.ReindexDocumentRequest{ID: id}
-	reqData, err := easyjson.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("failed to marshal reindex request: %w", err)
+	if _, err := natsw.Call(ctx, c.client, documentsReindexEndpoint, &req, natsw.WithHeader("X-User-Role", userRole)); err != nil {
+		return rpcError(err)
 	}
 
-	msg := nats.NewMsg(SubjectDocumentsReindex)
-	msg.Data = reqData
-	if userRole != "" {
-		msg.Header.Set("X-User-Role", userRole)
-	}
+	return nil
+}
 
-	respMsg, err := c.client.RequestMsg(ctx, msg)
-	if err != nil {
-		return fmt.Errorf("failed to send reindex request: %w", err)
+// withUserID passes the authenticated user in the X-User-ID header, uuid.Nil is skipped
+func withUserID(userID uuid.UUID) natsw.CallOption {
+	if userID == uuid.Nil {
+		return natsw.WithHeader("X-User-ID", "")
 	}
+	return natsw.WithHeader("X-User-ID", userID.String())
+}
 
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return errResp
+// rpcError maps an error envelope of a typed NATS call to connector errors
+func rpcError(err error) error {
+	if code := natsw.ErrorCode(err); code != "" {
+		return errorFromCode(code)
 	}
-
-	return nil
+	return err
 }
 
-// checkErrorResponse checks if NATS response contains an error and returns it
-func checkErrorResponse(data []byte) error {
-	var errorResp dto.ErrorResponse
-	if err := errorResp.UnmarshalJSON(data); err == nil && errorResp.Error != "" {
-		switch errorResp.Error {
-		case string(dto.ErrCodeInvalidRequest):
-			return ErrInvalidRequest
-		case string(dto.ErrCodeNotFound):
-			return ErrNotFound
-		case string(dto.ErrCodeUnauthorized):
-			return ErrUnauthorized
-		case string(dto.ErrCodeForbidden):
-			return ErrForbidden
-		case string(dto.ErrCodeInternal):
-			return ErrInternal
-		default:
-			return fmt.Errorf("nats error response: %s", errorResp.Error)
-		}
+func errorFromCode(code dto.ErrorCode) error {
+	switch code {
+	case dto.ErrCodeInvalidRequest:
+		return ErrInvalidRequest
+	case dto.ErrCodeNotFound:
+		return ErrNotFound
+	case dto.ErrCodeUnauthorized:
+		return ErrUnauthorized
+	case dto.ErrCodeForbidden:
+		return ErrForbidden
+	case dto.ErrCodeInternal:
+		return ErrInternal
+	default:
+		return fmt.Errorf("nats error response: %s", code)
 	}
-	return nil
 }
 
 // Metadata methods
 
 // ListAuthors retrieves all authors
 func (c *NATSDocumentConnector) ListAuthors(ctx context.Context) (*domain.ListAuthorsResponse, error) {
-	msg := nats.NewMsg(SubjectAuthorsList)
-	msg.Data = []byte("{}")
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, authorsListEndpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send list authors request: %w", err)
-	}
-
-	var dtoResponse documents.ListAuthorsResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal list authors response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	// Convert to domain type
@@ -649,32 +489,9 @@ func (c *NATSDocumentConnector) CreateAuthor(ctx context.Context, req domain.Cre
 		Name: req.Name,
 	}
 
-	reqData, err := dtoReq.MarshalJSON()
+	dtoResponse, err := natsw.Call(ctx, c.client, authorsCreateEndpoint, &dtoReq, natsw.WithHeader("X-User-Role", userRole))
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal create author request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectAuthorsCreate)
-	msg.Data = reqData
-
-	// Set user role in NATS header
-	if userRole != "" {
-		msg.Header.Set("X-User-Role", userRole)
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send create author request: %w", err)
-	}
-
-	// Check for error response
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return nil, errResp
-	}
-
-	var dtoResponse documents.CreateAuthorResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal create author response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	return &domain.CreateAuthorResponse{
@@ -687,17 +504,9 @@ func (c *NATSDocumentConnector) CreateAuthor(ctx context.Context, req domain.Cre
 
 // ListCategories retrieves all categories
 func (c *NATSDocumentConnector) ListCategories(ctx context.Context) (*domain.ListCategoriesResponse, error) {
-	msg := nats.NewMsg(SubjectCategoriesList)
-	msg.Data = []byte("{}")
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, categoriesListEndpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send list categories request: %w", err)
-	}
-
-	var dtoResponse documents.ListCategoriesResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal list categories response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	// Convert to domain type
@@ -720,32 +529,9 @@ func (c *NATSDocumentConnector) CreateCategory(ctx context.Context, req domain.C
 		Title: req.Title,
 	}
 
-	reqData, err := dtoReq.MarshalJSON()
+	dtoResponse, err := natsw.Call(ctx, c.client, categoriesCreateEndpoint, &dtoReq, natsw.WithHeader("X-User-Role", userRole))
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal create category request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectCategoriesCreate)
-	msg.Data = reqData
-
-	// Set user role in NATS header
-	if userRole != "" {
-		msg.Header.Set("X-User-Role", userRole)
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send create category request: %w", err)
-	}
-
-	// Check for error response
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return nil, errResp
-	}
-
-	var dtoResponse documents.CreateCategoryResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal create category response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	return &domain.CreateCategoryResponse{
@@ -758,17 +544,9 @@ func (c *NATSDocumentConnector) CreateCategory(ctx context.Context, req domain.C
 
 // ListTags retrieves all tags
 func (c *NATSDocumentConnector) ListTags(ctx context.Context) (*domain.ListTagsResponse, error) {
-	msg := nats.NewMsg(SubjectTagsList)
-	msg.Data = []byte("{}")
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, tagsListEndpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send list tags request: %w", err)
-	}
-
-	var dtoResponse documents.ListTagsResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal list tags response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	// Convert to domain type
@@ -787,17 +565,9 @@ func (c *NATSDocumentConnector) ListTags(ctx context.Context) (*domain.ListTagsR
 
 // ListDocumentTypes retrieves all document types.
 func (c *NATSDocumentConnector) ListDocumentTypes(ctx context.Context) (*domain.ListDocumentTypesResponse, error) {
-	msg := nats.NewMsg(SubjectDocumentTypesList)
-	msg.Data = []byte("{}")
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, documentTypesListEndpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send list document types request: %w", err)
-	}
-
-	var dtoResponse documents.ListDocumentTypesResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal list document types response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	documentTypes := make([]domain.DocumentType, len(dtoResponse.DocumentTypes))
@@ -810,17 +580,9 @@ func (c *NATSDocumentConnector) ListDocumentTypes(ctx context.Context) (*domain.
 
 // ListAuthorshipTypes retrieves all authorship types.
 func (c *NATSDocumentConnector) ListAuthorshipTypes(ctx context.Context) (*domain.ListAuthorshipTypesResponse, error) {
-	msg := nats.NewMsg(SubjectAuthorshipTypesList)
-	msg.Data = []byte("{}")
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, authorshipTypesListEndpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send list authorship types request: %w", err)
-	}
-
-	var dtoResponse documents.ListAuthorshipTypesResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal list authorship types response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	authorshipTypes := make([]domain.AuthorshipType, len(dtoResponse.AuthorshipTypes))
@@ -866,32 +628,9 @@ func (c *NATSDocumentConnector) CreateTag(ctx context.Context, req domain.Create
 		Title: req.Title,
 	}
 
-	reqData, err := dtoReq.MarshalJSON()
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal create tag request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectTagsCreate)
-	msg.Data = reqData
-
-	// Set user role in NATS header
-	if userRole != "" {
-		msg.Header.Set("X-User-Role", userRole)
-	}
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, tagsCreateEndpoint, &dtoReq, natsw.WithHeader("X-User-Role", userRole))
 	if err != nil {
-		return nil, fmt.Errorf("failed to send create tag request: %w", err)
-	}
-
-	// Check for error response
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return nil, errResp
-	}
-
-	var dtoResponse documents.CreateTagResponse
-	if err := dtoResponse.UnmarshalJSON(respMsg.Data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal create tag response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	return &domain.CreateTagResponse{
@@ -915,26 +654,9 @@ func (c *NATSDocumentConnector) ListNotes(ctx context.Context, query domain.List
 		UserID:     query.UserID,
 	}
 
-	reqData, err := dtoQuery.MarshalJSON()
+	dtoResponse, err := natsw.Call(ctx, c.client, notesListEndpoint, &dtoQuery)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal notes list request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectNotesList)
-	msg.Data = reqData
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send notes list request: %w", err)
-	}
-
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return nil, errResp
-	}
-
-	var dtoResponse documents.ListNotesResponse
-	if err := easyjson.Unmarshal(respMsg.Data, &dtoResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal notes list response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	return &domain.ListNotesResponse{
@@ -953,26 +675,9 @@ func (c *NATSDocumentConnector) GetNote(ctx context.Context, userID, noteID uuid
 		UserID: userID,
 	}
 
-	reqData, err := dtoReq.MarshalJSON()
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal get note request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectNotesGet)
-	msg.Data = reqData
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, notesGetEndpoint, &dtoReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send get note request: %w", err)
-	}
-
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return nil, errResp
-	}
-
-	var dtoResponse documents.GetNoteResponse
-	if err := easyjson.Unmarshal(respMsg.Data, &dtoResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal get note response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	return &domain.GetNoteResponse{Item: convertNote(dtoResponse.Item)}, nil
@@ -989,26 +694,9 @@ func (c *NATSDocumentConnector) CreateNote(ctx context.Context, req domain.Creat
 		PositionInDocument: req.PositionInDocument,
 	}
 
-	reqData, err := dtoReq.MarshalJSON()
+	dtoResponse, err := natsw.Call(ctx, c.client, notesCreateEndpoint, &dtoReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal create note request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectNotesCreate)
-	msg.Data = reqData
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send create note request: %w", err)
-	}
-
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return nil, errResp
-	}
-
-	var dtoResponse documents.CreateNoteResponse
-	if err := easyjson.Unmarshal(respMsg.Data, &dtoResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal create note response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	return &domain.CreateNoteResponse{Item: convertNote(dtoResponse.Item)}, nil
@@ -1029,26 +717,9 @@ func (c *NATSDocumentConnector) UpdateNote(ctx context.Context, req domain.Updat
 		ClearPosition:      req.ClearPosition,
 	}
 
-	reqData, err := dtoReq.MarshalJSON()
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal update note request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectNotesUpdate)
-	msg.Data = reqData
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, notesUpdateEndpoint, &dtoReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send update note request: %w", err)
-	}
-
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return nil, errResp
-	}
-
-	var dtoResponse documents.UpdateNoteResponse
-	if err := easyjson.Unmarshal(respMsg.Data, &dtoResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal update note response: %w", err)
+		return nil, rpcError(err)
 	}
 
 	return &domain.UpdateNoteResponse{Item: convertNote(dtoResponse.Item)}, nil
@@ -1061,26 +732,9 @@ func (c *NATSDocumentConnector) DeleteNote(ctx context.Context, userID, noteID u
 		UserID: userID,
 	}
 
-	reqData, err := dtoReq.MarshalJSON()
-	if err != nil {
-		return fmt.Errorf("failed to marshal delete note request: %w", err)
-	}
-
-	msg := nats.NewMsg(SubjectNotesDelete)
-	msg.Data = reqData
-
-	respMsg, err := c.client.RequestMsg(ctx, msg)
+	dtoResponse, err := natsw.Call(ctx, c.client, notesDeleteEndpoint, &dtoReq)
 	if err != nil {
-		return fmt.Errorf("failed to send delete note request: %w", err)
-	}
-
-	if errResp := checkErrorResponse(respMsg.Data); errResp != nil {
-		return errResp
-	}
-
-	var dtoResponse documents.DeleteNoteResponse
-	if err := easyjson.Unmarshal(respMsg.Data, &dtoResponse); err != nil {
-		return fmt.Errorf("failed to unmarshal delete note response: %w", err)
+		return rpcError(err)
 	}
 	if !dtoResponse.Success {
 		return fmt.Errorf("delete note failed")
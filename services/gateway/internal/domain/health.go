@@ -0,0 +1,24 @@
+package domain
+
+//go:generate easyjson -all health.go
+
+// ServiceInstance identifies a running instance of a backing service
+type ServiceInstance struct {
+	Name    string `json:"name"`
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// ServiceReadiness reports whether a backing service answered the readiness ping
+type ServiceReadiness struct {
+	Ready   bool   `json:"ready"`
+	ID      string `json:"id,omitempty"`
+	Version string `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ReadinessResponse aggregates the readiness of all backing services
+type ReadinessResponse struct {
+	Ready    bool                        `json:"ready"`
+	Services map[string]ServiceReadiness `json:"services"`
+}
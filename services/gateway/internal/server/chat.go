@@ -1,12 +1,21 @@
 package server
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/raibecas/services/gateway/internal/connector"
+	"github.com/artmexbet/raibecas/services/gateway/internal/domain"
 )
 
 // handleWebSocketChat handles WebSocket connections for chat streaming
@@ -25,8 +34,15 @@ func (s *Server) handleWebSocketChat(c *websocket.Conn) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Connect to chat service and bridge connections
-	err := s.chatConnector.Connect(ctx, c, userID)
+	// Connect to chat service and bridge connections.
+	// The quota is charged to the authenticated user, not to the owner of the chat in the path.
+	authUser, ok := c.Locals(UserContextKey).(*AuthUser)
+	if !ok {
+		slog.Error("WebSocket connection without authenticated user", "user_id", userID)
+		c.Close() //nolint:errcheck // safe to ignore
+		return
+	}
+	err := s.chatConnector.Connect(ctx, c, userID, authUser.ID.String(), authUser.Role)
 	if err != nil {
 		slog.Error("Failed to connect to chat service", "user_id", userID, "error", err)
 		c.WriteMessage(websocket.TextMessage, []byte(`{"type":"error","error":"failed to connect to chat service"}`)) //nolint:errcheck // safe to ignore
@@ -47,14 +63,16 @@ func (s *Server) WebSocketUpgradeHandler(c *fiber.Ctx) error {
 	return fiber.ErrUpgradeRequired
 }
 
-// getChatSessions proxies GET /api/v1/chat/:userID/sessions to the chat service
+// getChatSessions proxies GET /api/v1/chat/:userID/sessions to the chat service.
+// Query parameters: archived, limit, offset.
 func (s *Server) getChatSessions(c *fiber.Ctx) error {
 	userID := c.Params("userID")
 	if userID == "" {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "userID is required"})
 	}
 
-	sessions, err := s.chatHTTPConnector.GetUserSessions(c.UserContext(), userID)
+	sessions, err := s.chatHTTPConnector.GetUserSessions(c.UserContext(), userID,
+		c.QueryBool("archived"), c.QueryInt("limit"), c.QueryInt("offset"))
 	if err != nil {
 		slog.Error("failed to get chat sessions", "user_id", userID, "error", err)
 		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get chat sessions"})
@@ -63,6 +81,161 @@ func (s *Server) getChatSessions(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(sessions)
 }
 
+// getChatSessionMessages proxies GET /api/v1/chat/:userID/sessions/:sessionID/messages to the chat service.
+// Query parameters: limit, offset (counted from the newest message).
+func (s *Server) getChatSessionMessages(c *fiber.Ctx) error {
+	userID, sessionID := c.Params("userID"), c.Params("sessionID")
+
+	messages, err := s.chatHTTPConnector.GetSessionMessages(c.UserContext(), userID, sessionID,
+		c.QueryInt("limit"), c.QueryInt("offset"))
+	if err != nil {
+		slog.Error("failed to get chat session messages", "user_id", userID, "session_id", sessionID, "error", err)
+		return chatConnectorError(c, err, "Failed to get chat messages")
+	}
+
+	return c.Status(http.StatusOK).JSON(messages)
+}
+
+// updateChatSession proxies PATCH /api/v1/chat/:userID/sessions/:sessionID (rename, archive, restore).
+func (s *Server) updateChatSession(c *fiber.Ctx) error {
+	userID, sessionID := c.Params("userID"), c.Params("sessionID")
+
+	var update connector.ChatSessionUpdate
+	if err := c.BodyParser(&update); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(domain.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+	}
+
+	session, err := s.chatHTTPConnector.UpdateSession(c.UserContext(), userID, sessionID, update)
+	if err != nil {
+		slog.Error("failed to update chat session", "user_id", userID, "session_id", sessionID, "error", err)
+		return chatConnectorError(c, err, "Failed to update chat session")
+	}
+
+	return c.Status(http.StatusOK).JSON(session)
+}
+
+// setChatMessageFeedback proxies PUT /api/v1/chat/:userID/sessions/:sessionID/messages/:messageID/feedback
+// to the chat service. Rating 1 marks a helpful answer, -1 an unhelpful one and 0 removes the feedback.
+func (s *Server) setChatMessageFeedback(c *fiber.Ctx) error {
+	userID, sessionID, messageID := c.Params("userID"), c.Params("sessionID"), c.Params("messageID")
+
+	var feedback connector.ChatFeedback
+	if err := c.BodyParser(&feedback); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(domain.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+	}
+
+	err := s.chatHTTPConnector.SetMessageFeedback(c.UserContext(), userID, sessionID, messageID, feedback)
+	if err != nil {
+		slog.Error("failed to save chat feedback", "user_id", userID, "session_id", sessionID, "message_id", messageID, "error", err)
+		return chatConnectorError(c, err, "Failed to save feedback")
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// deleteChatSession proxies DELETE /api/v1/chat/:userID/sessions/:sessionID to the chat service.
+func (s *Server) deleteChatSession(c *fiber.Ctx) error {
+	userID, sessionID := c.Params("userID"), c.Params("sessionID")
+
+	if err := s.chatHTTPConnector.DeleteSession(c.UserContext(), userID, sessionID); err != nil {
+		slog.Error("failed to delete chat session", "user_id", userID, "session_id", sessionID, "error", err)
+		return chatConnectorError(c, err, "Failed to delete chat session")
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// shareChatSession proxies POST /api/v1/chat/:userID/sessions/:sessionID/shares to the chat service.
+func (s *Server) shareChatSession(c *fiber.Ctx) error {
+	userID, sessionID := c.Params("userID"), c.Params("sessionID")
+
+	share, err := s.chatHTTPConnector.ShareSession(c.UserContext(), userID, sessionID)
+	if err != nil {
+		slog.Error("failed to share chat session", "user_id", userID, "session_id", sessionID, "error", err)
+		return chatConnectorError(c, err, "Failed to share chat session")
+	}
+
+	return c.Status(http.StatusCreated).JSON(share)
+}
+
+// getChatSessionShares proxies GET /api/v1/chat/:userID/sessions/:sessionID/shares to the chat service.
+func (s *Server) getChatSessionShares(c *fiber.Ctx) error {
+	userID, sessionID := c.Params("userID"), c.Params("sessionID")
+
+	shares, err := s.chatHTTPConnector.ListSessionShares(c.UserContext(), userID, sessionID)
+	if err != nil {
+		slog.Error("failed to list chat session shares", "user_id", userID, "session_id", sessionID, "error", err)
+		return chatConnectorError(c, err, "Failed to list chat session shares")
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"shares": shares})
+}
+
+// revokeChatSessionShare proxies DELETE /api/v1/chat/:userID/shares/:token to the chat service.
+func (s *Server) revokeChatSessionShare(c *fiber.Ctx) error {
+	userID := c.Params("userID")
+
+	if err := s.chatHTTPConnector.RevokeSessionShare(c.UserContext(), userID, c.Params("token")); err != nil {
+		slog.Error("failed to revoke chat session share", "user_id", userID, "error", err)
+		return chatConnectorError(c, err, "Failed to revoke chat session share")
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// getSharedChat serves GET /api/v1/shares/:token without authentication: the token is the only credential.
+func (s *Server) getSharedChat(c *fiber.Ctx) error {
+	share, err := s.chatHTTPConnector.GetSharedTranscript(c.UserContext(), c.Params("token"))
+	if err != nil {
+		if !errors.Is(err, connector.ErrNotFound) {
+			slog.Error("failed to get shared chat", "error", err)
+		}
+		return chatConnectorError(c, err, "Failed to get shared chat")
+	}
+
+	return c.Status(http.StatusOK).JSON(share)
+}
+
+// searchChatMessages proxies GET /api/v1/chat/:userID/search?q= to the chat service.
+func (s *Server) searchChatMessages(c *fiber.Ctx) error {
+	userID := c.Params("userID")
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(http.StatusBadRequest).JSON(domain.ErrorResponse{
+			Error:   "validation_error",
+			Message: "Query parameter q is required",
+		})
+	}
+
+	hits, err := s.chatHTTPConnector.SearchMessages(c.UserContext(), userID, query, c.QueryInt("limit"), c.QueryInt("offset"))
+	if err != nil {
+		slog.Error("failed to search chat messages", "user_id", userID, "error", err)
+		return chatConnectorError(c, err, "Failed to search chat messages")
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"results": hits})
+}
+
+// chatConnectorError writes the error response for a failed chat service call.
+// Rejections by a quota keep the Retry-After of the chat service.
+func chatConnectorError(c *fiber.Ctx, err error, fallbackMsg string) error {
+	status, errorCode, message := mapConnectorError(err, fallbackMsg)
+	var quotaErr *connector.QuotaExceededError
+	if errors.As(err, &quotaErr) && quotaErr.RetryAfter > 0 {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(quotaErr.RetryAfter.Seconds())))
+	}
+	return c.Status(status).JSON(domain.ErrorResponse{
+		Error:   errorCode,
+		Message: message,
+	})
+}
+
 // createChatSession proxies POST /api/v1/chat/:userID/sessions to the chat service
 func (s *Server) createChatSession(c *fiber.Ctx) error {
 	userID := c.Params("userID")
@@ -85,3 +258,218 @@ func (s *Server) createChatSession(c *fiber.Ctx) error {
 
 	return c.Status(http.StatusCreated).JSON(fiber.Map{"session_id": sessionID})
 }
+
+// streamChatRequest is the body of POST /api/v1/chat/stream
+type streamChatRequest struct {
+	SessionID string `json:"session_id"`
+	Input     string `json:"input" validate:"required"`
+	connector.ChatOptions
+}
+
+// regenerateChatRequest is the body of POST /api/v1/chat/regenerate
+type regenerateChatRequest struct {
+	SessionID string `json:"session_id"`
+	connector.ChatOptions
+}
+
+// editChatMessageRequest is the body of POST /api/v1/chat/edit
+type editChatMessageRequest struct {
+	SessionID string `json:"session_id"`
+	MessageID string `json:"message_id" validate:"required"`
+	Input     string `json:"input" validate:"required"`
+	connector.ChatOptions
+}
+
+// switchChatBranchRequest is the body of POST /api/v1/chat/branch
+type switchChatBranchRequest struct {
+	SessionID string `json:"session_id"`
+	MessageID string `json:"message_id" validate:"required"`
+}
+
+// streamChat relays a chat answer as server-sent events.
+// Every chunk is sent as a default "message" event whose data is the chat service ChatResponse JSON;
+// a failure after the stream started is sent as an "error" event. Closing the connection stops generation.
+func (s *Server) streamChat(c *fiber.Ctx) error {
+	authUser, ok := getAuthUser(c)
+	if !ok {
+		return unauthorizedChat(c)
+	}
+
+	var req streamChatRequest
+	if errResp := s.parseChatRequest(c, &req); errResp != nil {
+		return c.Status(http.StatusBadRequest).JSON(errResp)
+	}
+
+	userID := authUser.ID.String()
+	req.UserRole = authUser.Role
+	return s.relayChatStream(c, userID, func(ctx context.Context, fn func(connector.ChatChunk) error) error {
+		return s.chatHTTPConnector.StreamChat(ctx, userID, req.SessionID, req.Input, req.ChatOptions, fn)
+	})
+}
+
+// regenerateChat relays a new version of the last answer of the session as server-sent events like streamChat.
+func (s *Server) regenerateChat(c *fiber.Ctx) error {
+	authUser, ok := getAuthUser(c)
+	if !ok {
+		return unauthorizedChat(c)
+	}
+
+	var req regenerateChatRequest
+	if errResp := s.parseChatRequest(c, &req); errResp != nil {
+		return c.Status(http.StatusBadRequest).JSON(errResp)
+	}
+
+	userID := authUser.ID.String()
+	req.UserRole = authUser.Role
+	return s.relayChatStream(c, userID, func(ctx context.Context, fn func(connector.ChatChunk) error) error {
+		return s.chatHTTPConnector.RegenerateChat(ctx, userID, req.SessionID, req.ChatOptions, fn)
+	})
+}
+
+// editChatMessage relays the answer to an edited user message as server-sent events like streamChat.
+func (s *Server) editChatMessage(c *fiber.Ctx) error {
+	authUser, ok := getAuthUser(c)
+	if !ok {
+		return unauthorizedChat(c)
+	}
+
+	var req editChatMessageRequest
+	if errResp := s.parseChatRequest(c, &req); errResp != nil {
+		return c.Status(http.StatusBadRequest).JSON(errResp)
+	}
+
+	userID := authUser.ID.String()
+	req.UserRole = authUser.Role
+	return s.relayChatStream(c, userID, func(ctx context.Context, fn func(connector.ChatChunk) error) error {
+		return s.chatHTTPConnector.EditChatMessage(ctx, userID, req.SessionID, req.MessageID, req.Input, req.ChatOptions, fn)
+	})
+}
+
+// switchChatBranch activates another version of a message and returns the messages of the new active branch.
+func (s *Server) switchChatBranch(c *fiber.Ctx) error {
+	authUser, ok := getAuthUser(c)
+	if !ok {
+		return unauthorizedChat(c)
+	}
+
+	var req switchChatBranchRequest
+	if errResp := s.parseChatRequest(c, &req); errResp != nil {
+		return c.Status(http.StatusBadRequest).JSON(errResp)
+	}
+
+	userID := authUser.ID.String()
+	messages, err := s.chatHTTPConnector.SwitchChatBranch(c.UserContext(), userID, req.SessionID, req.MessageID)
+	if err != nil {
+		slog.Error("failed to switch chat branch", "user_id", userID, "error", err)
+		return chatConnectorError(c, err, "Failed to switch chat branch")
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"messages": messages})
+}
+
+// unauthorizedChat writes the 401 response of chat endpoints.
+func unauthorizedChat(c *fiber.Ctx) error {
+	return c.Status(http.StatusUnauthorized).JSON(domain.ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Authentication required",
+	})
+}
+
+// parseChatRequest parses and validates the body into req.
+// It returns the 400 response body on failure and nil otherwise.
+func (s *Server) parseChatRequest(c *fiber.Ctx, req any) *domain.ErrorResponse {
+	if err := c.BodyParser(req); err != nil {
+		return &domain.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		}
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return &domain.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		}
+	}
+	return nil
+}
+
+// relayChatStream runs stream and relays its chunks to the client as server-sent events.
+// Errors returned before the first chunk are mapped to a status code.
+func (s *Server) relayChatStream(
+	c *fiber.Ctx,
+	userID string,
+	stream func(ctx context.Context, fn func(connector.ChatChunk) error) error,
+) error {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c.UserContext()))
+	chunks := make(chan []byte)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(chunks)
+		errCh <- stream(ctx, func(chunk connector.ChatChunk) error {
+			data, err := json.Marshal(chunk)
+			if err != nil {
+				return err
+			}
+			select {
+			case chunks <- data:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	// Wait for the first chunk so session errors can still be reported with a status code.
+	first, ok := <-chunks
+	if !ok {
+		cancel()
+		if err := <-errCh; err != nil {
+			slog.Error("failed to stream chat", "user_id", userID, "error", err)
+			return chatConnectorError(c, err, "Failed to process chat message")
+		}
+		return c.SendStatus(http.StatusNoContent)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // disable proxy buffering (nginx)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		send := func(event string, data []byte) bool {
+			if event != "" {
+				fmt.Fprintf(w, "event: %s\n", event) //nolint:errcheck // write errors surface on Flush
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data) //nolint:errcheck // write errors surface on Flush
+			if err := w.Flush(); err != nil {
+				slog.Debug("chat stream client disconnected", "user_id", userID, "error", err)
+				return false
+			}
+			return true
+		}
+		abort := func() {
+			cancel()
+			for range chunks {
+			}
+		}
+
+		if !send("", first) {
+			abort()
+			return
+		}
+		for data := range chunks {
+			if !send("", data) {
+				abort()
+				return
+			}
+		}
+
+		if err := <-errCh; err != nil {
+			slog.Error("chat stream interrupted", "user_id", userID, "error", err)
+			send("error", []byte(`{"error":"internal_error","message":"Failed to process chat message"}`))
+		}
+	})
+	return nil
+}
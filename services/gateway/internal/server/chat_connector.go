@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+
+	"github.com/artmexbet/raibecas/services/gateway/internal/connector"
+)
+
+// ChatServiceConnector defines the interface for communicating with the chat service REST API
+type ChatServiceConnector interface {
+	// GetUserSessions retrieves a page of a user's active or archived chat sessions without messages
+	GetUserSessions(ctx context.Context, userID string, archived bool, limit, offset int) (connector.ChatSessionPage, error)
+
+	// GetSessionMessages retrieves a page of the active branch of a session, newest page first
+	GetSessionMessages(ctx context.Context, userID, sessionID string, limit, offset int) (connector.ChatMessagePage, error)
+
+	// UpdateSession renames, archives or restores a chat session
+	UpdateSession(ctx context.Context, userID, sessionID string, update connector.ChatSessionUpdate) (connector.ChatSession, error)
+
+	// SetMessageFeedback rates an assistant message of a chat session
+	SetMessageFeedback(ctx context.Context, userID, sessionID, messageID string, feedback connector.ChatFeedback) error
+
+	// DeleteSession deletes a chat session with its messages
+	DeleteSession(ctx context.Context, userID, sessionID string) error
+
+	// ShareSession publishes a read-only snapshot of a chat session under a random token
+	ShareSession(ctx context.Context, userID, sessionID string) (connector.ChatShare, error)
+
+	// ListSessionShares retrieves the snapshots of a chat session published by the user
+	ListSessionShares(ctx context.Context, userID, sessionID string) ([]connector.ChatShare, error)
+
+	// RevokeSessionShare deletes a snapshot published by the user
+	RevokeSessionShare(ctx context.Context, userID, token string) error
+
+	// GetSharedTranscript retrieves the snapshot published under token
+	GetSharedTranscript(ctx context.Context, token string) (connector.ChatShare, error)
+
+	// SearchMessages runs a full-text search over a user's chat messages
+	SearchMessages(ctx context.Context, userID, query string, limit, offset int) ([]connector.ChatSearchHit, error)
+
+	// CreateSession creates a new chat session and returns its ID
+	CreateSession(ctx context.Context, userID, title string) (string, error)
+
+	// StreamChat sends a chat message and calls fn for every chunk of the answer as it is generated
+	StreamChat(ctx context.Context, userID, sessionID, input string, opts connector.ChatOptions, fn func(connector.ChatChunk) error) error
+
+	// RegenerateChat streams a new version of the last answer of the session, keeping the old one as a branch
+	RegenerateChat(ctx context.Context, userID, sessionID string, opts connector.ChatOptions, fn func(connector.ChatChunk) error) error
+
+	// EditChatMessage streams the answer to an edited version of a user message, keeping the original as a branch
+	EditChatMessage(
+		ctx context.Context,
+		userID, sessionID, messageID, input string,
+		opts connector.ChatOptions,
+		fn func(connector.ChatChunk) error,
+	) error
+
+	// SwitchChatBranch activates the newest branch going through messageID and returns its messages
+	SwitchChatBranch(ctx context.Context, userID, sessionID, messageID string) ([]connector.ChatMessage, error)
+
+	// ListCorpora retrieves the corpora chat requests can be routed to
+	ListCorpora(ctx context.Context) ([]connector.Corpus, error)
+
+	// GetCorpus retrieves a corpus with its persona
+	GetCorpus(ctx context.Context, id string) (connector.Corpus, error)
+
+	// CreateCorpus registers a new corpus
+	CreateCorpus(ctx context.Context, corpus connector.Corpus) (connector.Corpus, error)
+
+	// UpdateCorpus replaces the settings of a corpus
+	UpdateCorpus(ctx context.Context, corpus connector.Corpus) (connector.Corpus, error)
+
+	// DeleteCorpus removes a corpus from the registry
+	DeleteCorpus(ctx context.Context, id string) error
+}
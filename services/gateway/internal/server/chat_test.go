@@ -0,0 +1,412 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artmexbet/raibecas/services/gateway/internal/connector"
+)
+
+func postStreamChat(t *testing.T, app *routedApp, body string) *http.Response {
+	t.Helper()
+	return postChatRoute(t, app, "/api/v1/chat/stream", body)
+}
+
+func TestStreamChatRequiresAuth(t *testing.T) {
+	t.Parallel()
+
+	srv := &Server{validator: validator.New(), chatHTTPConnector: NewMockChatServiceConnector(t)}
+
+	resp := postStreamChat(t, newRoutedApp(t, srv, nil), `{"input":"hi"}`)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestStreamChatRequiresInput(t *testing.T) {
+	t.Parallel()
+
+	srv := &Server{validator: validator.New(), chatHTTPConnector: NewMockChatServiceConnector(t)}
+
+	resp := postStreamChat(t, newRoutedApp(t, srv, &AuthUser{ID: uuid.New()}), `{"session_id":"s1"}`)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestStreamChatSendsEvents(t *testing.T) {
+	t.Parallel()
+
+	user := &AuthUser{ID: uuid.New()}
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		StreamChat(mock.Anything, user.ID.String(), "s1", "Привет", connector.ChatOptions{}, mock.Anything).
+		RunAndReturn(func(_ context.Context, _, _, _ string, _ connector.ChatOptions, fn func(connector.ChatChunk) error) error {
+			if err := fn(connector.ChatChunk{Message: &connector.ChatMessage{Role: "assistant", Content: "Здрав"}}); err != nil {
+				return err
+			}
+			return fn(connector.ChatChunk{Done: true, Message: &connector.ChatMessage{Role: "assistant", Content: "ствуйте"}})
+		})
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	resp := postStreamChat(t, newRoutedApp(t, srv, user), `{"session_id":"s1","input":"Привет"}`)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	var events []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			events = append(events, data)
+		}
+	}
+	require.Len(t, events, 2)
+	assert.Contains(t, events[0], `"content":"Здрав"`)
+	assert.Contains(t, events[1], `"done":true`)
+}
+
+func TestStreamChatPassesFiltersAndRole(t *testing.T) {
+	t.Parallel()
+
+	user := &AuthUser{ID: uuid.New(), Role: "User"}
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		StreamChat(mock.Anything, user.ID.String(), "", "hi", mock.MatchedBy(func(opts connector.ChatOptions) bool {
+			return opts.UserRole == "User" && opts.Filters != nil && assert.ObjectsAreEqual([]string{"d1"}, opts.Filters.DocumentIDs)
+		}), mock.Anything).
+		RunAndReturn(func(_ context.Context, _, _, _ string, _ connector.ChatOptions, fn func(connector.ChatChunk) error) error {
+			return fn(connector.ChatChunk{Done: true})
+		})
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	// The role is taken from the token, a role in the body is ignored.
+	resp := postStreamChat(t, newRoutedApp(t, srv, user), `{"input":"hi","UserRole":"Admin","filters":{"document_ids":["d1"]}}`)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestStreamChatMapsSessionNotFound(t *testing.T) {
+	t.Parallel()
+
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		StreamChat(mock.Anything, mock.Anything, "missing", "hi", mock.Anything, mock.Anything).
+		Return(connector.ErrNotFound)
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	resp := postStreamChat(t, newRoutedApp(t, srv, &AuthUser{ID: uuid.New()}), `{"session_id":"missing","input":"hi"}`)
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestStreamChatMapsQuotaExceeded(t *testing.T) {
+	t.Parallel()
+
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		StreamChat(mock.Anything, mock.Anything, "", "hi", mock.Anything, mock.Anything).
+		Return(&connector.QuotaExceededError{RetryAfter: 30 * time.Second})
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	resp := postStreamChat(t, newRoutedApp(t, srv, &AuthUser{ID: uuid.New()}), `{"input":"hi"}`)
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get(fiber.HeaderRetryAfter))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"quota_exceeded"`)
+}
+
+func TestStreamChatSendsErrorEvent(t *testing.T) {
+	t.Parallel()
+
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		StreamChat(mock.Anything, mock.Anything, "", "hi", mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _, _, _ string, _ connector.ChatOptions, fn func(connector.ChatChunk) error) error {
+			if err := fn(connector.ChatChunk{Message: &connector.ChatMessage{Content: "частично"}}); err != nil {
+				return err
+			}
+			return io.ErrUnexpectedEOF
+		})
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	resp := postStreamChat(t, newRoutedApp(t, srv, &AuthUser{ID: uuid.New()}), `{"input":"hi"}`)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "event: error\n")
+}
+
+func postChatRoute(t *testing.T, app *routedApp, path, body string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestRegenerateChatSendsEvents(t *testing.T) {
+	t.Parallel()
+
+	user := &AuthUser{ID: uuid.New()}
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		RegenerateChat(mock.Anything, user.ID.String(), "s1", mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _, _ string, _ connector.ChatOptions, fn func(connector.ChatChunk) error) error {
+			return fn(connector.ChatChunk{Done: true, Message: &connector.ChatMessage{ID: "a2", ParentID: "q1", Role: "assistant"}})
+		})
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	resp := postChatRoute(t, newRoutedApp(t, srv, user), "/api/v1/chat/regenerate", `{"session_id":"s1"}`)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"id":"a2"`)
+}
+
+func TestEditChatMessageValidation(t *testing.T) {
+	t.Parallel()
+
+	srv := &Server{validator: validator.New(), chatHTTPConnector: NewMockChatServiceConnector(t)}
+
+	resp := postChatRoute(t, newRoutedApp(t, srv, &AuthUser{ID: uuid.New()}), "/api/v1/chat/edit", `{"session_id":"s1","input":"hi"}`)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestEditChatMessageMapsNotFound(t *testing.T) {
+	t.Parallel()
+
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		EditChatMessage(mock.Anything, mock.Anything, "s1", "missing", "hi", mock.Anything, mock.Anything).
+		Return(connector.ErrNotFound)
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	resp := postChatRoute(t, newRoutedApp(t, srv, &AuthUser{ID: uuid.New()}), "/api/v1/chat/edit", `{"session_id":"s1","message_id":"missing","input":"hi"}`)
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSwitchChatBranch(t *testing.T) {
+	t.Parallel()
+
+	user := &AuthUser{ID: uuid.New()}
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		SwitchChatBranch(mock.Anything, user.ID.String(), "s1", "q2").
+		Return([]connector.ChatMessage{{ID: "q2", Role: "user", SiblingIDs: []string{"q1", "q2"}}}, nil)
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	resp := postChatRoute(t, newRoutedApp(t, srv, user), "/api/v1/chat/branch", `{"session_id":"s1","message_id":"q2"}`)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"sibling_ids":["q1","q2"]`)
+}
+
+func TestUserChatRoutesRequireOwnership(t *testing.T) {
+	t.Parallel()
+
+	owner := uuid.New()
+	tests := []struct {
+		name   string
+		user   *AuthUser
+		status int
+	}{
+		{name: "owner", user: &AuthUser{ID: owner, Role: "User"}, status: http.StatusNoContent},
+		{name: "other user", user: &AuthUser{ID: uuid.New(), Role: "User"}, status: http.StatusForbidden},
+		{name: "admin", user: &AuthUser{ID: uuid.New(), Role: "Admin"}, status: http.StatusNoContent},
+		{name: "super admin", user: &AuthUser{ID: uuid.New(), Role: "SuperAdmin"}, status: http.StatusNoContent},
+		{name: "anonymous", user: nil, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chat := NewMockChatServiceConnector(t)
+			if tt.status == http.StatusNoContent {
+				chat.EXPECT().DeleteSession(mock.Anything, owner.String(), "s1").Return(nil)
+			}
+			srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/chat/"+owner.String()+"/sessions/s1", nil)
+			resp, err := newRoutedApp(t, srv, tt.user).Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebSocketChatRequiresOwnership(t *testing.T) {
+	t.Parallel()
+
+	owner := uuid.New()
+	tests := []struct {
+		name   string
+		user   *AuthUser
+		status int
+	}{
+		// Allowed requests reach the upgrade, which plain HTTP requests fail.
+		{name: "owner", user: &AuthUser{ID: owner, Role: "User"}, status: http.StatusUpgradeRequired},
+		{name: "other user", user: &AuthUser{ID: uuid.New(), Role: "User"}, status: http.StatusForbidden},
+		{name: "admin", user: &AuthUser{ID: uuid.New(), Role: "Admin"}, status: http.StatusUpgradeRequired},
+		{name: "anonymous", user: nil, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &Server{validator: validator.New()}
+
+			req := httptest.NewRequest(http.MethodGet, "/ws/chat/"+owner.String(), nil)
+			resp, err := newRoutedApp(t, srv, tt.user).Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestDeleteChatSessionMapsNotFound(t *testing.T) {
+	t.Parallel()
+
+	user := &AuthUser{ID: uuid.New()}
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().DeleteSession(mock.Anything, user.ID.String(), "missing").Return(connector.ErrNotFound)
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/chat/"+user.ID.String()+"/sessions/missing", nil)
+	resp, err := newRoutedApp(t, srv, user).Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSetChatMessageFeedback(t *testing.T) {
+	t.Parallel()
+
+	user := &AuthUser{ID: uuid.New()}
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		SetMessageFeedback(mock.Anything, user.ID.String(), "s1", "a1", connector.ChatFeedback{Rating: -1, Comment: "Не по тексту"}).
+		Return(nil)
+	chat.EXPECT().
+		SetMessageFeedback(mock.Anything, user.ID.String(), "s1", "q1", connector.ChatFeedback{Rating: 1}).
+		Return(connector.ErrInvalidRequest)
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+	app := newRoutedApp(t, srv, user)
+
+	put := func(messageID, body string) int {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/chat/"+user.ID.String()+"/sessions/s1/messages/"+messageID+"/feedback", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusNoContent, put("a1", `{"rating":-1,"comment":"Не по тексту"}`))
+	assert.Equal(t, http.StatusBadRequest, put("q1", `{"rating":1}`))
+}
+
+func TestShareChatSession(t *testing.T) {
+	t.Parallel()
+
+	user := &AuthUser{ID: uuid.New()}
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		ShareSession(mock.Anything, user.ID.String(), "s1").
+		Return(connector.ChatShare{Token: "t1", SessionID: "s1", Title: "Диалектика"}, nil)
+	chat.EXPECT().ShareSession(mock.Anything, user.ID.String(), "empty").Return(connector.ChatShare{}, connector.ErrInvalidRequest)
+	chat.EXPECT().RevokeSessionShare(mock.Anything, user.ID.String(), "t1").Return(nil)
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+	app := newRoutedApp(t, srv, user)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/chat/"+user.ID.String()+"/sessions/s1/shares", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var share connector.ChatShare
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&share))
+	assert.Equal(t, "t1", share.Token)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/chat/"+user.ID.String()+"/sessions/empty/shares", nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/chat/"+user.ID.String()+"/shares/t1", nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestGetSharedChatIsPublic(t *testing.T) {
+	t.Parallel()
+
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().GetSharedTranscript(mock.Anything, "t1").Return(connector.ChatShare{
+		Token:    "t1",
+		Title:    "Диалектика",
+		Messages: []connector.ChatMessage{{Role: "assistant", Content: "Метод", Citations: []connector.ChatCitation{{DocumentID: "d1"}}}},
+	}, nil)
+	chat.EXPECT().GetSharedTranscript(mock.Anything, "revoked").Return(connector.ChatShare{}, connector.ErrNotFound)
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+	app := newRoutedApp(t, srv, nil)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/shares/t1", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var share connector.ChatShare
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&share))
+	require.Len(t, share.Messages, 1)
+	assert.Equal(t, "d1", share.Messages[0].Citations[0].DocumentID)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/shares/revoked", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSearchChatMessagesRequiresQuery(t *testing.T) {
+	t.Parallel()
+
+	user := &AuthUser{ID: uuid.New()}
+	srv := &Server{validator: validator.New(), chatHTTPConnector: NewMockChatServiceConnector(t)}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/chat/"+user.ID.String()+"/search?q=%20", nil)
+	resp, err := newRoutedApp(t, srv, user).Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
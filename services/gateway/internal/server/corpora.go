@@ -0,0 +1,83 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/raibecas/services/gateway/internal/connector"
+	"github.com/artmexbet/raibecas/services/gateway/internal/domain"
+)
+
+// listCorpora proxies GET /api/v1/corpora to the chat service.
+func (s *Server) listCorpora(c *fiber.Ctx) error {
+	corpora, err := s.chatHTTPConnector.ListCorpora(c.UserContext())
+	if err != nil {
+		slog.Error("failed to list corpora", "error", err)
+		return chatConnectorError(c, err, "Failed to list corpora")
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"corpora": corpora})
+}
+
+// getCorpus proxies GET /api/v1/corpora/:id to the chat service.
+func (s *Server) getCorpus(c *fiber.Ctx) error {
+	corpus, err := s.chatHTTPConnector.GetCorpus(c.UserContext(), c.Params("id"))
+	if err != nil {
+		slog.Error("failed to get corpus", "corpus_id", c.Params("id"), "error", err)
+		return chatConnectorError(c, err, "Failed to get corpus")
+	}
+
+	return c.Status(http.StatusOK).JSON(corpus)
+}
+
+// createCorpus proxies POST /api/v1/corpora to the chat service.
+func (s *Server) createCorpus(c *fiber.Ctx) error {
+	var corpus connector.Corpus
+	if err := c.BodyParser(&corpus); err != nil {
+		return invalidCorpusBody(c)
+	}
+
+	created, err := s.chatHTTPConnector.CreateCorpus(c.UserContext(), corpus)
+	if err != nil {
+		slog.Error("failed to create corpus", "corpus_id", corpus.ID, "error", err)
+		return chatConnectorError(c, err, "Failed to create corpus")
+	}
+
+	return c.Status(http.StatusCreated).JSON(created)
+}
+
+// updateCorpus proxies PUT /api/v1/corpora/:id to the chat service.
+func (s *Server) updateCorpus(c *fiber.Ctx) error {
+	var corpus connector.Corpus
+	if err := c.BodyParser(&corpus); err != nil {
+		return invalidCorpusBody(c)
+	}
+	corpus.ID = c.Params("id")
+
+	updated, err := s.chatHTTPConnector.UpdateCorpus(c.UserContext(), corpus)
+	if err != nil {
+		slog.Error("failed to update corpus", "corpus_id", corpus.ID, "error", err)
+		return chatConnectorError(c, err, "Failed to update corpus")
+	}
+
+	return c.Status(http.StatusOK).JSON(updated)
+}
+
+// deleteCorpus proxies DELETE /api/v1/corpora/:id to the chat service.
+func (s *Server) deleteCorpus(c *fiber.Ctx) error {
+	if err := s.chatHTTPConnector.DeleteCorpus(c.UserContext(), c.Params("id")); err != nil {
+		slog.Error("failed to delete corpus", "corpus_id", c.Params("id"), "error", err)
+		return chatConnectorError(c, err, "Failed to delete corpus")
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+func invalidCorpusBody(c *fiber.Ctx) error {
+	return c.Status(http.StatusBadRequest).JSON(domain.ErrorResponse{
+		Error:   "invalid_request",
+		Message: "Invalid request body",
+	})
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artmexbet/raibecas/services/gateway/internal/connector"
+)
+
+func TestListCorporaAllowsAnyUser(t *testing.T) {
+	t.Parallel()
+
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().ListCorpora(mock.Anything).Return([]connector.Corpus{{ID: "kant", Name: "Кант"}}, nil)
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/corpora/", nil)
+	resp, err := newRoutedApp(t, srv, &AuthUser{ID: uuid.New(), Role: "User"}).Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestCorpusWritesRequireAdmin(t *testing.T) {
+	t.Parallel()
+
+	chat := NewMockChatServiceConnector(t)
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/corpora/kant", nil)
+	resp, err := newRoutedApp(t, srv, &AuthUser{ID: uuid.New(), Role: "User"}).Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestUpdateCorpusUsesPathID(t *testing.T) {
+	t.Parallel()
+
+	chat := NewMockChatServiceConnector(t)
+	chat.EXPECT().
+		UpdateCorpus(mock.Anything, mock.MatchedBy(func(c connector.Corpus) bool { return c.ID == "kant" })).
+		Return(connector.Corpus{}, connector.ErrNotFound)
+	srv := &Server{validator: validator.New(), chatHTTPConnector: chat}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/corpora/kant", strings.NewReader(`{"id":"hegel","name":"Кант"}`))
+	req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+	resp, err := newRoutedApp(t, srv, &AuthUser{ID: uuid.New(), Role: "Admin"}).Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -1173,6 +1173,34 @@ func (_m *MockDocumentServiceConnector) DeleteNote(ctx context.Context, userID u
 	return r0
 }
 
+// SemanticSearch provides a mock function with given fields: ctx, query
+func (_m *MockDocumentServiceConnector) SemanticSearch(ctx context.Context, query domain.SearchQuery) (*domain.SearchResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SemanticSearch")
+	}
+
+	var r0 *domain.SearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchQuery) (*domain.SearchResponse, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchQuery) *domain.SearchResponse); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SearchResponse)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SearchQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 // NewMockDocumentServiceConnector creates a new instance of MockDocumentServiceConnector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDocumentServiceConnector(t interface {
@@ -23,6 +23,12 @@ func mapConnectorError(err error, fallbackMsg string) (status int, code string,
 		return http.StatusUnauthorized, "unauthorized", fallbackMsg
 	case errors.Is(err, connector.ErrForbidden):
 		return http.StatusForbidden, "forbidden", fallbackMsg
+	case errors.Is(err, connector.ErrConflict):
+		return http.StatusConflict, "conflict", fallbackMsg
+	case errors.Is(err, connector.ErrQuotaExceeded):
+		return http.StatusTooManyRequests, "quota_exceeded", "Chat quota exceeded"
+	case errors.Is(err, connector.ErrUnavailable):
+		return http.StatusServiceUnavailable, "unavailable", "Service is busy, try again later"
 	default:
 		return http.StatusInternalServerError, "internal_error", fallbackMsg
 	}
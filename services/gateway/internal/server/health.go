@@ -0,0 +1,54 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/raibecas/services/gateway/internal/domain"
+)
+
+// readiness pings every required backing service in parallel and answers 503
+// unless all of them have at least one instance ready to serve requests
+func (s *Server) readiness(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), s.readinessCfg.Timeout)
+	defer cancel()
+
+	resp := domain.ReadinessResponse{
+		Ready:    true,
+		Services: make(map[string]domain.ServiceReadiness, len(s.readinessCfg.Services)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, name := range s.readinessCfg.Services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			instance, err := s.healthConnector.PingService(ctx, name)
+			status := domain.ServiceReadiness{
+				Ready:   err == nil,
+				ID:      instance.ID,
+				Version: instance.Version,
+			}
+			if err != nil {
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Services[name] = status
+			resp.Ready = resp.Ready && status.Ready
+		}()
+	}
+	wg.Wait()
+
+	if !resp.Ready {
+		c.Status(fiber.StatusServiceUnavailable)
+	}
+	return c.JSON(resp)
+}
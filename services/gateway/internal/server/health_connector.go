@@ -0,0 +1,13 @@
+package server
+
+import (
+	"context"
+
+	"github.com/artmexbet/raibecas/services/gateway/internal/domain"
+)
+
+// HealthConnector defines the interface for checking backing services over NATS
+type HealthConnector interface {
+	// PingService returns an instance of the named service that is ready to serve requests
+	PingService(ctx context.Context, name string) (domain.ServiceInstance, error)
+}
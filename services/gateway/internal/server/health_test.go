@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artmexbet/raibecas/services/gateway/internal/config"
+	"github.com/artmexbet/raibecas/services/gateway/internal/domain"
+)
+
+func getReadiness(t *testing.T, srv *Server) (*http.Response, domain.ReadinessResponse) {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/readyz", srv.readiness)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body domain.ReadinessResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp, body
+}
+
+func TestReadinessAllServicesUp(t *testing.T) {
+	t.Parallel()
+
+	health := NewMockHealthConnector(t)
+	health.EXPECT().PingService(mock.Anything, "auth").Return(domain.ServiceInstance{Name: "auth", ID: "a1", Version: "1.0.0"}, nil)
+	health.EXPECT().PingService(mock.Anything, "users").Return(domain.ServiceInstance{Name: "users", ID: "u1", Version: "1.2.0"}, nil)
+	srv := &Server{
+		healthConnector: health,
+		readinessCfg:    config.ReadinessConfig{Services: []string{"auth", "users"}, Timeout: time.Second},
+	}
+
+	resp, body := getReadiness(t, srv)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, body.Ready)
+	assert.Equal(t, domain.ServiceReadiness{Ready: true, ID: "u1", Version: "1.2.0"}, body.Services["users"])
+	assert.Len(t, body.Services, 2)
+}
+
+func TestReadinessReportsMissingService(t *testing.T) {
+	t.Parallel()
+
+	health := NewMockHealthConnector(t)
+	health.EXPECT().PingService(mock.Anything, "auth").Return(domain.ServiceInstance{Name: "auth", ID: "a1"}, nil)
+	health.EXPECT().PingService(mock.Anything, "documents").Return(domain.ServiceInstance{}, errors.New("no responders"))
+	srv := &Server{
+		healthConnector: health,
+		readinessCfg:    config.ReadinessConfig{Services: []string{"auth", "documents"}, Timeout: time.Second},
+	}
+
+	resp, body := getReadiness(t, srv)
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.False(t, body.Ready)
+	assert.True(t, body.Services["auth"].Ready)
+	assert.Equal(t, domain.ServiceReadiness{Error: "no responders"}, body.Services["documents"])
+}
+
+func TestReadinessPassesDeadlineToPings(t *testing.T) {
+	t.Parallel()
+
+	health := NewMockHealthConnector(t)
+	health.EXPECT().PingService(mock.MatchedBy(func(ctx interface{ Deadline() (time.Time, bool) }) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	}), "users").Return(domain.ServiceInstance{ID: "u1"}, nil)
+	srv := &Server{
+		healthConnector: health,
+		readinessCfg:    config.ReadinessConfig{Services: []string{"users"}, Timeout: time.Second},
+	}
+
+	resp, _ := getReadiness(t, srv)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
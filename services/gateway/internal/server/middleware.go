@@ -250,3 +250,40 @@ func requireRole(roles ...string) fiber.Handler {
 		return c.Next()
 	}
 }
+
+// requireSelfOrRole returns a middleware that allows access to routes scoped to the user in the param path parameter
+// only for that user or for users with one of the specified roles.
+// Must be used after authMiddleware (which populates UserContextKey).
+func requireSelfOrRole(param string, roles ...string) fiber.Handler {
+	allowed := make(map[string]struct{}, len(roles))
+	for _, r := range roles {
+		allowed[r] = struct{}{}
+	}
+
+	return func(c *fiber.Ctx) error {
+		user, ok := getAuthUser(c)
+		if !ok {
+			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+				"error":   "unauthorized",
+				"message": "Authentication required",
+			})
+		}
+
+		if c.Params(param) == user.ID.String() {
+			return c.Next()
+		}
+		if _, permitted := allowed[user.Role]; !permitted {
+			slog.Warn("access denied: foreign resource",
+				"user_id", user.ID,
+				"user_role", user.Role,
+				"owner_id", c.Params(param),
+			)
+			return c.Status(http.StatusForbidden).JSON(fiber.Map{
+				"error":   "forbidden",
+				"message": "Access to another user's data is not allowed",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -29,7 +29,9 @@ type Server struct {
 	authConnector     AuthServiceConnector
 	userConnector     UserServiceConnector
 	chatConnector     *connector.ChatWSConnector
-	chatHTTPConnector *connector.ChatHTTPConnector
+	chatHTTPConnector ChatServiceConnector
+	healthConnector   HealthConnector
+	readinessCfg      config.ReadinessConfig
 	validator         *validator.Validate
 }
 
@@ -40,7 +42,9 @@ func New(
 	authConnector AuthServiceConnector,
 	userConnector UserServiceConnector,
 	chatConnector *connector.ChatWSConnector,
-	chatHTTPConnector *connector.ChatHTTPConnector,
+	chatHTTPConnector ChatServiceConnector,
+	healthConnector HealthConnector,
+	readinessCfg config.ReadinessConfig,
 ) *Server {
 	router := fiber.New()
 	logger := slog.Default()
@@ -57,7 +61,12 @@ func New(
 	router.Use(requestid.New())
 	router.Use(limiter.New(limiter.Config{Max: cfg.RPS}))
 	router.Use(recoverer.New())
-	router.Use(healthcheck.New())
+	// /readyz is served by s.readiness, which checks the backing services
+	router.Use(healthcheck.New(healthcheck.Config{
+		Next: func(c *fiber.Ctx) bool {
+			return c.Path() == healthcheck.DefaultReadinessEndpoint
+		},
+	}))
 
 	// Init http metrics
 	prometheus := fiberprometheus.New(serviceName)
@@ -77,16 +86,22 @@ func New(
 		userConnector:     userConnector,
 		chatConnector:     chatConnector,
 		chatHTTPConnector: chatHTTPConnector,
+		healthConnector:   healthConnector,
+		readinessCfg:      readinessCfg,
 		validator:         validator.New(),
 	}
 
-	// Setup routes
+	s.setupRoutes()
+
+	return s
+}
+
+// setupRoutes registers the full route table on s.router
+func (s *Server) setupRoutes() {
 	s.setupPublicRoutes()
 	s.setupWebSocketRoutes()
 	s.setupCookieAuthRoutes()
 	s.setupProtectedRoutes()
-
-	return s
 }
 
 // setupWebSocketRoutes sets up WebSocket routes for real-time features
@@ -94,11 +109,18 @@ func (s *Server) setupWebSocketRoutes() {
 	// WebSocket chat endpoint - token via query param (browsers can't set Authorization header on WS)
 	wsGroup := s.router.Group("/ws/chat", s.wsAuthMiddleware())
 	//s.router.Use("/ws/chat/:userID", s.wsAuthMiddleware(), s.WebSocketUpgradeHandler)
-	wsGroup.Get("/:userID", websocket.New(s.handleWebSocketChat))
+	// Only the owner of the chat or an admin may open it: the connection can regenerate, edit and cancel answers
+	wsGroup.Get("/:userID",
+		requireSelfOrRole("userID", string(domain.RoleAdmin), string(domain.RoleSuperAdmin)),
+		websocket.New(s.handleWebSocketChat),
+	)
 }
 
 // setupPublicRoutes sets up public routes that don't require authentication
 func (s *Server) setupPublicRoutes() {
+	// Readiness - every backing service answers $SRV.PING over NATS
+	s.router.Get(healthcheck.DefaultReadinessEndpoint, s.readiness)
+
 	// Auth routes - login doesn't require authentication
 	auth := s.router.Group("/api/v1/auth")
 	auth.Post("/login", s.login)
@@ -106,6 +128,9 @@ func (s *Server) setupPublicRoutes() {
 	// Registration requests - creating request is public
 	registrationRequests := s.router.Group("/api/v1/registration-requests")
 	registrationRequests.Post("/", s.createRegistrationRequest)
+
+	// Shared chat transcripts - anyone with the link can read them
+	s.router.Get("/api/v1/shares/:token", s.getSharedChat)
 }
 
 // setupCookieAuthRoutes sets up routes that work with cookie-based refresh flow
@@ -194,8 +219,32 @@ func (s *Server) setupProtectedRoutes() {
 
 	// Chat sessions routes
 	chatSessions := protected.Group("/api/v1/chat")
-	chatSessions.Get("/:userID/sessions", s.getChatSessions)
-	chatSessions.Post("/:userID/sessions", s.createChatSession)
+	chatSessions.Post("/stream", s.streamChat)
+	chatSessions.Post("/regenerate", s.regenerateChat)
+	chatSessions.Post("/edit", s.editChatMessage)
+	chatSessions.Post("/branch", s.switchChatBranch)
+
+	// Per-user chat data: only the owner or an admin
+	userChats := chatSessions.Group("/:userID", requireSelfOrRole("userID", string(domain.RoleAdmin), string(domain.RoleSuperAdmin)))
+	userChats.Get("/sessions", s.getChatSessions)
+	userChats.Post("/sessions", s.createChatSession)
+	userChats.Patch("/sessions/:sessionID", s.updateChatSession)
+	userChats.Delete("/sessions/:sessionID", s.deleteChatSession)
+	userChats.Get("/sessions/:sessionID/messages", s.getChatSessionMessages)
+	userChats.Put("/sessions/:sessionID/messages/:messageID/feedback", s.setChatMessageFeedback)
+	userChats.Get("/search", s.searchChatMessages)
+	userChats.Post("/sessions/:sessionID/shares", s.shareChatSession)
+	userChats.Get("/sessions/:sessionID/shares", s.getChatSessionShares)
+	userChats.Delete("/shares/:token", s.revokeChatSessionShare)
+
+	// Chat corpora (per-philosopher collections and personas)
+	// GET - any authenticated user, POST / PUT / DELETE - Admin and SuperAdmin only
+	corpora := protected.Group("/api/v1/corpora")
+	corpora.Get("/", s.listCorpora)
+	corpora.Get("/:id", s.getCorpus)
+	corpora.Post("/", adminOnly, s.createCorpus)
+	corpora.Put("/:id", adminOnly, s.updateCorpus)
+	corpora.Delete("/:id", adminOnly, s.deleteCorpus)
 
 	// Semantic search route — any authenticated user
 	protected.Get("/api/v1/search", s.semanticSearch)
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/artmexbet/raibecas/services/gateway/internal/domain"
+)
+
+const (
+	testAccessToken = "test-access-token"
+	testFingerprint = "test-fingerprint"
+)
+
+// routedApp serves the gateway route table from setupRoutes. Requests are sent
+// with a valid access token when the app was built for a user.
+type routedApp struct {
+	app  *fiber.App
+	user *AuthUser
+}
+
+// newRoutedApp registers the real routes on a bare router and stubs token
+// validation to authenticate user, so role checks and route wiring are tested
+// exactly as served. A nil user sends requests without credentials.
+func newRoutedApp(t *testing.T, srv *Server, user *AuthUser) *routedApp {
+	t.Helper()
+
+	if srv.validator == nil {
+		srv.validator = validator.New()
+	}
+	if srv.authConnector == nil {
+		auth := NewMockAuthServiceConnector(t)
+		if user != nil {
+			auth.EXPECT().
+				ValidateToken(mock.Anything, testAccessToken, testFingerprint).
+				Return(&domain.ValidateTokenResponse{Valid: true, UserID: user.ID, Role: user.Role}, nil).
+				Maybe()
+			auth.EXPECT().
+				ValidateTokenWS(mock.Anything, testAccessToken).
+				Return(&domain.ValidateTokenResponse{Valid: true, UserID: user.ID, Role: user.Role}, nil).
+				Maybe()
+		}
+		srv.authConnector = auth
+	}
+	srv.router = fiber.New()
+	srv.setupRoutes()
+
+	return &routedApp{app: srv.router, user: user}
+}
+
+// Test sends req through the router, see fiber.App.Test
+func (a *routedApp) Test(req *http.Request) (*http.Response, error) {
+	if a.user != nil {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+testAccessToken)
+		req.AddCookie(&http.Cookie{Name: CookieFingerprint, Value: testFingerprint})
+	}
+	return a.app.Test(req, int((5 * time.Second).Milliseconds()))
+}
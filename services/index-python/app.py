@@ -275,7 +275,7 @@ class App:
         }
 
     @staticmethod
-    def _event_metadata(payload: Dict[str, Any], version: Any, source: str) -> Dict[str, str]:
+    def _event_metadata(payload: Dict[str, Any], version: Any, source: str) -> Dict[str, Any]:
         participants = payload.get("participants") or []
         tags = payload.get("tags") or []
 
@@ -285,6 +285,8 @@ class App:
         participant_names = [str(item.get("name", "")).strip() for item in participants if isinstance(item, dict) and item.get("name")]
         participant_roles = [str(item.get("type_title", "")).strip() for item in participants if isinstance(item, dict) and item.get("type_title")]
         tag_titles = [str(item.get("title", "")).strip() for item in tags if isinstance(item, dict) and item.get("title")]
+        author_ids = [str(item["author_id"]) for item in participants if isinstance(item, dict) and item.get("author_id")]
+        tag_ids = [int(item["id"]) for item in tags if isinstance(item, dict) and item.get("id") is not None]
 
         metadata = {
             "category_id": _safe_string(payload.get("category_id")),
@@ -297,6 +299,10 @@ class App:
             "participant_names": " | ".join(participant_names),
             "participant_roles": " | ".join(participant_roles),
             "tag_titles": " | ".join(tag_titles),
+            # Filterable fields used by the chat service to scope retrieval.
+            "author_ids": author_ids,
+            "tag_ids": tag_ids,
+            "is_public": bool(payload.get("is_public", False)),
         }
 
         for index, participant in enumerate(participants):
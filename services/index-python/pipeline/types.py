@@ -13,7 +13,7 @@ class TextChunk:
 class DocumentIndexRequest:
     title: str
     path: Optional[str] = None
-    metadata: Dict[str, str] = field(default_factory=dict)
+    metadata: Dict[str, Any] = field(default_factory=dict)
     content: Optional[str] = None
     document_id: Optional[str] = None
 
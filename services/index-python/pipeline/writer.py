@@ -8,6 +8,15 @@ from qdrant_client.http.exceptions import UnexpectedResponse
 
 from .config import QdrantConfig
 
+# Payload fields the chat service filters retrieval by.
+_PAYLOAD_INDEXES = {
+    "document_id": qdrant_models.PayloadSchemaType.KEYWORD,
+    "author_ids": qdrant_models.PayloadSchemaType.KEYWORD,
+    "tag_ids": qdrant_models.PayloadSchemaType.INTEGER,
+    "publication_date": qdrant_models.PayloadSchemaType.DATETIME,
+    "is_public": qdrant_models.PayloadSchemaType.BOOL,
+}
+
 
 class QdrantWriter:
     def __init__(self, cfg: QdrantConfig, client: Optional[QdrantClient] = None):
@@ -37,6 +46,12 @@ class QdrantWriter:
                         collection_name=self.cfg.collection_name,
                         vectors_config=qdrant_models.VectorParams(size=target_size, distance=distance),
                     )
+                for field_name, schema in _PAYLOAD_INDEXES.items():
+                    self.client.create_payload_index(
+                        collection_name=self.cfg.collection_name,
+                        field_name=field_name,
+                        field_schema=schema,
+                    )
 
             await asyncio.to_thread(create_if_missing)
             self._collection_vector_size = target_size
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,7 +12,6 @@ import (
 
 	"github.com/nats-io/nats.go"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/artmexbet/raibecas/libs/natsw"
@@ -92,6 +90,7 @@ func New() (*App, error) {
 	client := natsw.NewClient(natsConn,
 		natsw.WithLogger(slog.Default()),
 		natsw.WithRecover(),
+		natsw.WithMetrics(natsw.NewMetrics(prometheus.DefaultRegisterer)),
 		natsw.WithMiddleware(metrics.Middleware),
 		natsw.WithTracer(natsTracer),
 		natsw.WithMiddleware(natsw.TraceHandlerMiddleware(natsTracer)),
@@ -101,7 +100,15 @@ func New() (*App, error) {
 	svc := service.New(pg, pg, pg, metrics, serviceTracer)
 	h := handler.New(svc)
 
-	srv := server.New(client, h)
+	natsService := natsw.NewService(client, natsw.ServiceConfig{
+		Name:          "users",
+		Version:       cfg.Telemetry.ServiceVersion,
+		Description:   "Users and registration requests",
+		QueueGroup:    cfg.NATS.QueueGroup,
+		MaxConcurrent: cfg.NATS.MaxConcurrent,
+		DrainTimeout:  cfg.NATS.DrainTimeout,
+	})
+	srv := server.New(natsService, h)
 
 	// Create outbox processor
 	publisher := natspublisher.NewPublisher(client)
@@ -124,7 +131,7 @@ func (a *App) Run() error {
 		return fmt.Errorf("failed to start server: %w", err)
 	}
 
-	metricsSrv := a.startMetricsServer()
+	metricsSrv := natsw.ServeMetrics(fmt.Sprintf(":%d", a.cfg.Metrics.Port))
 
 	metricsCtx, cancelMetrics := context.WithCancel(context.Background())
 	go a.runMetricCollectors(metricsCtx)
@@ -140,6 +147,11 @@ func (a *App) Run() error {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
+
+	// Finish in-flight requests before closing connections
+	if err := a.server.Stop(context.Background()); err != nil {
+		slog.Error("failed to drain nats endpoints", "error", err)
+	}
 	cancelMetrics()
 	cancelOutbox()
 
@@ -159,25 +171,6 @@ func (a *App) Run() error {
 	return nil
 }
 
-func (a *App) startMetricsServer() *http.Server {
-	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.Handler())
-
-	srv := &http.Server{
-		Addr:    fmt.Sprintf(":%d", a.cfg.Metrics.Port),
-		Handler: mux,
-	}
-
-	go func() {
-		slog.Info("starting metrics server", "addr", srv.Addr)
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			slog.Error("metrics server failed", "error", err)
-		}
-	}()
-
-	return srv
-}
-
 func (a *App) runMetricCollectors(ctx context.Context) {
 	a.updateUserCountMetrics(ctx)
 
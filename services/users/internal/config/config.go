@@ -8,8 +8,11 @@ import (
 )
 
 type NATS struct {
-	Host string `yaml:"host" env:"HOST" env-default:"localhost"`
-	Port int    `yaml:"port" env:"PORT" env-default:"4222"`
+	Host          string        `yaml:"host" env:"HOST" env-default:"localhost"`
+	Port          int           `yaml:"port" env:"PORT" env-default:"4222"`
+	QueueGroup    string        `yaml:"queue_group" env:"QUEUE_GROUP" env-default:"users"`
+	MaxConcurrent int           `yaml:"max_concurrent" env:"MAX_CONCURRENT" env-default:"32"`
+	DrainTimeout  time.Duration `yaml:"drain_timeout" env:"DRAIN_TIMEOUT" env-default:"20s"`
 }
 
 func (n *NATS) GetURL() string {
@@ -10,7 +10,10 @@ import (
 )
 
 type Metrics struct {
-	OpsProcessed    *prometheus.CounterVec
+	// Deprecated: replaced by natsw_handled_messages_total, kept for one release
+	// so that dashboards and alerts can be migrated.
+	OpsProcessed *prometheus.CounterVec
+	// Deprecated: replaced by natsw_handler_duration_seconds, kept for one release.
 	RequestDuration *prometheus.HistogramVec
 	UsersTotal      prometheus.Gauge
 	UserRegistered  prometheus.Counter
@@ -26,12 +29,12 @@ func NewMetrics(reg prometheus.Registerer) *Metrics {
 	m := &Metrics{
 		OpsProcessed: factory.NewCounterVec(prometheus.CounterOpts{
 			Name: "nats_requests_total",
-			Help: "The total number of processed NATS requests",
+			Help: "Deprecated: use natsw_handled_messages_total. The total number of processed NATS requests",
 		}, []string{"subject", "status"}),
 
 		RequestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Name:    "nats_request_duration_seconds",
-			Help:    "A histogram of the request duration.",
+			Help:    "Deprecated: use natsw_handler_duration_seconds. A histogram of the request duration.",
 			Buckets: prometheus.DefBuckets,
 		}, []string{"subject", "status"}),
 
@@ -49,6 +52,8 @@ func NewMetrics(reg prometheus.Registerer) *Metrics {
 	return m
 }
 
+// Middleware records the deprecated nats_requests_total and nats_request_duration_seconds.
+// natsw.WithMetrics records the same data; remove it once dashboards use the natsw_* series.
 func (m *Metrics) Middleware(next natsw.HandlerFunc) natsw.HandlerFunc {
 	return func(msg *natsw.Message) error {
 		start := time.Now()
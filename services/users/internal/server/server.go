@@ -1,34 +1,47 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/artmexbet/raibecas/libs/natsw"
 
 	"github.com/artmexbet/raibecas/services/users/internal/handler"
 )
 
 type Server struct {
-	client  *natsw.Client
+	service *natsw.Service
 	handler *handler.Handler
 }
 
-func New(client *natsw.Client, handler *handler.Handler) Server {
+func New(service *natsw.Service, handler *handler.Handler) Server {
 	return Server{
-		client:  client,
+		service: service,
 		handler: handler,
 	}
 }
 
-//nolint:errcheck // Subscriptions are fire-and-forget, errors are logged in the client
 func (s *Server) Start() error {
-	s.client.Subscribe("users.list", s.handler.HandleListUsers)
-	s.client.Subscribe("users.get", s.handler.HandleGetUser)
-	s.client.Subscribe("users.update", s.handler.HandleUpdateUser)
-	s.client.Subscribe("users.delete", s.handler.HandleDeleteUser)
+	err := errors.Join(
+		s.service.Handle("users.list", s.handler.HandleListUsers),
+		s.service.Handle("users.get", s.handler.HandleGetUser),
+		s.service.Handle("users.update", s.handler.HandleUpdateUser),
+		s.service.Handle("users.delete", s.handler.HandleDeleteUser),
 
-	s.client.Subscribe("users.registration.create", s.handler.HandleCreateRegistration)
-	s.client.Subscribe("users.registration.list", s.handler.HandleListRegistrations)
-	s.client.Subscribe("users.registration.approve", s.handler.HandleApproveRegistration)
-	s.client.Subscribe("users.registration.reject", s.handler.HandleRejectRegistration)
+		s.service.Handle("users.registration.create", s.handler.HandleCreateRegistration),
+		s.service.Handle("users.registration.list", s.handler.HandleListRegistrations),
+		s.service.Handle("users.registration.approve", s.handler.HandleApproveRegistration),
+		s.service.Handle("users.registration.reject", s.handler.HandleRejectRegistration),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register endpoints: %w", err)
+	}
 
 	return nil
 }
+
+// Stop drains the endpoints, waiting for in-flight requests until ctx is done
+func (s *Server) Stop(ctx context.Context) error {
+	return s.service.Drain(ctx)
+}